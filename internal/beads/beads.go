@@ -727,6 +727,8 @@ func (b *Beads) Create(opts CreateOptions) (*Issue, error) {
 		args = append(args, "--actor="+actor)
 	}
 
+	// Not retried: bd picks the ID, so a failed attempt that still committed
+	// can't be told apart from one that didn't, and a retry would duplicate it.
 	out, err := b.run(args...)
 	if err != nil {
 		return nil, err
@@ -780,17 +782,7 @@ func (b *Beads) CreateWithID(id string, opts CreateOptions) (*Issue, error) {
 		args = append(args, "--actor="+actor)
 	}
 
-	out, err := b.run(args...)
-	if err != nil {
-		return nil, err
-	}
-
-	var issue Issue
-	if err := json.Unmarshal(out, &issue); err != nil {
-		return nil, fmt.Errorf("parsing bd create output: %w", err)
-	}
-
-	return &issue, nil
+	return b.createWithIDRetry(id, args...)
 }
 
 // Update updates an existing issue.
@@ -826,7 +818,7 @@ func (b *Beads) Update(id string, opts UpdateOptions) error {
 		}
 	}
 
-	_, err := b.run(args...)
+	_, err := b.runWithRetry(args...)
 	return err
 }
 
@@ -116,6 +116,40 @@ func (b *Beads) ListOpenSlingContexts() ([]*Issue, error) {
 	return issues, nil
 }
 
+// SlingContextEntry is one open sling context as seen by an audit listing.
+// ParseError is set (and Fields is nil) when the description isn't valid
+// sling context JSON, so corrupt contexts are reported instead of dropped.
+type SlingContextEntry struct {
+	Issue      *Issue
+	Fields     *capacity.SlingContextFields
+	ParseError bool
+}
+
+// ListSlingContextEntries returns every open sling context in this database
+// with its parsed fields, for auditing what is slung across the town.
+func (b *Beads) ListSlingContextEntries() ([]SlingContextEntry, error) {
+	contexts, err := b.ListOpenSlingContexts()
+	if err != nil {
+		return nil, err
+	}
+	return NewSlingContextEntries(contexts), nil
+}
+
+// NewSlingContextEntries parses each context bead's description. A malformed
+// description marks that entry with ParseError rather than failing the list.
+func NewSlingContextEntries(contexts []*Issue) []SlingContextEntry {
+	entries := make([]SlingContextEntry, 0, len(contexts))
+	for _, ctx := range contexts {
+		fields := ParseSlingContextFields(ctx.Description)
+		entries = append(entries, SlingContextEntry{
+			Issue:      ctx,
+			Fields:     fields,
+			ParseError: fields == nil,
+		})
+	}
+	return entries
+}
+
 // CloseSlingContext closes a sling context bead with a reason.
 // Idempotent: suppresses "already closed" errors so retries are safe.
 func (b *Beads) CloseSlingContext(contextID, reason string) error {
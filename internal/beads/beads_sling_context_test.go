@@ -154,3 +154,44 @@ func TestFormatSlingContextDescription_SpecialChars(t *testing.T) {
 		t.Errorf("LastFailure roundtrip failed:\ngot:  %q\nwant: %q", parsed.LastFailure, fields.LastFailure)
 	}
 }
+
+func TestNewSlingContextEntries_ReportsCorruptContexts(t *testing.T) {
+	contexts := []*Issue{
+		{ID: "hq-ctx1", Description: FormatSlingContextDescription(&capacity.SlingContextFields{
+			Version: 1, WorkBeadID: "gt-aaa", TargetRig: "gastown",
+		})},
+		{ID: "hq-ctx2", Description: "not valid json {{{"},
+		{ID: "hq-ctx3", Description: FormatSlingContextDescription(&capacity.SlingContextFields{
+			Version: 1, WorkBeadID: "bd-bbb", TargetRig: "beads",
+		})},
+	}
+
+	entries := NewSlingContextEntries(contexts)
+	if len(entries) != 3 {
+		t.Fatalf("got %d entries, want 3", len(entries))
+	}
+
+	for i, want := range []struct {
+		id         string
+		workBeadID string
+		parseError bool
+	}{
+		{"hq-ctx1", "gt-aaa", false},
+		{"hq-ctx2", "", true},
+		{"hq-ctx3", "bd-bbb", false},
+	} {
+		got := entries[i]
+		if got.Issue.ID != want.id || got.ParseError != want.parseError {
+			t.Errorf("entry %d: ID=%q ParseError=%v, want ID=%q ParseError=%v",
+				i, got.Issue.ID, got.ParseError, want.id, want.parseError)
+			continue
+		}
+		if want.parseError {
+			if got.Fields != nil {
+				t.Errorf("entry %d: Fields = %+v, want nil for corrupt context", i, got.Fields)
+			}
+		} else if got.Fields == nil || got.Fields.WorkBeadID != want.workBeadID {
+			t.Errorf("entry %d: Fields = %+v, want WorkBeadID %q", i, got.Fields, want.workBeadID)
+		}
+	}
+}
@@ -45,6 +45,21 @@ func FindTownRoot(startDir string) string {
 	}
 }
 
+// DoltDataDir returns the town's Dolt data directory: the dolt_data_dir
+// recorded in the town's beads metadata (see doltserver.SetDataDir), or
+// <townRoot>/.dolt-data if none is recorded.
+func DoltDataDir(townRoot string) string {
+	if data, err := os.ReadFile(filepath.Join(townRoot, ".beads", "metadata.json")); err == nil {
+		var meta struct {
+			DoltDataDir string `json:"dolt_data_dir"`
+		}
+		if json.Unmarshal(data, &meta) == nil && meta.DoltDataDir != "" {
+			return meta.DoltDataDir
+		}
+	}
+	return filepath.Join(townRoot, ".dolt-data")
+}
+
 // ResolveRoutingTarget determines which beads directory a bead ID will route to.
 // It extracts the prefix from the bead ID and looks up the corresponding route.
 // Returns the resolved beads directory path, following any redirects.
@@ -178,7 +193,8 @@ func ensureDatabaseInitialized(beadsDir string) error {
 	// Check for metadata.json (server mode — gastown's exclusive mode).
 	// In server mode, .beads/ may contain only metadata.json with no local dolt/ dir.
 	// This mirrors the deep check in bdDatabaseExists (internal/rig/manager.go):
-	// parse metadata.json and verify the referenced database exists in .dolt-data/.
+	// parse metadata.json and verify the referenced database exists in the
+	// town's Dolt data directory (see DoltDataDir).
 	// metadata.json can be git-tracked from another workspace where the Dolt server
 	// had this database, but this may be a fresh server without it.
 	metadataFile := filepath.Join(beadsDir, "metadata.json")
@@ -195,7 +211,7 @@ func ensureDatabaseInitialized(beadsDir string) error {
 			if townRoot == "" {
 				return nil // Can't find town root — assume initialized
 			}
-			dbDir := filepath.Join(townRoot, ".dolt-data", meta.DoltDatabase)
+			dbDir := filepath.Join(DoltDataDir(townRoot), meta.DoltDatabase)
 			if _, err := os.Stat(dbDir); !os.IsNotExist(err) {
 				return nil // Database exists (or stat error — assume initialized)
 			}
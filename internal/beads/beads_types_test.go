@@ -1,6 +1,7 @@
 package beads
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
@@ -261,6 +262,33 @@ func TestEnsureDatabaseInitialized(t *testing.T) {
 		}
 	})
 
+	t.Run("metadata.json with db in custom data dir — skip init", func(t *testing.T) {
+		// The town records a custom Dolt data dir; .dolt-data doesn't exist.
+		townDir := t.TempDir()
+		os.MkdirAll(filepath.Join(townDir, "mayor"), 0755)
+		os.WriteFile(filepath.Join(townDir, "mayor", "town.json"), []byte("{}"), 0644)
+		dataDir := filepath.Join(t.TempDir(), "shared-dolt")
+		os.MkdirAll(filepath.Join(dataDir, "testdb"), 0755)
+		os.MkdirAll(filepath.Join(townDir, ".beads"), 0755)
+		townMeta := fmt.Sprintf(`{"dolt_data_dir":%q}`, dataDir)
+		os.WriteFile(filepath.Join(townDir, ".beads", "metadata.json"), []byte(townMeta), 0644)
+
+		beadsDir := filepath.Join(townDir, "testrig", ".beads")
+		os.MkdirAll(beadsDir, 0755)
+		meta := `{"dolt_mode":"server","dolt_database":"testdb"}`
+		os.WriteFile(filepath.Join(beadsDir, "metadata.json"), []byte(meta), 0644)
+
+		if got := DoltDataDir(townDir); got != dataDir {
+			t.Fatalf("DoltDataDir = %q, want %q", got, dataDir)
+		}
+		// A missing bd would make an init attempt fail; finding the database
+		// in the custom dir means no init is attempted.
+		t.Setenv("PATH", t.TempDir())
+		if err := ensureDatabaseInitialized(beadsDir); err != nil {
+			t.Errorf("expected nil error when the db exists in the custom data dir, got: %v", err)
+		}
+	})
+
 	t.Run("metadata.json exists but db missing — attempts init", func(t *testing.T) {
 		// metadata.json references a database that doesn't exist in .dolt-data/
 		townDir := t.TempDir()
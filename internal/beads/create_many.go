@@ -0,0 +1,216 @@
+package beads
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// BeadSpec describes one bead for CreateMany.
+type BeadSpec struct {
+	Title       string
+	Type        string // Converted to a gt:<type> label, as in Create
+	Priority    int    // Omitted when negative
+	Description string
+	Labels      []string
+}
+
+// CreateMany creates all specs with a single bd invocation and returns the
+// new IDs in spec order. Specs are written to a markdown plan and passed to
+// `bd create --file`, so a large convoy costs one bd process instead of one
+// per bead.
+//
+// If a spec can't be expressed in the plan format, the beads are created one
+// at a time with Create instead. If the batch fails with a transient Dolt
+// error (see isDoltOrWispError), it may have committed some beads before
+// failing, so only the specs that didn't make it are created one at a time
+// (see createMissing).
+func (b *Beads) CreateMany(specs []BeadSpec) ([]string, error) {
+	if len(specs) == 0 {
+		return nil, nil
+	}
+	for _, spec := range specs {
+		if IsFlagLikeTitle(spec.Title) {
+			return nil, fmt.Errorf("refusing to create bead: %w (got %q)", ErrFlagTitle, spec.Title)
+		}
+	}
+
+	if !batchable(specs) {
+		return b.createEach(specs)
+	}
+
+	batchLabel, err := newBatchLabel()
+	if err != nil {
+		return nil, err
+	}
+	ids, err := b.createBatch(specs, batchLabel)
+	if err != nil && isDoltOrWispError(err) {
+		return b.createMissing(specs, batchLabel, err)
+	}
+	return ids, err
+}
+
+// batchLabelPrefix marks the beads of one CreateMany batch, so a failed batch
+// can be reconciled against what it actually committed.
+const batchLabelPrefix = "internal:batch-"
+
+// newBatchLabel returns a label unique to one CreateMany batch.
+func newBatchLabel() (string, error) {
+	nonce := make([]byte, 6)
+	if _, err := rand.Read(nonce); err != nil {
+		return "", fmt.Errorf("generating batch label: %w", err)
+	}
+	return batchLabelPrefix + hex.EncodeToString(nonce), nil
+}
+
+// createBatch creates specs through one `bd create --file` run, tagging every
+// bead with batchLabel.
+func (b *Beads) createBatch(specs []BeadSpec, batchLabel string) ([]string, error) {
+	tagged := make([]BeadSpec, len(specs))
+	for i, spec := range specs {
+		spec.Labels = append(append([]string(nil), spec.Labels...), batchLabel)
+		tagged[i] = spec
+	}
+
+	plan, err := os.CreateTemp("", "gt-beads-*.md")
+	if err != nil {
+		return nil, fmt.Errorf("creating bead plan file: %w", err)
+	}
+	defer os.Remove(plan.Name())
+	if _, err := plan.WriteString(renderBeadPlan(tagged)); err != nil {
+		plan.Close()
+		return nil, fmt.Errorf("writing bead plan file: %w", err)
+	}
+	plan.Close()
+
+	args := []string{"create", "--json", "--file=" + plan.Name()}
+	if actor := b.getActor(); actor != "" {
+		args = append(args, "--actor="+actor)
+	}
+	out, err := b.run(args...)
+	if err != nil {
+		return nil, err
+	}
+
+	var issues []Issue
+	if err := json.Unmarshal(out, &issues); err != nil {
+		return nil, fmt.Errorf("parsing bd create --file output: %w", err)
+	}
+	if len(issues) != len(specs) {
+		return nil, fmt.Errorf("bd create --file created %d beads, want %d", len(issues), len(specs))
+	}
+	ids := make([]string, len(issues))
+	for i, issue := range issues {
+		ids[i] = issue.ID
+	}
+	return ids, nil
+}
+
+// createMissing recovers from a batch that failed with batchErr. The beads
+// the batch did commit carry batchLabel; they are matched to specs by title
+// and only the remaining specs are created, so nothing is created twice. If
+// the committed beads can't be listed, batchErr is returned rather than
+// risking duplicates.
+func (b *Beads) createMissing(specs []BeadSpec, batchLabel string, batchErr error) ([]string, error) {
+	committed, err := b.List(ListOptions{Status: "all", Label: batchLabel, Priority: -1})
+	if err != nil {
+		return nil, fmt.Errorf("%w (checking which beads were created: %v)", batchErr, err)
+	}
+	byTitle := make(map[string][]string)
+	for _, issue := range committed {
+		byTitle[issue.Title] = append(byTitle[issue.Title], issue.ID)
+	}
+
+	ids := make([]string, len(specs))
+	for i, spec := range specs {
+		if found := byTitle[spec.Title]; len(found) > 0 {
+			ids[i], byTitle[spec.Title] = found[0], found[1:]
+			continue
+		}
+		id, err := b.createOne(spec)
+		if err != nil {
+			return nil, err
+		}
+		ids[i] = id
+	}
+	return ids, nil
+}
+
+// createEach creates specs one bd invocation at a time.
+func (b *Beads) createEach(specs []BeadSpec) ([]string, error) {
+	ids := make([]string, 0, len(specs))
+	for _, spec := range specs {
+		id, err := b.createOne(spec)
+		if err != nil {
+			return ids, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// createOne creates a single spec with Create, then adds its labels.
+func (b *Beads) createOne(spec BeadSpec) (string, error) {
+	issue, err := b.Create(CreateOptions{
+		Title:       spec.Title,
+		Type:        spec.Type,
+		Priority:    spec.Priority,
+		Description: spec.Description,
+	})
+	if err != nil {
+		return "", fmt.Errorf("creating %q: %w", spec.Title, err)
+	}
+	if len(spec.Labels) > 0 {
+		if err := b.Update(issue.ID, UpdateOptions{AddLabels: spec.Labels}); err != nil {
+			return "", fmt.Errorf("labeling %s: %w", issue.ID, err)
+		}
+	}
+	return issue.ID, nil
+}
+
+// batchable reports whether every spec can be written to a bd markdown plan
+// without its text being mistaken for plan structure.
+func batchable(specs []BeadSpec) bool {
+	for _, spec := range specs {
+		if spec.Title == "" || strings.ContainsAny(spec.Title, "\r\n") {
+			return false
+		}
+		for _, line := range strings.Split(spec.Description, "\n") {
+			if strings.HasPrefix(strings.TrimSpace(line), "#") {
+				return false
+			}
+		}
+		for _, label := range spec.Labels {
+			if strings.ContainsAny(label, ",\r\n") {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// renderBeadPlan renders specs in the markdown format read by
+// `bd create --file`: one "## Title" section per bead with "###" fields.
+func renderBeadPlan(specs []BeadSpec) string {
+	var sb strings.Builder
+	for _, spec := range specs {
+		fmt.Fprintf(&sb, "## %s\n\n", spec.Title)
+		if spec.Priority >= 0 {
+			fmt.Fprintf(&sb, "### Priority\n%d\n\n", spec.Priority)
+		}
+		if spec.Description != "" {
+			fmt.Fprintf(&sb, "### Description\n%s\n\n", strings.TrimSpace(spec.Description))
+		}
+		labels := append([]string(nil), spec.Labels...)
+		if spec.Type != "" {
+			labels = append([]string{"gt:" + spec.Type}, labels...)
+		}
+		if len(labels) > 0 {
+			fmt.Fprintf(&sb, "### Labels\n%s\n\n", strings.Join(labels, ", "))
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,44 @@
+//go:build integration
+
+package beads
+
+import (
+	"os/exec"
+	"testing"
+)
+
+// TestCreateMany_RealBd creates ten beads in one batch against a real bd and
+// verifies every returned ID resolves.
+func TestCreateMany_RealBd(t *testing.T) {
+	if _, err := exec.LookPath("bd"); err != nil {
+		t.Skip("bd not found in PATH — skipping integration test")
+	}
+
+	b := NewIsolated(t.TempDir())
+	if err := b.Init("test"); err != nil {
+		t.Fatalf("bd init: %v", err)
+	}
+
+	specs := make([]BeadSpec, 10)
+	for i := range specs {
+		specs[i] = BeadSpec{Title: "Batch bead " + string(rune('A'+i)), Type: "task", Priority: 2}
+	}
+
+	ids, err := b.CreateMany(specs)
+	if err != nil {
+		t.Fatalf("CreateMany() error: %v", err)
+	}
+	if len(ids) != len(specs) {
+		t.Fatalf("CreateMany() returned %d IDs, want %d", len(ids), len(specs))
+	}
+	for i, id := range ids {
+		issue, err := b.Show(id)
+		if err != nil {
+			t.Errorf("Show(%s): %v", id, err)
+			continue
+		}
+		if issue.Title != specs[i].Title {
+			t.Errorf("bead %s title = %q, want %q", id, issue.Title, specs[i].Title)
+		}
+	}
+}
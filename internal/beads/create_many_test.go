@@ -0,0 +1,170 @@
+package beads
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestRenderBeadPlan(t *testing.T) {
+	got := renderBeadPlan([]BeadSpec{
+		{Title: "First", Type: "task", Priority: 1, Description: "Do the thing", Labels: []string{"frontend"}},
+		{Title: "Second", Priority: -1},
+	})
+	want := "## First\n\n### Priority\n1\n\n### Description\nDo the thing\n\n### Labels\ngt:task, frontend\n\n## Second\n\n"
+	if got != want {
+		t.Errorf("renderBeadPlan() =\n%q\nwant\n%q", got, want)
+	}
+}
+
+func TestBatchable(t *testing.T) {
+	tests := []struct {
+		name string
+		spec BeadSpec
+		want bool
+	}{
+		{"plain", BeadSpec{Title: "Fix it", Description: "line one\nline two"}, true},
+		{"multiline title", BeadSpec{Title: "Fix\nit"}, false},
+		{"heading in description", BeadSpec{Title: "Fix it", Description: "intro\n## Not a bead"}, false},
+		{"comma in label", BeadSpec{Title: "Fix it", Labels: []string{"a,b"}}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := batchable([]BeadSpec{tt.spec}); got != tt.want {
+				t.Errorf("batchable(%+v) = %v, want %v", tt.spec, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestCreateMany_FallsBackOnCrash uses a bd stub whose batch create panics
+// and verifies CreateMany falls back to one create per bead.
+func TestCreateMany_FallsBackOnCrash(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("shell stubs not supported on windows")
+	}
+
+	binDir := t.TempDir()
+	callLog := filepath.Join(t.TempDir(), "calls.log")
+	stub := `#!/bin/sh
+echo "$*" >> "` + callLog + `"
+case "$*" in
+  *--file=*) echo "panic: runtime error: invalid memory address or nil pointer dereference" >&2; exit 2 ;;
+  *list*) echo "[]" ;;
+  *create*) n=$(grep -c create "` + callLog + `"); echo "{\"id\":\"test-$n\",\"title\":\"t\"}" ;;
+esac
+exit 0
+`
+	if err := os.WriteFile(filepath.Join(binDir, "bd"), []byte(stub), 0755); err != nil {
+		t.Fatalf("writing bd stub: %v", err)
+	}
+	t.Setenv("PATH", binDir+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	b := NewIsolated(t.TempDir())
+	ids, err := b.CreateMany([]BeadSpec{{Title: "one", Priority: 2}, {Title: "two", Priority: 2}})
+	if err != nil {
+		t.Fatalf("CreateMany() error: %v", err)
+	}
+	if len(ids) != 2 || ids[0] == ids[1] {
+		t.Errorf("CreateMany() ids = %v, want two distinct IDs", ids)
+	}
+
+	calls, _ := os.ReadFile(callLog)
+	var batch, single int
+	for _, line := range strings.Split(strings.TrimSpace(string(calls)), "\n") {
+		switch {
+		case strings.Contains(line, "--file="):
+			batch++
+		case strings.Contains(line, "create"):
+			single++
+		}
+	}
+	if batch != 1 || single != 2 {
+		t.Errorf("bd calls: %d batch, %d single; want 1 batch then 2 single creates:\n%s", batch, single, calls)
+	}
+}
+
+// TestCreateMany_FallbackSkipsCommittedBeads uses a bd stub whose batch
+// create commits the first bead before crashing, and verifies the fallback
+// reuses that bead and only creates the second.
+func TestCreateMany_FallbackSkipsCommittedBeads(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("shell stubs not supported on windows")
+	}
+
+	binDir := t.TempDir()
+	callLog := filepath.Join(t.TempDir(), "calls.log")
+	stub := `#!/bin/sh
+echo "$*" >> "` + callLog + `"
+case "$*" in
+  *--file=*) echo "panic: runtime error: invalid memory address or nil pointer dereference" >&2; exit 2 ;;
+  *list*--label=internal:batch-*) echo '[{"id":"test-one","title":"one"}]' ;;
+  *create*) echo '{"id":"test-two","title":"two"}' ;;
+esac
+exit 0
+`
+	if err := os.WriteFile(filepath.Join(binDir, "bd"), []byte(stub), 0755); err != nil {
+		t.Fatalf("writing bd stub: %v", err)
+	}
+	t.Setenv("PATH", binDir+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	b := NewIsolated(t.TempDir())
+	ids, err := b.CreateMany([]BeadSpec{{Title: "one", Priority: 2}, {Title: "two", Priority: 2}})
+	if err != nil {
+		t.Fatalf("CreateMany() error: %v", err)
+	}
+	if strings.Join(ids, ",") != "test-one,test-two" {
+		t.Errorf("CreateMany() ids = %v, want [test-one test-two]", ids)
+	}
+
+	calls, _ := os.ReadFile(callLog)
+	var single int
+	for _, line := range strings.Split(strings.TrimSpace(string(calls)), "\n") {
+		if strings.Contains(line, "create") && !strings.Contains(line, "--file=") {
+			single++
+		}
+	}
+	if single != 1 {
+		t.Errorf("bd ran %d single creates, want 1 (only the missing bead):\n%s", single, calls)
+	}
+}
+
+// TestCreateMany_FailedBatchUnlistable verifies that a failed batch whose
+// committed beads can't be listed returns the error instead of recreating
+// every bead.
+func TestCreateMany_FailedBatchUnlistable(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("shell stubs not supported on windows")
+	}
+
+	binDir := t.TempDir()
+	callLog := filepath.Join(t.TempDir(), "calls.log")
+	stub := `#!/bin/sh
+echo "$*" >> "` + callLog + `"
+case "$*" in
+  *--file=*) echo "panic: runtime error: invalid memory address or nil pointer dereference" >&2; exit 2 ;;
+  *list*) echo "dial tcp 127.0.0.1:3307: connect: connection refused" >&2; exit 1 ;;
+  *create*) echo '{"id":"test-x","title":"x"}' ;;
+esac
+exit 0
+`
+	if err := os.WriteFile(filepath.Join(binDir, "bd"), []byte(stub), 0755); err != nil {
+		t.Fatalf("writing bd stub: %v", err)
+	}
+	t.Setenv("PATH", binDir+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	b := NewIsolated(t.TempDir())
+	if _, err := b.CreateMany([]BeadSpec{{Title: "one", Priority: 2}}); err == nil {
+		t.Fatal("CreateMany() = nil error, want the batch failure")
+	}
+
+	calls, _ := os.ReadFile(callLog)
+	for _, line := range strings.Split(strings.TrimSpace(string(calls)), "\n") {
+		if strings.Contains(line, "create") && !strings.Contains(line, "--file=") {
+			t.Errorf("bd ran a single create after an unreconciled batch failure:\n%s", calls)
+			break
+		}
+	}
+}
@@ -0,0 +1,116 @@
+package beads
+
+import (
+	"errors"
+	"strings"
+)
+
+// BeadErrorClass is a coarse severity for errors from the bd/Dolt layer, so
+// callers can decide whether to retry, restart the server, or report the
+// problem to the user.
+type BeadErrorClass int
+
+const (
+	// BeadErrorUnknown is an error that matches no known pattern.
+	BeadErrorUnknown BeadErrorClass = iota
+	// BeadErrorTransient is a crashed subprocess or server hiccup; retrying
+	// (or restarting the server) is expected to help.
+	BeadErrorTransient
+	// BeadErrorCorruption means the database is missing tables or data is
+	// damaged; retrying won't help and the server may need repair.
+	BeadErrorCorruption
+	// BeadErrorUserError is a problem with the request itself, such as a
+	// constraint violation or a missing issue.
+	BeadErrorUserError
+)
+
+// String returns a human-readable class name.
+func (c BeadErrorClass) String() string {
+	switch c {
+	case BeadErrorTransient:
+		return "Transient"
+	case BeadErrorCorruption:
+		return "Corruption"
+	case BeadErrorUserError:
+		return "UserError"
+	default:
+		return "Unknown"
+	}
+}
+
+// transientDoltMarkers are error substrings (lowercased) from bd's Dolt
+// backend that indicate a transient server-side failure rather than a
+// problem with the request.
+var transientDoltMarkers = []string{
+	"database is read only",
+	"cannot update manifest",
+	"optimistic lock",
+	"serialization failure",
+	"lock wait timeout",
+	"try restarting transaction",
+	"connection refused",
+	"bad connection",
+	"invalid connection",
+	// doltserver.ErrQueryTimeout's text; beads can't import doltserver.
+	"dolt query timed out",
+}
+
+// corruptionDoltMarkers indicate missing schema or damaged storage.
+var corruptionDoltMarkers = []string{
+	"table not found",
+	"no such table",
+	"doesn't exist",
+	"corrupt",
+	"checksum",
+}
+
+// userErrorMarkers indicate the request itself was rejected.
+var userErrorMarkers = []string{
+	"unique constraint",
+	"duplicate entry",
+	"duplicate key",
+	"unknown flag",
+	"not found",
+	"invalid",
+}
+
+// ClassifyError assigns err to a BeadErrorClass. A nil error is
+// BeadErrorUnknown.
+func ClassifyError(err error) BeadErrorClass {
+	if err == nil {
+		return BeadErrorUnknown
+	}
+	if errors.Is(err, ErrNotFound) || errors.Is(err, ErrFlagTitle) {
+		return BeadErrorUserError
+	}
+	if isSubprocessCrash(err) {
+		return BeadErrorTransient
+	}
+	msg := strings.ToLower(err.Error())
+	switch {
+	case containsAny(msg, transientDoltMarkers):
+		return BeadErrorTransient
+	case containsAny(msg, corruptionDoltMarkers):
+		return BeadErrorCorruption
+	case containsAny(msg, userErrorMarkers):
+		return BeadErrorUserError
+	}
+	return BeadErrorUnknown
+}
+
+// isDoltOrWispError reports whether err is a transient failure of bd's Dolt
+// backend — a crashed bd/Dolt subprocess or a server hiccup — that is worth
+// retrying. Errors caused by the request itself (constraint violations,
+// missing issues, bad arguments) are not.
+func isDoltOrWispError(err error) bool {
+	return ClassifyError(err) == BeadErrorTransient
+}
+
+func containsAny(s string, substrs []string) bool {
+	for _, sub := range substrs {
+		if strings.Contains(s, sub) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,45 @@
+package beads
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestClassifyError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want BeadErrorClass
+	}{
+		{"nil error", nil, BeadErrorUnknown},
+		{"plain exit status", fmt.Errorf("bd create: exit status 1"), BeadErrorUnknown},
+		{"not found sentinel", ErrNotFound, BeadErrorUserError},
+		{"not found wrapped", fmt.Errorf("show gt-x: %w", ErrNotFound), BeadErrorUserError},
+		{"flag title", fmt.Errorf("refusing to create bead: %w", ErrFlagTitle), BeadErrorUserError},
+		{"segfault", fmt.Errorf("bd create: signal: segmentation fault"), BeadErrorTransient},
+		{"killed", fmt.Errorf("bd create: signal: killed"), BeadErrorTransient},
+		{"nil pointer", fmt.Errorf("bd create: nil pointer dereference"), BeadErrorTransient},
+		{"panic", fmt.Errorf("bd update: panic: runtime error"), BeadErrorTransient},
+		{"read only", fmt.Errorf("bd create: Error 1105: database is read only"), BeadErrorTransient},
+		{"manifest", fmt.Errorf("bd create: cannot update manifest: lock held"), BeadErrorTransient},
+		{"connection refused", fmt.Errorf("dial tcp 127.0.0.1:3307: connect: connection refused"), BeadErrorTransient},
+		{"invalid connection", fmt.Errorf("bd list: invalid connection"), BeadErrorTransient},
+		{"query timeout", fmt.Errorf("querying wanted item: %w", fmt.Errorf("dolt query timed out after 30s: context deadline exceeded")), BeadErrorTransient},
+		{"table missing", fmt.Errorf("bd list: table not found: wisps"), BeadErrorCorruption},
+		{"mysql table missing", fmt.Errorf("Error 1146: Table 'beads.issues' doesn't exist"), BeadErrorCorruption},
+		{"checksum", fmt.Errorf("dolt: chunk checksum mismatch"), BeadErrorCorruption},
+		{"unique constraint", fmt.Errorf("bd create: UNIQUE constraint failed"), BeadErrorUserError},
+		{"duplicate entry", fmt.Errorf("Error 1062: Duplicate entry 'gt-1' for key 'PRIMARY'"), BeadErrorUserError},
+		{"bad flag", fmt.Errorf("bd create: unknown flag: --bogus"), BeadErrorUserError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ClassifyError(tt.err); got != tt.want {
+				t.Errorf("ClassifyError(%v) = %v, want %v", tt.err, got, tt.want)
+			}
+			if got, want := isDoltOrWispError(tt.err), tt.want == BeadErrorTransient; got != want {
+				t.Errorf("isDoltOrWispError(%v) = %v, want %v", tt.err, got, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,79 @@
+package beads
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Retry policy for hot bd write paths (create with a fixed ID, update).
+const (
+	runRetryAttempts = 3
+	runRetryBackoff  = 250 * time.Millisecond
+)
+
+// WithRetry calls fn up to attempts times, retrying only while it fails with
+// a transient Dolt error (see isDoltOrWispError). The wait between attempts
+// starts at backoff and doubles each time. It returns nil on the first
+// success, a non-retryable error immediately, or the last error once
+// attempts are exhausted.
+func WithRetry(fn func() error, attempts int, backoff time.Duration) error {
+	if attempts < 1 {
+		attempts = 1
+	}
+	var err error
+	for i := 0; i < attempts; i++ {
+		if i > 0 {
+			time.Sleep(backoff)
+			backoff *= 2
+		}
+		if err = fn(); err == nil || !isDoltOrWispError(err) {
+			return err
+		}
+	}
+	return err
+}
+
+// runWithRetry runs a bd command, retrying transient Dolt failures. Only use
+// it for commands that are safe to repeat.
+func (b *Beads) runWithRetry(args ...string) ([]byte, error) {
+	var out []byte
+	err := WithRetry(func() error {
+		var runErr error
+		out, runErr = b.run(args...)
+		return runErr
+	}, runRetryAttempts, runRetryBackoff)
+	return out, err
+}
+
+// createWithIDRetry runs a bd create for the bead id, retrying transient Dolt
+// failures. An attempt that failed may still have committed the bead, so
+// before each retry the ID is looked up and an existing bead is returned
+// instead of being created twice.
+func (b *Beads) createWithIDRetry(id string, args ...string) (*Issue, error) {
+	var issue *Issue
+	attempt := 0
+	err := WithRetry(func() error {
+		attempt++
+		if attempt > 1 {
+			if existing, err := b.Show(id); err == nil {
+				issue = existing
+				return nil
+			}
+		}
+		out, err := b.run(args...)
+		if err != nil {
+			return err
+		}
+		var created Issue
+		if err := json.Unmarshal(out, &created); err != nil {
+			return fmt.Errorf("parsing bd create output: %w", err)
+		}
+		issue = &created
+		return nil
+	}, runRetryAttempts, runRetryBackoff)
+	if err != nil {
+		return nil, err
+	}
+	return issue, nil
+}
@@ -0,0 +1,122 @@
+package beads
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWithRetry_RetriesCrashThenSucceeds(t *testing.T) {
+	calls := 0
+	err := WithRetry(func() error {
+		calls++
+		if calls <= 2 {
+			return fmt.Errorf("bd create: signal: segmentation fault")
+		}
+		return nil
+	}, 5, time.Millisecond)
+	if err != nil {
+		t.Fatalf("WithRetry() = %v, want nil", err)
+	}
+	if calls != 3 {
+		t.Errorf("fn called %d times, want 3", calls)
+	}
+}
+
+func TestWithRetry_DoesNotRetryConstraintError(t *testing.T) {
+	calls := 0
+	want := errors.New("bd create: UNIQUE constraint failed: issues.id")
+	err := WithRetry(func() error {
+		calls++
+		return want
+	}, 5, time.Millisecond)
+	if !errors.Is(err, want) {
+		t.Errorf("WithRetry() = %v, want %v", err, want)
+	}
+	if calls != 1 {
+		t.Errorf("fn called %d times, want 1 (no retry)", calls)
+	}
+}
+
+func TestWithRetry_GivesUpAfterAttempts(t *testing.T) {
+	calls := 0
+	err := WithRetry(func() error {
+		calls++
+		return fmt.Errorf("bd update: database is read only")
+	}, 3, time.Millisecond)
+	if err == nil || calls != 3 {
+		t.Errorf("WithRetry() = %v after %d calls, want error after 3", err, calls)
+	}
+}
+
+func TestIsDoltOrWispError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"nil error", nil, false},
+		{"not found", ErrNotFound, false},
+		{"segfault", fmt.Errorf("bd create: signal: segmentation fault"), true},
+		{"panic", fmt.Errorf("bd update: panic: runtime error"), true},
+		{"read only", fmt.Errorf("bd create: Error 1105: database is read only"), true},
+		{"manifest", fmt.Errorf("bd create: cannot update manifest: lock held"), true},
+		{"connection refused", fmt.Errorf("bd list: dial tcp 127.0.0.1:3307: connect: connection refused"), true},
+		{"unique constraint", fmt.Errorf("bd create: UNIQUE constraint failed"), false},
+		{"bad flag", fmt.Errorf("bd create: unknown flag: --bogus"), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isDoltOrWispError(tt.err); got != tt.want {
+				t.Errorf("isDoltOrWispError(%v) = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestCreateWithID_RetryFindsCommittedBead uses a bd stub whose create
+// commits the bead but then reports a transient error, and verifies the
+// retry returns the existing bead instead of creating it again.
+func TestCreateWithID_RetryFindsCommittedBead(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("shell stubs not supported on windows")
+	}
+
+	binDir := t.TempDir()
+	stateDir := t.TempDir()
+	callLog := filepath.Join(stateDir, "calls.log")
+	marker := filepath.Join(stateDir, "created")
+	stub := `#!/bin/sh
+echo "$*" >> "` + callLog + `"
+case "$*" in
+  *create*) touch "` + marker + `"; echo "Error 1105: database is read only" >&2; exit 1 ;;
+  *show*)
+    if [ -f "` + marker + `" ]; then echo '[{"id":"gt-fixed","title":"t"}]'; exit 0; fi
+    echo "no issue found" >&2; exit 1 ;;
+esac
+exit 0
+`
+	if err := os.WriteFile(filepath.Join(binDir, "bd"), []byte(stub), 0755); err != nil {
+		t.Fatalf("writing bd stub: %v", err)
+	}
+	t.Setenv("PATH", binDir+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	b := NewIsolated(t.TempDir())
+	issue, err := b.CreateWithID("gt-fixed", CreateOptions{Title: "t", Priority: -1})
+	if err != nil {
+		t.Fatalf("CreateWithID() error: %v", err)
+	}
+	if issue.ID != "gt-fixed" {
+		t.Errorf("CreateWithID() ID = %q, want gt-fixed", issue.ID)
+	}
+
+	calls, _ := os.ReadFile(callLog)
+	if n := strings.Count(string(calls), "create"); n != 1 {
+		t.Errorf("bd create ran %d times, want 1:\n%s", n, calls)
+	}
+}
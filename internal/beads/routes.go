@@ -223,16 +223,28 @@ func FindConflictingPrefixes(beadsDir string) (map[string][]string, error) {
 // Returns empty string if no valid prefix found (empty input, no hyphen,
 // or hyphen at position 0 which would indicate an invalid prefix).
 func ExtractPrefix(beadID string) string {
-	if beadID == "" {
+	return ExtractPrefixN(beadID, 1)
+}
+
+// ExtractPrefixN extracts the first segments hyphen-terminated segments of a
+// bead ID, for compound prefixes. For example, ExtractPrefixN("hq-cv-abc", 2)
+// returns "hq-cv-". Returns empty string if the ID has fewer than segments
+// hyphens or any of those segments is empty.
+func ExtractPrefixN(beadID string, segments int) string {
+	if beadID == "" || segments <= 0 {
 		return ""
 	}
 
-	idx := strings.Index(beadID, "-")
-	if idx <= 0 {
-		return ""
+	end := 0
+	for i := 0; i < segments; i++ {
+		idx := strings.Index(beadID[end:], "-")
+		if idx <= 0 {
+			return ""
+		}
+		end += idx + 1
 	}
 
-	return beadID[:idx+1]
+	return beadID[:end]
 }
 
 // GetRigPathForPrefix returns the rig path for a given bead ID prefix.
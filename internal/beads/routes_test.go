@@ -107,6 +107,29 @@ func TestExtractPrefix(t *testing.T) {
 	}
 }
 
+func TestExtractPrefixN(t *testing.T) {
+	tests := []struct {
+		beadID   string
+		segments int
+		expected string
+	}{
+		{"ap-qtsup.16", 1, "ap-"},
+		{"hq-cv-abc", 1, "hq-"},
+		{"hq-cv-abc", 2, "hq-cv-"},
+		{"hq-cv-abc", 3, ""}, // Only two hyphens
+		{"gt-mol-", 2, "gt-mol-"},
+		{"hq--abc", 2, ""}, // Empty second segment
+		{"hq-cv-abc", 0, ""},
+		{"", 2, ""},
+	}
+
+	for _, tc := range tests {
+		if got := ExtractPrefixN(tc.beadID, tc.segments); got != tc.expected {
+			t.Errorf("ExtractPrefixN(%q, %d) = %q, want %q", tc.beadID, tc.segments, got, tc.expected)
+		}
+	}
+}
+
 func TestGetRigPathForPrefix(t *testing.T) {
 	// Create a temporary directory with routes.jsonl
 	tmpDir := t.TempDir()
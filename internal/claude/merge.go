@@ -0,0 +1,203 @@
+package claude
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/steveyegge/gastown/internal/hooks"
+	"github.com/steveyegge/gastown/internal/util"
+)
+
+// MergedSettings returns the settings file at dir/subdir/file merged with the
+// current template for role. Template hooks replace hooks with the same
+// matcher and missing template hooks are added; hooks with other matchers,
+// hook events the template doesn't use, enabledPlugins entries, and top-level
+// keys the template doesn't set are kept, so user customizations survive.
+// The merge works on the raw JSON, so fields gastown doesn't model (e.g. a
+// hook's timeout) are preserved too. The settings version is always taken
+// from the template.
+func MergedSettings(dir, role, subdir, file string) ([]byte, error) {
+	templateName := templateFor(RoleTypeFor(role))
+	content, err := configFS.ReadFile(templateName)
+	if err != nil {
+		return nil, fmt.Errorf("reading template %s: %w", templateName, err)
+	}
+	tmpl, err := decodeSettingsObject(content)
+	if err != nil {
+		return nil, fmt.Errorf("template %s: %w", templateName, err)
+	}
+
+	path := filepath.Join(dir, subdir, file)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading settings: %w", err)
+	}
+	current, err := decodeSettingsObject(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	if err := mergeSettingsObject(current, tmpl); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	out, err := encodeSettingsObject(current)
+	if err != nil {
+		return nil, fmt.Errorf("rendering settings: %w", err)
+	}
+	if err := validateSettingsForRole(out, RoleTypeFor(role)); err != nil {
+		return nil, fmt.Errorf("merged %s: %w", path, err)
+	}
+	return out, nil
+}
+
+// mergeSettingsObject merges the template object tmpl into current in place
+// (see MergedSettings).
+func mergeSettingsObject(current, tmpl map[string]json.RawMessage) error {
+	for key, raw := range tmpl {
+		switch key {
+		case "hooks":
+			merged, err := mergeHooksJSON(current[key], raw)
+			if err != nil {
+				return err
+			}
+			current[key] = merged
+		case "enabledPlugins":
+			merged, err := mergeObjectJSON(current[key], raw)
+			if err != nil {
+				return fmt.Errorf("malformed enabledPlugins: %w", err)
+			}
+			current[key] = merged
+		default:
+			if _, ok := current[key]; !ok || key == SettingsVersionKey {
+				current[key] = raw
+			}
+		}
+	}
+	return nil
+}
+
+// mergeHooksJSON merges the template's hooks section into the current one,
+// with the per-matcher semantics of hooks.Merge. Events the template doesn't
+// use are copied through untouched.
+func mergeHooksJSON(current, tmpl json.RawMessage) (json.RawMessage, error) {
+	events := make(map[string]json.RawMessage)
+	if len(current) > 0 {
+		if err := json.Unmarshal(current, &events); err != nil {
+			return nil, fmt.Errorf("malformed hooks: %w", err)
+		}
+		if events == nil {
+			events = make(map[string]json.RawMessage)
+		}
+	}
+	var tmplEvents map[string]json.RawMessage
+	if err := json.Unmarshal(tmpl, &tmplEvents); err != nil {
+		return nil, fmt.Errorf("malformed template hooks: %w", err)
+	}
+
+	for event, rawTmpl := range tmplEvents {
+		var entries, tmplEntries []map[string]json.RawMessage
+		if raw, ok := events[event]; ok {
+			if err := json.Unmarshal(raw, &entries); err != nil {
+				return nil, fmt.Errorf("malformed hooks.%s: %w", event, err)
+			}
+		}
+		if err := json.Unmarshal(rawTmpl, &tmplEntries); err != nil {
+			return nil, fmt.Errorf("malformed template hooks.%s: %w", event, err)
+		}
+		for _, te := range tmplEntries {
+			replaced := false
+			for i, e := range entries {
+				if hookEntryMatcher(e) == hookEntryMatcher(te) {
+					entries[i] = te
+					replaced = true
+					break
+				}
+			}
+			if !replaced {
+				entries = append(entries, te)
+			}
+		}
+		merged, err := json.Marshal(entries)
+		if err != nil {
+			return nil, err
+		}
+		events[event] = merged
+	}
+	return json.Marshal(events)
+}
+
+// hookEntryMatcher returns a raw hook entry's matcher ("" if unset).
+func hookEntryMatcher(entry map[string]json.RawMessage) string {
+	var m string
+	_ = json.Unmarshal(entry["matcher"], &m)
+	return m
+}
+
+// mergeObjectJSON returns current with tmpl's keys set on it, tmpl winning.
+func mergeObjectJSON(current, tmpl json.RawMessage) (json.RawMessage, error) {
+	obj := make(map[string]json.RawMessage)
+	if len(current) > 0 && string(current) != "null" {
+		if err := json.Unmarshal(current, &obj); err != nil {
+			return nil, err
+		}
+	}
+	var tmplObj map[string]json.RawMessage
+	if err := json.Unmarshal(tmpl, &tmplObj); err != nil {
+		return nil, err
+	}
+	for k, v := range tmplObj {
+		obj[k] = v
+	}
+	return json.Marshal(obj)
+}
+
+// SettingsDrift returns the flattened keys (as in DiffSettings) that
+// MergeSettings would change in the settings file at dir/subdir/file, or nil
+// if the file already carries everything the current template for role sets.
+func SettingsDrift(dir, role, subdir, file string) ([]string, error) {
+	merged, err := MergedSettings(dir, role, subdir, file)
+	if err != nil {
+		return nil, err
+	}
+	path := filepath.Join(dir, subdir, file)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading settings: %w", err)
+	}
+
+	want, err := flattenJSON(merged)
+	if err != nil {
+		return nil, err
+	}
+	got, err := flattenJSON(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return changedKeys(want, got), nil
+}
+
+// MergeSettings rewrites the settings file at dir/subdir/file with
+// MergedSettings, backing up the previous file first. Files that are already
+// up to date are left untouched.
+func MergeSettings(dir, role, subdir, file string) error {
+	drift, err := SettingsDrift(dir, role, subdir, file)
+	if err != nil || len(drift) == 0 {
+		return err
+	}
+	merged, err := MergedSettings(dir, role, subdir, file)
+	if err != nil {
+		return err
+	}
+
+	path := filepath.Join(dir, subdir, file)
+	if _, err := hooks.BackupSettings(path); err != nil {
+		return fmt.Errorf("backing up settings: %w", err)
+	}
+	if err := util.AtomicWriteFile(path, merged, 0600); err != nil {
+		return fmt.Errorf("writing settings: %w", err)
+	}
+	return nil
+}
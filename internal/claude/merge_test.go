@@ -0,0 +1,142 @@
+package claude
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/gastown/internal/hooks"
+)
+
+func TestMergeSettings_KeepsCustomizations(t *testing.T) {
+	dir := t.TempDir()
+	if err := EnsureSettingsForRole(dir, "witness"); err != nil {
+		t.Fatalf("EnsureSettingsForRole failed: %v", err)
+	}
+	if drift, err := SettingsDrift(dir, "witness", ".claude", "settings.json"); err != nil || len(drift) != 0 {
+		t.Fatalf("fresh settings: drift = %v, err = %v; want none", drift, err)
+	}
+
+	// Stale the Stop hook, drop UserPromptSubmit, and customize.
+	path := filepath.Join(dir, ".claude", "settings.json")
+	settings, err := hooks.LoadSettings(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	settings.Hooks.Stop[0].Hooks[0].Command = "gt costs record --old"
+	settings.Hooks.UserPromptSubmit = nil
+	settings.Hooks.PostToolUse = []hooks.HookEntry{{
+		Matcher: "Edit",
+		Hooks:   []hooks.Hook{{Type: "command", Command: "make fmt"}},
+	}}
+	settings.EditorMode = "vim"
+	settings.Extra["model"] = []byte(`"opus"`)
+	data, err := hooks.MarshalSettings(settings)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	drift, err := SettingsDrift(dir, "witness", ".claude", "settings.json")
+	if err != nil {
+		t.Fatalf("SettingsDrift failed: %v", err)
+	}
+	if len(drift) == 0 {
+		t.Fatal("expected drift after staling the Stop hook")
+	}
+
+	if err := MergeSettings(dir, "witness", ".claude", "settings.json"); err != nil {
+		t.Fatalf("MergeSettings failed: %v", err)
+	}
+	if drift, err := SettingsDrift(dir, "witness", ".claude", "settings.json"); err != nil || len(drift) != 0 {
+		t.Fatalf("after merge: drift = %v, err = %v; want none", drift, err)
+	}
+
+	merged, err := hooks.LoadSettings(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := merged.Hooks.Stop[0].Hooks[0].Command; got == "gt costs record --old" {
+		t.Error("stale Stop hook was not replaced")
+	}
+	if len(merged.Hooks.UserPromptSubmit) == 0 {
+		t.Error("template UserPromptSubmit hook was not restored")
+	}
+	if len(merged.Hooks.PostToolUse) != 1 || merged.Hooks.PostToolUse[0].Hooks[0].Command != "make fmt" {
+		t.Errorf("user PostToolUse hook lost: %+v", merged.Hooks.PostToolUse)
+	}
+	if merged.EditorMode != "vim" {
+		t.Errorf("EditorMode = %q, want user's %q", merged.EditorMode, "vim")
+	}
+	if string(merged.Extra["model"]) != `"opus"` {
+		t.Errorf("model = %s, want user's \"opus\"", merged.Extra["model"])
+	}
+}
+
+func TestMergeSettings_KeepsUnmodeledHooks(t *testing.T) {
+	dir := t.TempDir()
+	if err := EnsureSettingsForRole(dir, "witness"); err != nil {
+		t.Fatalf("EnsureSettingsForRole failed: %v", err)
+	}
+	path := filepath.Join(dir, ".claude", "settings.json")
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Add a hook under an event gastown doesn't model, and a field it
+	// doesn't model on a user hook, then stale a template hook.
+	var top map[string]any
+	if err := json.Unmarshal(data, &top); err != nil {
+		t.Fatal(err)
+	}
+	hooksSection := top["hooks"].(map[string]any)
+	hooksSection["FutureEvent"] = []any{map[string]any{
+		"matcher": "*",
+		"hooks":   []any{map[string]any{"type": "command", "command": "notify-me", "timeout": 30}},
+	}}
+	stop := hooksSection["Stop"].([]any)[0].(map[string]any)["hooks"].([]any)[0].(map[string]any)
+	stop["command"] = "gt costs record --old"
+	data, err = json.MarshalIndent(top, "", "  ")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	drift, err := SettingsDrift(dir, "witness", ".claude", "settings.json")
+	if err != nil {
+		t.Fatalf("SettingsDrift failed: %v", err)
+	}
+	for _, key := range drift {
+		if strings.HasPrefix(key, "hooks.FutureEvent") {
+			t.Errorf("unmodeled hook reported as drift: %v", drift)
+		}
+	}
+
+	if err := MergeSettings(dir, "witness", ".claude", "settings.json"); err != nil {
+		t.Fatalf("MergeSettings failed: %v", err)
+	}
+	merged, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := flattenJSON(merged)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got["hooks.FutureEvent[0].hooks[0].command"] != `"notify-me"` || got["hooks.FutureEvent[0].hooks[0].timeout"] != "30" {
+		t.Errorf("unmodeled hook not preserved by merge:\n%s", merged)
+	}
+	if got["hooks.Stop[0].hooks[0].command"] == `"gt costs record --old"` {
+		t.Error("stale Stop hook was not replaced")
+	}
+	if drift, err := SettingsDrift(dir, "witness", ".claude", "settings.json"); err != nil || len(drift) != 0 {
+		t.Errorf("after merge: drift = %v, err = %v; want none", drift, err)
+	}
+}
@@ -0,0 +1,200 @@
+package claude
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+
+	"github.com/steveyegge/gastown/internal/hooks"
+	"github.com/steveyegge/gastown/internal/util"
+)
+
+// SettingsVersionKey is the top-level settings key recording which template
+// version a settings file was generated from.
+const SettingsVersionKey = "_gastown_settings_version"
+
+// CurrentSettingsVersion is the version embedded in the current templates.
+// Files without a version marker predate versioning and are treated as v1.
+const CurrentSettingsVersion = 2
+
+// settingsMigrations holds the ordered upgrade steps. settingsMigrations[i]
+// upgrades a file from version i+1 to version i+2. Steps work on the raw
+// top-level object so keys and hook fields gastown doesn't model survive.
+var settingsMigrations = []func(map[string]json.RawMessage) error{
+	migrateV1PrimeHook,
+}
+
+// primeCommandRe matches a bare "gt prime" invocation, with or without --hook.
+var primeCommandRe = regexp.MustCompile(`\bgt prime\b( --hook)?`)
+
+// migrateV1PrimeHook renames v1's "gt prime" hook to "gt prime --hook", which
+// lets prime detect that it is running from a Claude hook.
+func migrateV1PrimeHook(top map[string]json.RawMessage) error {
+	return rewriteHookCommands(top, func(cmd string) string {
+		return primeCommandRe.ReplaceAllString(cmd, "gt prime --hook")
+	})
+}
+
+// rewriteHookCommands applies rewrite to the command of every hook in top's
+// "hooks" section, leaving every other field untouched. Settings without
+// hooks are left as they are.
+func rewriteHookCommands(top map[string]json.RawMessage, rewrite func(string) string) error {
+	raw, ok := top["hooks"]
+	if !ok {
+		return nil
+	}
+	var events map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &events); err != nil {
+		return fmt.Errorf("malformed hooks: %w", err)
+	}
+	for event, rawEntries := range events {
+		var entries []map[string]json.RawMessage
+		if err := json.Unmarshal(rawEntries, &entries); err != nil {
+			return fmt.Errorf("malformed hooks.%s: %w", event, err)
+		}
+		for i, entry := range entries {
+			rawHooks, ok := entry["hooks"]
+			if !ok {
+				continue
+			}
+			var list []map[string]json.RawMessage
+			if err := json.Unmarshal(rawHooks, &list); err != nil {
+				return fmt.Errorf("malformed hooks.%s[%d].hooks: %w", event, i, err)
+			}
+			for _, h := range list {
+				var cmd string
+				if err := json.Unmarshal(h["command"], &cmd); err != nil {
+					continue // No command, or not a string: nothing to rewrite
+				}
+				h["command"], _ = json.Marshal(rewrite(cmd))
+			}
+			entry["hooks"], _ = json.Marshal(list)
+		}
+		events[event], _ = json.Marshal(entries)
+	}
+	top["hooks"], _ = json.Marshal(events)
+	return nil
+}
+
+// SettingsVersion returns the settings version recorded in data, or 1 if the
+// file has no version marker.
+func SettingsVersion(data []byte) (int, error) {
+	top, err := decodeSettingsObject(data)
+	if err != nil {
+		return 0, err
+	}
+	return settingsVersionOf(top)
+}
+
+func settingsVersionOf(top map[string]json.RawMessage) (int, error) {
+	raw, ok := top[SettingsVersionKey]
+	if !ok {
+		return 1, nil
+	}
+	var v int
+	if err := json.Unmarshal(raw, &v); err != nil {
+		return 0, fmt.Errorf("invalid %s: %w", SettingsVersionKey, err)
+	}
+	return v, nil
+}
+
+// needsSettingsMigration reports whether data is a settings object at a
+// version MigrateSettings can upgrade. A file without a version marker only
+// counts as v1 if it has a hooks section, as every v1 template did. Anything
+// else — current or newer files, JSON that isn't an object, content that
+// isn't JSON at all — is not ours to rewrite.
+func needsSettingsMigration(data []byte) bool {
+	top, err := decodeSettingsObject(data)
+	if err != nil {
+		return false
+	}
+	_, versioned := top[SettingsVersionKey]
+	if _, hasHooks := top["hooks"]; !versioned && !hasHooks {
+		return false
+	}
+	version, err := settingsVersionOf(top)
+	return err == nil && version >= 1 && version < CurrentSettingsVersion
+}
+
+// migrateSettingsData returns data upgraded to CurrentSettingsVersion, and
+// whether anything changed. Data already at (or newer than) the current
+// version is returned as is.
+func migrateSettingsData(data []byte) ([]byte, bool, error) {
+	top, err := decodeSettingsObject(data)
+	if err != nil {
+		return nil, false, err
+	}
+	version, err := settingsVersionOf(top)
+	if err != nil {
+		return nil, false, err
+	}
+	if version >= CurrentSettingsVersion {
+		return data, false, nil
+	}
+	if version < 1 {
+		return nil, false, fmt.Errorf("unsupported settings version %d", version)
+	}
+
+	for v := version; v < CurrentSettingsVersion; v++ {
+		if err := settingsMigrations[v-1](top); err != nil {
+			return nil, false, fmt.Errorf("migrating from v%d: %w", v, err)
+		}
+	}
+	top[SettingsVersionKey] = json.RawMessage(fmt.Sprint(CurrentSettingsVersion))
+
+	out, err := encodeSettingsObject(top)
+	if err != nil {
+		return nil, false, fmt.Errorf("rendering settings: %w", err)
+	}
+	return out, true, nil
+}
+
+// MigrateSettings upgrades the settings file at path to CurrentSettingsVersion
+// by applying each upgrade step in order. Files already at (or newer than) the
+// current version are left untouched. The previous file is backed up before
+// it is rewritten.
+func MigrateSettings(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("reading settings: %w", err)
+	}
+	out, changed, err := migrateSettingsData(data)
+	if err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	if !changed {
+		return nil
+	}
+
+	if _, err := hooks.BackupSettings(path); err != nil {
+		return fmt.Errorf("backing up settings: %w", err)
+	}
+	if err := util.AtomicWriteFile(path, out, 0600); err != nil {
+		return fmt.Errorf("writing settings: %w", err)
+	}
+	return nil
+}
+
+// decodeSettingsObject parses settings data as a JSON object, keeping every
+// value raw so keys gastown doesn't model survive a rewrite.
+func decodeSettingsObject(data []byte) (map[string]json.RawMessage, error) {
+	var top map[string]json.RawMessage
+	if err := json.Unmarshal(data, &top); err != nil {
+		return nil, fmt.Errorf("invalid settings JSON: %w", err)
+	}
+	if top == nil {
+		return nil, fmt.Errorf("invalid settings JSON: not an object")
+	}
+	return top, nil
+}
+
+// encodeSettingsObject renders a settings object the way the templates are
+// laid out, with a trailing newline.
+func encodeSettingsObject(top map[string]json.RawMessage) ([]byte, error) {
+	out, err := json.MarshalIndent(top, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(out, '\n'), nil
+}
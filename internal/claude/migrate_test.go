@@ -0,0 +1,255 @@
+package claude
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/gastown/internal/hooks"
+)
+
+// v1Settings is a settings file as generated before version markers existed.
+const v1Settings = `{
+  "editorMode": "normal",
+  "hooks": {
+    "SessionStart": [
+      {
+        "matcher": "",
+        "hooks": [
+          {
+            "type": "command",
+            "command": "export PATH=\"$HOME/go/bin:$HOME/bin:$PATH\" && gt prime && gt mail check --inject"
+          }
+        ]
+      }
+    ],
+    "PreCompact": [
+      {
+        "matcher": "",
+        "hooks": [
+          {
+            "type": "command",
+            "command": "export PATH=\"$HOME/go/bin:$HOME/bin:$PATH\" && gt prime"
+          }
+        ]
+      }
+    ]
+  }
+}
+`
+
+func TestMigrateSettings_V1(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "settings.json")
+	if err := os.WriteFile(path, []byte(v1Settings), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := MigrateSettings(path); err != nil {
+		t.Fatalf("MigrateSettings failed: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	version, err := SettingsVersion(data)
+	if err != nil {
+		t.Fatalf("SettingsVersion failed: %v", err)
+	}
+	if version != CurrentSettingsVersion {
+		t.Errorf("version = %d, want %d", version, CurrentSettingsVersion)
+	}
+
+	settings, err := hooks.UnmarshalSettings(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, event := range []string{"SessionStart", "PreCompact"} {
+		cmd := settings.Hooks.GetEntries(event)[0].Hooks[0].Command
+		if !strings.Contains(cmd, "gt prime --hook") {
+			t.Errorf("%s command = %q, want renamed gt prime --hook", event, cmd)
+		}
+	}
+	if settings.EditorMode != "normal" {
+		t.Errorf("editorMode = %q, want preserved %q", settings.EditorMode, "normal")
+	}
+
+	backups, _ := filepath.Glob(path + ".bak.*")
+	if len(backups) != 1 {
+		t.Errorf("expected 1 backup of the v1 file, got %d", len(backups))
+	}
+}
+
+func TestMigrateSettings_CurrentUntouched(t *testing.T) {
+	dir := t.TempDir()
+	if err := EnsureSettings(dir, Autonomous); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, ".claude", "settings.json")
+	before, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := MigrateSettings(path); err != nil {
+		t.Fatalf("MigrateSettings failed: %v", err)
+	}
+
+	after, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(before) != string(after) {
+		t.Error("MigrateSettings rewrote a file already at the current version")
+	}
+}
+
+func TestEnsureSettingsAt_MigratesStaleFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, ".claude", "settings.json")
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(v1Settings), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := EnsureSettingsAt(dir, Autonomous, ".claude", "settings.json"); err != nil {
+		t.Fatalf("EnsureSettingsAt failed: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if version, _ := SettingsVersion(data); version != CurrentSettingsVersion {
+		t.Errorf("stale settings not migrated: version = %d, want %d", version, CurrentSettingsVersion)
+	}
+}
+
+func TestTemplatesAtCurrentVersion(t *testing.T) {
+	for _, rt := range []RoleType{Autonomous, Interactive, ReadOnly} {
+		content, err := configFS.ReadFile(templateFor(rt))
+		if err != nil {
+			t.Fatal(err)
+		}
+		version, err := SettingsVersion(content)
+		if err != nil {
+			t.Fatalf("%s template: %v", rt, err)
+		}
+		if version != CurrentSettingsVersion {
+			t.Errorf("%s template version = %d, want %d", rt, version, CurrentSettingsVersion)
+		}
+	}
+}
+
+func TestMigrateSettings_PreservesUnmodeledFields(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "settings.json")
+	v1 := `{
+  "model": "opus",
+  "hooks": {
+    "SessionStart": [
+      {"matcher": "", "hooks": [{"type": "command", "command": "gt prime", "timeout": 30}]}
+    ],
+    "FutureEvent": [
+      {"matcher": "*", "hooks": [{"type": "command", "command": "notify-me"}]}
+    ]
+  }
+}
+`
+	if err := os.WriteFile(path, []byte(v1), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := MigrateSettings(path); err != nil {
+		t.Fatalf("MigrateSettings failed: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := flattenJSON(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]string{
+		"model":                                  `"opus"`,
+		"hooks.SessionStart[0].hooks[0].command": `"gt prime --hook"`,
+		"hooks.SessionStart[0].hooks[0].timeout": `30`,
+		"hooks.FutureEvent[0].hooks[0].command":  `"notify-me"`,
+	}
+	for k, v := range want {
+		if got[k] != v {
+			t.Errorf("%s = %s, want %s", k, got[k], v)
+		}
+	}
+}
+
+func TestEnsureSettingsAt_LeavesForeignJSON(t *testing.T) {
+	for name, content := range map[string]string{
+		"not an object":   "[1, 2]\n",
+		"no hooks":        "{\"model\": \"opus\"}\n",
+		"current":         "{\"_gastown_settings_version\": 2, \"model\": \"opus\"}\n",
+		"not JSON at all": "model = opus\n",
+	} {
+		t.Run(name, func(t *testing.T) {
+			dir := t.TempDir()
+			path := filepath.Join(dir, ".claude", "settings.json")
+			if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+				t.Fatal(err)
+			}
+			if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+				t.Fatal(err)
+			}
+
+			if err := EnsureSettingsAt(dir, Autonomous, ".claude", "settings.json"); err != nil {
+				t.Fatalf("EnsureSettingsAt failed: %v", err)
+			}
+			data, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(data) != content {
+				t.Errorf("settings rewritten to %q, want untouched", data)
+			}
+		})
+	}
+}
+
+func TestMigrateSettings_ReplacesFileAtomically(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("relies on rename replacing an open file")
+	}
+	path := filepath.Join(t.TempDir(), "settings.json")
+	if err := os.WriteFile(path, []byte(v1Settings), 0600); err != nil {
+		t.Fatal(err)
+	}
+	// A reader holding the old file must still see it whole: the migration
+	// renames a new file into place rather than truncating this one.
+	old, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer old.Close()
+
+	if err := MigrateSettings(path); err != nil {
+		t.Fatalf("MigrateSettings failed: %v", err)
+	}
+
+	data, err := io.ReadAll(old)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != v1Settings {
+		t.Errorf("old file was rewritten in place: %q", data)
+	}
+	if info, err := os.Stat(path); err != nil {
+		t.Fatal(err)
+	} else if perm := info.Mode().Perm(); perm != 0600 {
+		t.Errorf("settings perm = %o, want 600", perm)
+	}
+}
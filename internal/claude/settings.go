@@ -3,15 +3,26 @@ package claude
 
 import (
 	"embed"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/hooks"
+	"github.com/steveyegge/gastown/internal/util"
 )
 
 //go:embed config/*.json
 var configFS embed.FS
 
-// RoleType indicates whether a role is autonomous or interactive.
+// RoleType indicates whether a role is autonomous, interactive, or read-only.
 type RoleType string
 
 const (
@@ -22,6 +33,11 @@ const (
 	// Interactive roles (mayor, crew) wait for user input, so UserPromptSubmit
 	// handles mail injection.
 	Interactive RoleType = "interactive"
+
+	// ReadOnly roles (spectator) observe but must never modify the workspace.
+	// Their template drops write-capable hooks and blocks Edit/Write tools
+	// and Bash commands with output redirection via PreToolUse.
+	ReadOnly RoleType = "readonly"
 )
 
 // RoleTypeFor returns the RoleType for a given role name.
@@ -29,56 +45,269 @@ func RoleTypeFor(role string) RoleType {
 	switch role {
 	case "polecat", "witness", "refinery", "deacon", "boot":
 		return Autonomous
+	case "spectator":
+		return ReadOnly
 	default:
 		return Interactive
 	}
 }
 
-// EnsureSettings ensures .claude/settings.json exists in the given directory.
+const (
+	defaultSettingsDir  = ".claude"
+	defaultSettingsFile = "settings.json"
+)
+
+var (
+	settingsLocationMu  sync.RWMutex
+	settingsLocationDir = defaultSettingsDir
+	settingsLocationFn  = defaultSettingsFile
+)
+
+// SetDefaultSettingsLocation overrides the directory and file name used by
+// EnsureSettings and EnsureSettingsForRole (default .claude/settings.json),
+// for deployments with a different layout such as .config/claude. An empty
+// subdir or file restores that part's default. Safe for concurrent use.
+func SetDefaultSettingsLocation(subdir, file string) {
+	if subdir == "" {
+		subdir = defaultSettingsDir
+	}
+	if file == "" {
+		file = defaultSettingsFile
+	}
+	settingsLocationMu.Lock()
+	defer settingsLocationMu.Unlock()
+	settingsLocationDir = subdir
+	settingsLocationFn = file
+}
+
+// DefaultSettingsLocation returns the directory and file name currently used
+// by EnsureSettings.
+func DefaultSettingsLocation() (subdir, file string) {
+	settingsLocationMu.RLock()
+	defer settingsLocationMu.RUnlock()
+	return settingsLocationDir, settingsLocationFn
+}
+
+// EnsureSettings ensures .claude/settings.json (or the location configured via
+// SetDefaultSettingsLocation) exists in the given directory.
 // Settings are installed in a gastown-managed parent directory and passed to
 // Claude Code via --settings flag, keeping customer repos untouched.
 func EnsureSettings(workDir string, roleType RoleType) error {
-	return EnsureSettingsAt(workDir, roleType, ".claude", "settings.json")
+	subdir, file := DefaultSettingsLocation()
+	return EnsureSettingsAt(workDir, roleType, subdir, file)
 }
 
 // EnsureSettingsAt ensures a settings file exists at a custom directory/file.
 // If the file doesn't exist, it copies the appropriate template based on role type.
-// If the file already exists, it's left unchanged.
+// If the file already exists, it's left unchanged apart from being upgraded to
+// CurrentSettingsVersion by MigrateSettings.
 func EnsureSettingsAt(workDir string, roleType RoleType, settingsDir, settingsFile string) error {
+	return EnsureSettingsAtWithEnv(workDir, roleType, settingsDir, settingsFile, nil)
+}
+
+// PlanSettingsAt reports what EnsureSettingsAt would do without creating
+// anything: the settings path, the content it would write, and whether it
+// would write it. For an existing file, willWrite is true only when
+// EnsureSettingsAt would upgrade it with MigrateSettings, and content is the
+// migrated file; otherwise content is the file as it stands.
+func PlanSettingsAt(workDir string, roleType RoleType, settingsDir, settingsFile string) (path string, content []byte, willWrite bool, err error) {
+	path = filepath.Join(workDir, settingsDir, settingsFile)
+	if data, err := os.ReadFile(path); err == nil {
+		if !needsSettingsMigration(data) {
+			return path, data, false, nil
+		}
+		migrated, _, err := migrateSettingsData(data)
+		if err != nil {
+			return path, nil, false, fmt.Errorf("%s: %w", path, err)
+		}
+		return path, migrated, true, nil
+	} else if !os.IsNotExist(err) {
+		return path, nil, false, fmt.Errorf("checking settings: %w", err)
+	}
+	content, err = RenderSettings(roleType)
+	if err != nil {
+		return path, nil, false, err
+	}
+	return path, content, true, nil
+}
+
+// EnsureSettingsAtWithEnv is like EnsureSettingsAt, but also exports the given
+// environment variables at the start of every SessionStart hook command, so
+// roles can receive extra context (e.g. GT_REFINERY_BATCH) at session start.
+// Values are shell-quoted; names must be valid shell identifiers.
+func EnsureSettingsAtWithEnv(workDir string, roleType RoleType, settingsDir, settingsFile string, env map[string]string) error {
+	return ensureSettingsAt(workDir, roleType, settingsDir, settingsFile, env, nil)
+}
+
+// ensureSettingsAt renders the role template with env and extraHooks applied
+// and writes it, unless a settings file is already present.
+func ensureSettingsAt(workDir string, roleType RoleType, settingsDir, settingsFile string, env map[string]string, extraHooks []Hook) error {
 	claudeDir := filepath.Join(workDir, settingsDir)
 	settingsPath := filepath.Join(claudeDir, settingsFile)
 
-	// If settings already exist, don't overwrite
+	// If settings already exist, don't overwrite — but upgrade files recorded
+	// at an older template version. Anything else isn't ours; leave it be.
 	if _, err := os.Stat(settingsPath); err == nil {
+		if data, err := os.ReadFile(settingsPath); err == nil && needsSettingsMigration(data) {
+			return MigrateSettings(settingsPath)
+		}
 		return nil
 	}
 
+	content, err := RenderSettings(roleType)
+	if err != nil {
+		return err
+	}
+	templateName := templateFor(roleType)
+
+	if len(env) > 0 {
+		content, err = injectSessionStartEnv(content, env)
+		if err != nil {
+			return fmt.Errorf("template %s: %w", templateName, err)
+		}
+	}
+
+	if len(extraHooks) > 0 {
+		content, err = appendHooks(content, extraHooks)
+		if err != nil {
+			return fmt.Errorf("template %s: %w", templateName, err)
+		}
+	}
+
+	// Refuse to write a file Claude would silently reject at startup
+	if err := validateSettingsForRole(content, roleType); err != nil {
+		return fmt.Errorf("template %s: %w", templateName, err)
+	}
+
 	// Create settings directory if needed
 	if err := os.MkdirAll(claudeDir, 0755); err != nil {
 		return fmt.Errorf("creating settings directory: %w", err)
 	}
 
-	// Select template based on role type
-	var templateName string
+	// Write via temp file + rename so a killed process never leaves a
+	// truncated settings.json that Claude would fail to start with
+	if err := util.AtomicWriteFile(settingsPath, content, 0600); err != nil {
+		return fmt.Errorf("writing settings: %w", err)
+	}
+
+	return nil
+}
+
+// RenderSettings returns the settings file content for roleType as
+// EnsureSettingsAt would write it, without touching the filesystem.
+func RenderSettings(roleType RoleType) ([]byte, error) {
+	templateName := templateFor(roleType)
+	content, err := configFS.ReadFile(templateName)
+	if err != nil {
+		return nil, fmt.Errorf("reading template %s: %w", templateName, err)
+	}
+	if err := validateSettingsForRole(content, roleType); err != nil {
+		return nil, fmt.Errorf("template %s: %w", templateName, err)
+	}
+	return content, nil
+}
+
+// templateFor returns the embedded template path for a role type.
+func templateFor(roleType RoleType) string {
 	switch roleType {
 	case Autonomous:
-		templateName = "config/settings-autonomous.json"
+		return "config/settings-autonomous.json"
+	case ReadOnly:
+		return "config/settings-readonly.json"
 	default:
-		templateName = "config/settings-interactive.json"
+		return "config/settings-interactive.json"
 	}
+}
 
-	// Read template
-	content, err := configFS.ReadFile(templateName)
+// envNameRe matches names that are safe to use in a shell export.
+var envNameRe = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// injectSessionStartEnv prefixes every SessionStart hook command in content
+// with an export of env. Variables are exported in sorted order so the
+// rendered file is deterministic.
+func injectSessionStartEnv(content []byte, env map[string]string) ([]byte, error) {
+	names := make([]string, 0, len(env))
+	for name := range env {
+		if !envNameRe.MatchString(name) {
+			return nil, fmt.Errorf("invalid environment variable name %q", name)
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	assignments := make([]string, len(names))
+	for i, name := range names {
+		assignments[i] = name + "=" + config.ShellQuote(env[name])
+	}
+	prefix := "export " + strings.Join(assignments, " ") + " && "
+
+	settings, err := hooks.UnmarshalSettings(content)
 	if err != nil {
-		return fmt.Errorf("reading template %s: %w", templateName, err)
+		return nil, fmt.Errorf("parsing settings: %w", err)
+	}
+	for i := range settings.Hooks.SessionStart {
+		entry := &settings.Hooks.SessionStart[i]
+		for j := range entry.Hooks {
+			entry.Hooks[j].Command = prefix + entry.Hooks[j].Command
+		}
 	}
 
-	// Write settings file
-	if err := os.WriteFile(settingsPath, content, 0600); err != nil {
-		return fmt.Errorf("writing settings: %w", err)
+	out, err := hooks.MarshalSettings(settings)
+	if err != nil {
+		return nil, fmt.Errorf("rendering settings: %w", err)
 	}
+	return append(out, '\n'), nil
+}
 
-	return nil
+// Hook is an extra hook command to append to a role's settings template.
+type Hook struct {
+	Event   string // hook event, e.g. "PostToolUse"
+	Matcher string
+	Command string
+}
+
+// appendHooks adds extraHooks to the hooks in content. Hooks sharing an event
+// and matcher are grouped into one entry; a matcher the template already
+// defines for that event is a conflict and is rejected rather than merged.
+func appendHooks(content []byte, extraHooks []Hook) ([]byte, error) {
+	settings, err := hooks.UnmarshalSettings(content)
+	if err != nil {
+		return nil, fmt.Errorf("parsing settings: %w", err)
+	}
+
+	base := make(map[string]map[string]bool)
+	for event, entries := range settings.Hooks.ToMap() {
+		base[event] = make(map[string]bool)
+		for _, e := range entries {
+			base[event][e.Matcher] = true
+		}
+	}
+
+	for _, h := range extraHooks {
+		if !slices.Contains(hooks.EventTypes, h.Event) {
+			return nil, fmt.Errorf("extra hook: unknown event %q", h.Event)
+		}
+		if strings.TrimSpace(h.Command) == "" {
+			return nil, fmt.Errorf("extra hook: %s hook has empty command", h.Event)
+		}
+		if base[h.Event][h.Matcher] {
+			return nil, fmt.Errorf("extra hook: %s matcher %q conflicts with the template", h.Event, h.Matcher)
+		}
+
+		cmd := hooks.Hook{Type: "command", Command: h.Command}
+		entries := settings.Hooks.GetEntries(h.Event)
+		if i := slices.IndexFunc(entries, func(e hooks.HookEntry) bool { return e.Matcher == h.Matcher }); i >= 0 {
+			entries[i].Hooks = append(entries[i].Hooks, cmd)
+			continue
+		}
+		settings.Hooks.SetEntries(h.Event, append(entries, hooks.HookEntry{Matcher: h.Matcher, Hooks: []hooks.Hook{cmd}}))
+	}
+
+	out, err := hooks.MarshalSettings(settings)
+	if err != nil {
+		return nil, fmt.Errorf("rendering settings: %w", err)
+	}
+	return append(out, '\n'), nil
 }
 
 // EnsureSettingsForRole is a convenience function that combines RoleTypeFor and EnsureSettings.
@@ -90,3 +319,221 @@ func EnsureSettingsForRole(workDir, role string) error {
 func EnsureSettingsForRoleAt(workDir, role, settingsDir, settingsFile string) error {
 	return EnsureSettingsAt(workDir, RoleTypeFor(role), settingsDir, settingsFile)
 }
+
+// EnsureSettingsForRoleAtWithHooks is like EnsureSettingsForRoleAt, but appends
+// extraHooks to the role's template before writing it. An extra hook whose
+// event and matcher are already defined by the template is rejected.
+func EnsureSettingsForRoleAtWithHooks(workDir, role, settingsDir, settingsFile string, extraHooks []Hook) error {
+	return ensureSettingsAt(workDir, RoleTypeFor(role), settingsDir, settingsFile, nil, extraHooks)
+}
+
+// EnsureSettingsForAllRoles provisions settings for several roles at once.
+// roles maps a role name to the directory that should receive its settings;
+// relative directories are resolved against townRoot. Provisioning is
+// best-effort: a failure for one role does not stop the rest. Returns the
+// number of roles provisioned and a combined error naming each role that
+// failed.
+func EnsureSettingsForAllRoles(townRoot string, roles map[string]string) (int, error) {
+	names := make([]string, 0, len(roles))
+	for role := range roles {
+		names = append(names, role)
+	}
+	sort.Strings(names)
+
+	provisioned := 0
+	var errs []error
+	for _, role := range names {
+		dir := roles[role]
+		if !filepath.IsAbs(dir) {
+			dir = filepath.Join(townRoot, dir)
+		}
+		if err := EnsureSettingsForRole(dir, role); err != nil {
+			errs = append(errs, fmt.Errorf("%s (%s): %w", role, dir, err))
+			continue
+		}
+		provisioned++
+	}
+
+	if len(errs) > 0 {
+		return provisioned, fmt.Errorf("provisioned %d/%d roles: %w", provisioned, len(names), errors.Join(errs...))
+	}
+	return provisioned, nil
+}
+
+// mailInjectCommand is the hook command that injects mail into a session.
+// Autonomous roles must run it from SessionStart.
+const mailInjectCommand = "gt mail check --inject"
+
+// hookMatcher is one entry in a hook event list of a Claude settings file.
+type hookMatcher struct {
+	Matcher string      `json:"matcher"`
+	Hooks   []hookEntry `json:"hooks"`
+}
+
+// hookEntry is a single hook action within a hookMatcher.
+type hookEntry struct {
+	Type    string `json:"type"`
+	Command string `json:"command"`
+}
+
+// ValidateSettings checks that data is a Claude settings file that Claude Code
+// will accept: a JSON object with a "hooks" key whose every hook entry has a
+// non-empty command.
+func ValidateSettings(data []byte) error {
+	_, err := parseSettingsHooks(data)
+	return err
+}
+
+// validateSettingsForRole runs ValidateSettings and, for autonomous roles,
+// also requires the SessionStart mail injection hook.
+func validateSettingsForRole(data []byte, roleType RoleType) error {
+	hooks, err := parseSettingsHooks(data)
+	if err != nil {
+		return err
+	}
+	if roleType != Autonomous {
+		return nil
+	}
+	for _, m := range hooks["SessionStart"] {
+		for _, h := range m.Hooks {
+			if strings.Contains(h.Command, mailInjectCommand) {
+				return nil
+			}
+		}
+	}
+	return fmt.Errorf("invalid settings: autonomous SessionStart hook must run %q", mailInjectCommand)
+}
+
+// parseSettingsHooks decodes the "hooks" section of a settings file and
+// verifies that every hook entry has a command.
+func parseSettingsHooks(data []byte) (map[string][]hookMatcher, error) {
+	var top map[string]json.RawMessage
+	if err := json.Unmarshal(data, &top); err != nil {
+		return nil, fmt.Errorf("invalid settings JSON: %w", err)
+	}
+	raw, ok := top["hooks"]
+	if !ok {
+		return nil, fmt.Errorf("invalid settings: missing required key %q", "hooks")
+	}
+
+	var hooks map[string][]hookMatcher
+	if err := json.Unmarshal(raw, &hooks); err != nil {
+		return nil, fmt.Errorf("invalid settings: malformed hooks: %w", err)
+	}
+	for event, matchers := range hooks {
+		for i, m := range matchers {
+			if len(m.Hooks) == 0 {
+				return nil, fmt.Errorf("invalid settings: hooks.%s[%d] has no hooks", event, i)
+			}
+			for j, h := range m.Hooks {
+				if strings.TrimSpace(h.Command) == "" {
+					return nil, fmt.Errorf("invalid settings: hooks.%s[%d].hooks[%d] has empty command", event, i, j)
+				}
+			}
+		}
+	}
+	return hooks, nil
+}
+
+// DiffSettings compares the settings file at dir/subdir/file against what the
+// current template for role would generate. It returns a human-readable,
+// key-by-key diff of the two JSON documents ("-" lines are expected values,
+// "+" lines are actual values), or an empty string if they match.
+func DiffSettings(dir string, role string, subdir, file string) (string, error) {
+	roleType := RoleTypeFor(role)
+	templateName := templateFor(roleType)
+	expected, err := RenderSettings(roleType)
+	if err != nil {
+		return "", err
+	}
+
+	actualPath := filepath.Join(dir, subdir, file)
+	actual, err := os.ReadFile(actualPath)
+	if err != nil {
+		return "", fmt.Errorf("reading settings: %w", err)
+	}
+
+	want, err := flattenJSON(expected)
+	if err != nil {
+		return "", fmt.Errorf("template %s: %w", templateName, err)
+	}
+	got, err := flattenJSON(actual)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", actualPath, err)
+	}
+
+	var b strings.Builder
+	for _, k := range changedKeys(want, got) {
+		w, inWant := want[k]
+		g, inGot := got[k]
+		if inWant {
+			fmt.Fprintf(&b, "- %s: %s\n", k, w)
+		}
+		if inGot {
+			fmt.Fprintf(&b, "+ %s: %s\n", k, g)
+		}
+	}
+	if b.Len() == 0 {
+		return "", nil
+	}
+	return fmt.Sprintf("--- %s (%s template)\n+++ %s\n", templateName, role, actualPath) + b.String(), nil
+}
+
+// changedKeys returns, sorted, the flattened keys whose values differ
+// between want and got, including keys present in only one of them.
+func changedKeys(want, got map[string]string) []string {
+	var keys []string
+	for k, w := range want {
+		if g, ok := got[k]; !ok || g != w {
+			keys = append(keys, k)
+		}
+	}
+	for k := range got {
+		if _, ok := want[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// flattenJSON decodes data and maps each leaf's dotted path (e.g.
+// "hooks.SessionStart[0].hooks[0].command") to its JSON-encoded value.
+func flattenJSON(data []byte) (map[string]string, error) {
+	var v any
+	if err := json.Unmarshal(data, &v); err != nil {
+		return nil, fmt.Errorf("invalid settings JSON: %w", err)
+	}
+	out := make(map[string]string)
+	flattenValue("", v, out)
+	return out, nil
+}
+
+func flattenValue(path string, v any, out map[string]string) {
+	switch val := v.(type) {
+	case map[string]any:
+		if len(val) == 0 {
+			out[path] = "{}"
+		}
+		for k, child := range val {
+			p := k
+			if path != "" {
+				p = path + "." + k
+			}
+			flattenValue(p, child, out)
+		}
+	case []any:
+		if len(val) == 0 {
+			out[path] = "[]"
+		}
+		for i, child := range val {
+			flattenValue(fmt.Sprintf("%s[%d]", path, i), child, out)
+		}
+	default:
+		var buf strings.Builder
+		enc := json.NewEncoder(&buf)
+		enc.SetEscapeHTML(false)
+		_ = enc.Encode(val)
+		out[path] = strings.TrimSuffix(buf.String(), "\n")
+	}
+}
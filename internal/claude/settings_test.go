@@ -1,9 +1,14 @@
 package claude
 
 import (
+	"encoding/json"
 	"os"
 	"path/filepath"
+	"runtime"
+	"strings"
 	"testing"
+
+	"github.com/steveyegge/gastown/internal/hooks"
 )
 
 func TestRoleTypeFor(t *testing.T) {
@@ -18,6 +23,7 @@ func TestRoleTypeFor(t *testing.T) {
 		{"boot", Autonomous},
 		{"mayor", Interactive},
 		{"crew", Interactive},
+		{"spectator", ReadOnly},
 		{"unknown", Interactive},
 		{"", Interactive},
 	}
@@ -74,6 +80,52 @@ func TestEnsureSettingsAt_DoesNotOverwrite(t *testing.T) {
 	}
 }
 
+func TestEnsureSettingsAt_AtomicWrite(t *testing.T) {
+	dir := t.TempDir()
+	claudeDir := filepath.Join(dir, ".claude")
+	if err := os.MkdirAll(claudeDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	// A writer killed mid-write leaves only its temp file behind, never a
+	// truncated settings.json, so the next run must still write the file.
+	stale := filepath.Join(claudeDir, "settings.json.tmp.12345")
+	if err := os.WriteFile(stale, []byte(`{"hooks": {`), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := EnsureSettingsAt(dir, Interactive, ".claude", "settings.json"); err != nil {
+		t.Fatalf("EnsureSettingsAt failed: %v", err)
+	}
+
+	settingsPath := filepath.Join(claudeDir, "settings.json")
+	content, err := os.ReadFile(settingsPath)
+	if err != nil {
+		t.Fatalf("settings file not created: %v", err)
+	}
+	if !json.Valid(content) {
+		t.Errorf("settings file is not complete JSON: %q", content)
+	}
+	if runtime.GOOS != "windows" {
+		info, err := os.Stat(settingsPath)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if perm := info.Mode().Perm(); perm != 0600 {
+			t.Errorf("settings perm = %o, want 600", perm)
+		}
+	}
+
+	entries, err := os.ReadDir(claudeDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, e := range entries {
+		if e.Name() != "settings.json" && filepath.Join(claudeDir, e.Name()) != stale {
+			t.Errorf("leftover file %s after write", e.Name())
+		}
+	}
+}
+
 func TestEnsureSettingsAt_Autonomous(t *testing.T) {
 	dir := t.TempDir()
 
@@ -106,6 +158,191 @@ func TestEnsureSettingsAt_CustomDir(t *testing.T) {
 	}
 }
 
+func TestPlanSettingsAt_WouldCreate(t *testing.T) {
+	dir := t.TempDir()
+
+	path, content, willWrite, err := PlanSettingsAt(dir, Autonomous, ".claude", "settings.json")
+	if err != nil {
+		t.Fatalf("PlanSettingsAt failed: %v", err)
+	}
+	if want := filepath.Join(dir, ".claude", "settings.json"); path != want {
+		t.Errorf("path = %q, want %q", path, want)
+	}
+	if !willWrite {
+		t.Error("willWrite = false, want true for a missing settings file")
+	}
+	want, err := RenderSettings(Autonomous)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(content) != string(want) {
+		t.Error("planned content differs from RenderSettings output")
+	}
+	if _, err := os.Stat(filepath.Join(dir, ".claude")); !os.IsNotExist(err) {
+		t.Errorf("PlanSettingsAt should not create anything, stat err = %v", err)
+	}
+}
+
+func TestPlanSettingsAt_WouldSkip(t *testing.T) {
+	dir := t.TempDir()
+	if err := EnsureSettingsAt(dir, Interactive, ".claude", "settings.json"); err != nil {
+		t.Fatalf("EnsureSettingsAt failed: %v", err)
+	}
+
+	path, _, willWrite, err := PlanSettingsAt(dir, Interactive, ".claude", "settings.json")
+	if err != nil {
+		t.Fatalf("PlanSettingsAt failed: %v", err)
+	}
+	if willWrite {
+		t.Errorf("willWrite = true, want false when %s already exists", path)
+	}
+}
+
+func TestPlanSettingsAt_WouldMigrate(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, ".claude", "settings.json")
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(v1Settings), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	_, content, willWrite, err := PlanSettingsAt(dir, Autonomous, ".claude", "settings.json")
+	if err != nil {
+		t.Fatalf("PlanSettingsAt failed: %v", err)
+	}
+	if !willWrite {
+		t.Error("willWrite = false, want true for a settings file EnsureSettingsAt would migrate")
+	}
+	if version, _ := SettingsVersion(content); version != CurrentSettingsVersion {
+		t.Errorf("planned content version = %d, want migrated %d", version, CurrentSettingsVersion)
+	}
+	if data, _ := os.ReadFile(path); string(data) != v1Settings {
+		t.Error("PlanSettingsAt rewrote the settings file")
+	}
+}
+
+func TestEnsureSettingsAtWithEnv_QuotesValues(t *testing.T) {
+	dir := t.TempDir()
+
+	env := map[string]string{"GT_REFINERY_BATCH": `a b"c`}
+	if err := EnsureSettingsAtWithEnv(dir, Autonomous, ".claude", "settings.json", env); err != nil {
+		t.Fatalf("EnsureSettingsAtWithEnv failed: %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(dir, ".claude", "settings.json"))
+	if err != nil {
+		t.Fatalf("failed to read settings: %v", err)
+	}
+	if err := ValidateSettings(content); err != nil {
+		t.Fatalf("rendered settings are invalid: %v", err)
+	}
+
+	settings, err := hooks.UnmarshalSettings(content)
+	if err != nil {
+		t.Fatalf("parsing rendered settings: %v", err)
+	}
+	if len(settings.Hooks.SessionStart) == 0 {
+		t.Fatal("rendered settings have no SessionStart hooks")
+	}
+	want := `export GT_REFINERY_BATCH='a b"c' && `
+	for _, entry := range settings.Hooks.SessionStart {
+		for _, h := range entry.Hooks {
+			if !strings.HasPrefix(h.Command, want) {
+				t.Errorf("SessionStart command = %q, want prefix %q", h.Command, want)
+			}
+		}
+	}
+}
+
+func TestEnsureSettingsAtWithEnv_InvalidName(t *testing.T) {
+	dir := t.TempDir()
+
+	env := map[string]string{"BAD;rm -rf /": "x"}
+	if err := EnsureSettingsAtWithEnv(dir, Autonomous, ".claude", "settings.json", env); err == nil {
+		t.Fatal("expected error for invalid environment variable name")
+	}
+	if _, err := os.Stat(filepath.Join(dir, ".claude", "settings.json")); !os.IsNotExist(err) {
+		t.Errorf("settings file should not be written on error, stat err = %v", err)
+	}
+}
+
+func TestEnsureSettingsForRoleAtWithHooks_AppendsPostToolUse(t *testing.T) {
+	dir := t.TempDir()
+
+	extra := []Hook{{Event: "PostToolUse", Matcher: "Bash", Command: "gt audit log"}}
+	if err := EnsureSettingsForRoleAtWithHooks(dir, "polecat", ".claude", "settings.json", extra); err != nil {
+		t.Fatalf("EnsureSettingsForRoleAtWithHooks failed: %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(dir, ".claude", "settings.json"))
+	if err != nil {
+		t.Fatalf("failed to read settings: %v", err)
+	}
+	if err := validateSettingsForRole(content, Autonomous); err != nil {
+		t.Fatalf("rendered settings are invalid: %v", err)
+	}
+
+	settings, err := hooks.UnmarshalSettings(content)
+	if err != nil {
+		t.Fatalf("parsing rendered settings: %v", err)
+	}
+	post := settings.Hooks.PostToolUse
+	if len(post) != 1 || post[0].Matcher != "Bash" || len(post[0].Hooks) != 1 || post[0].Hooks[0].Command != "gt audit log" {
+		t.Errorf("PostToolUse = %+v, want one Bash entry running %q", post, "gt audit log")
+	}
+	if len(settings.Hooks.SessionStart) == 0 {
+		t.Error("template SessionStart hooks were dropped")
+	}
+}
+
+func TestEnsureSettingsForRoleAtWithHooks_EmptyMatchesBase(t *testing.T) {
+	for _, role := range []string{"polecat", "mayor", "crew"} {
+		baseDir, hooksDir := t.TempDir(), t.TempDir()
+		if err := EnsureSettingsForRoleAt(baseDir, role, ".claude", "settings.json"); err != nil {
+			t.Fatalf("EnsureSettingsForRoleAt(%s) failed: %v", role, err)
+		}
+		if err := EnsureSettingsForRoleAtWithHooks(hooksDir, role, ".claude", "settings.json", nil); err != nil {
+			t.Fatalf("EnsureSettingsForRoleAtWithHooks(%s) failed: %v", role, err)
+		}
+
+		want, err := os.ReadFile(filepath.Join(baseDir, ".claude", "settings.json"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := os.ReadFile(filepath.Join(hooksDir, ".claude", "settings.json"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(got) != string(want) {
+			t.Errorf("%s: settings with no extra hooks differ from base settings", role)
+		}
+	}
+}
+
+func TestEnsureSettingsForRoleAtWithHooks_RejectsConflicts(t *testing.T) {
+	tests := []struct {
+		name string
+		hook Hook
+	}{
+		{"template matcher", Hook{Event: "SessionStart", Matcher: "", Command: "echo hi"}},
+		{"unknown event", Hook{Event: "NotAnEvent", Command: "echo hi"}},
+		{"empty command", Hook{Event: "PostToolUse", Matcher: "Bash"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			if err := EnsureSettingsForRoleAtWithHooks(dir, "polecat", ".claude", "settings.json", []Hook{tt.hook}); err == nil {
+				t.Fatal("expected error")
+			}
+			if _, err := os.Stat(filepath.Join(dir, ".claude", "settings.json")); !os.IsNotExist(err) {
+				t.Errorf("settings file should not be written on error, stat err = %v", err)
+			}
+		})
+	}
+}
+
 func TestEnsureSettings(t *testing.T) {
 	dir := t.TempDir()
 
@@ -133,3 +370,294 @@ func TestEnsureSettingsForRole(t *testing.T) {
 		t.Fatalf("settings file not created: %v", err)
 	}
 }
+
+func TestRenderSettings_AllRoleTypes(t *testing.T) {
+	for _, rt := range []RoleType{Interactive, Autonomous, ReadOnly} {
+		content, err := RenderSettings(rt)
+		if err != nil {
+			t.Fatalf("RenderSettings(%s) failed: %v", rt, err)
+		}
+		if !json.Valid(content) {
+			t.Errorf("RenderSettings(%s) is not valid JSON", rt)
+		}
+		if err := ValidateSettings(content); err != nil {
+			t.Errorf("RenderSettings(%s) is invalid: %v", rt, err)
+		}
+	}
+}
+
+func TestRenderSettings_AutonomousInjectsMail(t *testing.T) {
+	content, err := RenderSettings(Autonomous)
+	if err != nil {
+		t.Fatalf("RenderSettings failed: %v", err)
+	}
+	settings, err := hooks.UnmarshalSettings(content)
+	if err != nil {
+		t.Fatalf("parsing rendered settings: %v", err)
+	}
+	for _, entry := range settings.Hooks.SessionStart {
+		for _, h := range entry.Hooks {
+			if strings.Contains(h.Command, mailInjectCommand) {
+				return
+			}
+		}
+	}
+	t.Errorf("autonomous SessionStart hooks do not run %q", mailInjectCommand)
+}
+
+func TestValidateSettings_Templates(t *testing.T) {
+	for _, name := range []string{"config/settings-autonomous.json", "config/settings-interactive.json", "config/settings-readonly.json"} {
+		t.Run(name, func(t *testing.T) {
+			content, err := configFS.ReadFile(name)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := ValidateSettings(content); err != nil {
+				t.Errorf("ValidateSettings(%s) = %v, want nil", name, err)
+			}
+		})
+	}
+}
+
+func TestValidateSettings_Malformed(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{"not json", `{"hooks": `},
+		{"missing hooks", `{"editorMode": "normal"}`},
+		{"hooks not object", `{"hooks": []}`},
+		{"empty command", `{"hooks": {"Stop": [{"matcher": "", "hooks": [{"type": "command", "command": ""}]}]}}`},
+		{"no hook entries", `{"hooks": {"Stop": [{"matcher": "", "hooks": []}]}}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidateSettings([]byte(tt.content)); err == nil {
+				t.Errorf("ValidateSettings(%s) = nil, want error", tt.content)
+			}
+		})
+	}
+}
+
+func TestValidateSettingsForRole_AutonomousRequiresMailInjection(t *testing.T) {
+	interactive, err := configFS.ReadFile("config/settings-interactive.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := validateSettingsForRole(interactive, Interactive); err != nil {
+		t.Errorf("interactive template as Interactive: %v", err)
+	}
+	// The interactive template lacks SessionStart mail injection, so it is
+	// not an acceptable autonomous template.
+	if err := validateSettingsForRole(interactive, Autonomous); err == nil {
+		t.Error("interactive template as Autonomous: want error, got nil")
+	}
+
+	autonomous, err := configFS.ReadFile("config/settings-autonomous.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := validateSettingsForRole(autonomous, Autonomous); err != nil {
+		t.Errorf("autonomous template as Autonomous: %v", err)
+	}
+}
+
+func TestEnsureSettingsForAllRoles(t *testing.T) {
+	townRoot := t.TempDir()
+	roles := map[string]string{
+		"polecat":  "rig/polecats",
+		"witness":  "rig/witness",
+		"refinery": filepath.Join(townRoot, "rig", "refinery"),
+		"crew":     "rig/crew",
+	}
+
+	n, err := EnsureSettingsForAllRoles(townRoot, roles)
+	if err != nil {
+		t.Fatalf("EnsureSettingsForAllRoles failed: %v", err)
+	}
+	if n != len(roles) {
+		t.Errorf("provisioned = %d, want %d", n, len(roles))
+	}
+	for role, dir := range roles {
+		if !filepath.IsAbs(dir) {
+			dir = filepath.Join(townRoot, dir)
+		}
+		if _, err := os.Stat(filepath.Join(dir, ".claude", "settings.json")); err != nil {
+			t.Errorf("%s: settings not created: %v", role, err)
+		}
+	}
+}
+
+func TestEnsureSettingsForAllRoles_PartialFailure(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("permission checks are not reliable on Windows")
+	}
+	if os.Geteuid() == 0 {
+		t.Skip("root ignores directory permissions")
+	}
+
+	townRoot := t.TempDir()
+	readOnlyDir := filepath.Join(townRoot, "witness")
+	if err := os.MkdirAll(readOnlyDir, 0555); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		os.Chmod(readOnlyDir, 0755) // restore so cleanup works
+	})
+
+	roles := map[string]string{
+		"polecat":  "polecats",
+		"witness":  "witness",
+		"refinery": "refinery",
+	}
+
+	n, err := EnsureSettingsForAllRoles(townRoot, roles)
+	if err == nil {
+		t.Fatal("expected error for read-only witness dir")
+	}
+	if n != 2 {
+		t.Errorf("provisioned = %d, want 2", n)
+	}
+	if !strings.Contains(err.Error(), "witness") {
+		t.Errorf("error %q does not name the failed role", err)
+	}
+	if strings.Contains(err.Error(), "polecat (") || strings.Contains(err.Error(), "refinery (") {
+		t.Errorf("error %q names roles that succeeded", err)
+	}
+	for _, dir := range []string{"polecats", "refinery"} {
+		if _, err := os.Stat(filepath.Join(townRoot, dir, ".claude", "settings.json")); err != nil {
+			t.Errorf("%s: settings not created despite other role failing: %v", dir, err)
+		}
+	}
+}
+
+func TestDiffSettings(t *testing.T) {
+	dir := t.TempDir()
+	if err := EnsureSettingsForRole(dir, "polecat"); err != nil {
+		t.Fatalf("EnsureSettingsForRole failed: %v", err)
+	}
+
+	diff, err := DiffSettings(dir, "polecat", ".claude", "settings.json")
+	if err != nil {
+		t.Fatalf("DiffSettings failed: %v", err)
+	}
+	if diff != "" {
+		t.Errorf("expected no diff for freshly rendered settings, got:\n%s", diff)
+	}
+
+	// Change the Stop command and add an extra PreCompact hook.
+	path := filepath.Join(dir, ".claude", "settings.json")
+	settings, err := hooks.LoadSettings(path)
+	if err != nil {
+		t.Fatalf("loading settings: %v", err)
+	}
+	settings.Hooks.Stop[0].Hooks[0].Command = "gt costs record --verbose"
+	settings.Hooks.PreCompact[0].Hooks = append(settings.Hooks.PreCompact[0].Hooks,
+		hooks.Hook{Type: "command", Command: "echo extra"})
+	data, err := hooks.MarshalSettings(settings)
+	if err != nil {
+		t.Fatalf("marshaling settings: %v", err)
+	}
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		t.Fatalf("writing settings: %v", err)
+	}
+
+	diff, err = DiffSettings(dir, "polecat", ".claude", "settings.json")
+	if err != nil {
+		t.Fatalf("DiffSettings failed: %v", err)
+	}
+	for _, want := range []string{
+		`- hooks.Stop[0].hooks[0].command: "export PATH=\"$HOME/go/bin:$HOME/bin:$PATH\" && gt costs record"`,
+		`+ hooks.Stop[0].hooks[0].command: "gt costs record --verbose"`,
+		`+ hooks.PreCompact[0].hooks[1].command: "echo extra"`,
+	} {
+		if !strings.Contains(diff, want) {
+			t.Errorf("diff missing %q, got:\n%s", want, diff)
+		}
+	}
+	if strings.Contains(diff, "hooks.SessionStart") {
+		t.Errorf("diff should not report unchanged keys, got:\n%s", diff)
+	}
+}
+
+func TestDiffSettings_MissingFile(t *testing.T) {
+	if _, err := DiffSettings(t.TempDir(), "mayor", ".claude", "settings.json"); err == nil {
+		t.Fatal("expected error for missing settings file")
+	}
+}
+
+func TestReadOnlyTemplate(t *testing.T) {
+	content, err := configFS.ReadFile(templateFor(ReadOnly))
+	if err != nil {
+		t.Fatal(err)
+	}
+	settings, err := hooks.UnmarshalSettings(content)
+	if err != nil {
+		t.Fatalf("read-only template is not valid JSON: %v", err)
+	}
+
+	blocked := make(map[string]bool)
+	for _, entry := range settings.Hooks.PreToolUse {
+		for _, h := range entry.Hooks {
+			if strings.Contains(h.Command, "exit 2") {
+				blocked[entry.Matcher] = true
+			}
+		}
+	}
+	for _, matcher := range []string{"Edit", "Write", "Bash(*>*)"} {
+		if !blocked[matcher] {
+			t.Errorf("read-only template does not block %q in PreToolUse", matcher)
+		}
+	}
+	if len(settings.Hooks.Stop) != 0 {
+		t.Errorf("read-only template should not run write-capable Stop hooks, got %+v", settings.Hooks.Stop)
+	}
+
+	for _, rt := range []RoleType{Autonomous, Interactive} {
+		other, err := configFS.ReadFile(templateFor(rt))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(other) == string(content) {
+			t.Errorf("read-only template is identical to the %s template", rt)
+		}
+	}
+}
+
+func TestSetDefaultSettingsLocation(t *testing.T) {
+	t.Cleanup(func() { SetDefaultSettingsLocation("", "") })
+
+	SetDefaultSettingsLocation(filepath.Join(".config", "claude"), "settings.json")
+	dir := t.TempDir()
+	if err := EnsureSettings(dir, Interactive); err != nil {
+		t.Fatalf("EnsureSettings failed: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, ".config", "claude", "settings.json")); err != nil {
+		t.Errorf("settings not written to overridden location: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, ".claude")); !os.IsNotExist(err) {
+		t.Errorf("default .claude directory should not be created, stat err = %v", err)
+	}
+
+	dir = t.TempDir()
+	if err := EnsureSettingsForRole(dir, "polecat"); err != nil {
+		t.Fatalf("EnsureSettingsForRole failed: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, ".config", "claude", "settings.json")); err != nil {
+		t.Errorf("EnsureSettingsForRole ignored overridden location: %v", err)
+	}
+}
+
+func TestSetDefaultSettingsLocation_EmptyFallsBack(t *testing.T) {
+	t.Cleanup(func() { SetDefaultSettingsLocation("", "") })
+
+	SetDefaultSettingsLocation("", "custom.json")
+	if subdir, file := DefaultSettingsLocation(); subdir != ".claude" || file != "custom.json" {
+		t.Errorf("DefaultSettingsLocation() = (%q, %q), want (%q, %q)", subdir, file, ".claude", "custom.json")
+	}
+
+	SetDefaultSettingsLocation("", "")
+	if subdir, file := DefaultSettingsLocation(); subdir != ".claude" || file != "settings.json" {
+		t.Errorf("DefaultSettingsLocation() = (%q, %q), want defaults", subdir, file)
+	}
+}
@@ -483,17 +483,9 @@ func buildCollisionReport(townRoot string) (*CollisionReport, error) {
 
 	// Get all tmux sessions
 	t := tmux.NewTmux()
-	sessions, err := t.ListSessions()
+	gtSessions, err := t.ListGastownSessions(session.IsKnownSession)
 	if err != nil {
-		sessions = []string{} // Continue even if tmux not running
-	}
-
-	// Filter to Gas Town sessions
-	var gtSessions []string
-	for _, s := range sessions {
-		if session.IsKnownSession(s) {
-			gtSessions = append(gtSessions, s)
-		}
+		gtSessions = []string{} // Continue even if tmux not running
 	}
 	report.TotalSessions = len(gtSessions)
 
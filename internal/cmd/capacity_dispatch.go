@@ -8,6 +8,7 @@ import (
 	"os/exec"
 	"path/filepath"
 	"sort"
+	"time"
 
 	"github.com/gofrs/flock"
 	"github.com/steveyegge/gastown/internal/beads"
@@ -25,9 +26,8 @@ const maxDispatchFailures = 3
 // Called by both `gt scheduler run` and the daemon heartbeat.
 func dispatchScheduledWork(townRoot, actor string, batchOverride int, dryRun bool) (int, error) {
 	// Acquire exclusive lock to prevent concurrent dispatch
-	runtimeDir := filepath.Join(townRoot, ".runtime")
-	_ = os.MkdirAll(runtimeDir, 0755)
-	lockFile := filepath.Join(runtimeDir, "scheduler-dispatch.lock")
+	lockFile := capacity.DispatchLockFile(townRoot)
+	_ = os.MkdirAll(filepath.Dir(lockFile), 0755)
 	fileLock := flock.New(lockFile)
 	locked, err := fileLock.TryLock()
 	if err != nil {
@@ -239,8 +239,9 @@ func cleanupStaleContexts(townRoot string) {
 		return
 	}
 
-	// First pass: close invalid and circuit-broken contexts, collect work bead IDs
+	// First pass: close invalid, circuit-broken, and expired contexts, collect work bead IDs
 	// that need status checks for stale detection.
+	now := time.Now()
 	var staleCheckContexts []*beads.Issue
 	var staleCheckFields []*capacity.SlingContextFields
 	for _, ctx := range contexts {
@@ -253,6 +254,10 @@ func cleanupStaleContexts(townRoot string) {
 			_ = townBeads.CloseSlingContext(ctx.ID, "circuit-broken")
 			continue
 		}
+		if fields.Expired(now) {
+			_ = townBeads.CloseSlingContext(ctx.ID, "expired")
+			continue
+		}
 		staleCheckContexts = append(staleCheckContexts, ctx)
 		staleCheckFields = append(staleCheckFields, fields)
 	}
@@ -365,6 +370,7 @@ func getReadySlingContexts(townRoot string) ([]capacity.PendingBead, error) {
 		return allContexts[i].ID < allContexts[j].ID // deterministic tiebreaker
 	})
 
+	now := time.Now()
 	seenWork := make(map[string]bool)
 	var result []capacity.PendingBead
 	for _, ctx := range allContexts {
@@ -378,6 +384,11 @@ func getReadySlingContexts(townRoot string) ([]capacity.PendingBead, error) {
 			continue
 		}
 
+		// Expired contexts are treated as absent — cleanupStaleContexts closes them
+		if fields.Expired(now) {
+			continue
+		}
+
 		// Only include if work bead is ready (unblocked)
 		if !readyWorkIDs[fields.WorkBeadID] {
 			continue
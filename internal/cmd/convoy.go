@@ -185,6 +185,7 @@ COMMANDS:
   add       Add issues to an existing convoy (reopens if closed)
   close     Close a convoy (verifies all items done, or use --force)
   land      Land an owned convoy (cleanup worktrees, close convoy)
+  unschedule Remove a convoy's scheduled issues from the scheduler
   status    Show convoy progress, tracked issues, and active workers
   list      List convoys (the dashboard view)`,
 }
@@ -224,7 +225,14 @@ var convoyStatusCmd = &cobra.Command{
 	Long: `Show detailed status for a convoy.
 
 Displays convoy metadata, tracked issues, and completion progress.
-Without an ID, shows status of all active convoys.`,
+Without an ID, shows status of all active convoys.
+
+With --schedule, tallies the tracked issues by scheduler state instead:
+scheduled, dispatched, blocked, unscheduled, or closed.
+
+With --tree, shows the tracked issues as a dependency tree: each issue sits
+under the issue that blocks it, marked with its scheduler state, and blocked
+issues list their open blockers.`,
 	Args: cobra.MaximumNArgs(1),
 	RunE: runConvoyStatus,
 }
@@ -390,6 +398,7 @@ func init() {
 	convoyCmd.AddCommand(convoyLandCmd)
 	convoyCmd.AddCommand(convoyStageCmd)
 	convoyCmd.AddCommand(convoyLaunchCmd)
+	convoyCmd.AddCommand(convoyUnscheduleCmd)
 
 	rootCmd.AddCommand(convoyCmd)
 }
@@ -1538,6 +1547,12 @@ func runConvoyStatus(cmd *cobra.Command, args []string) error {
 
 	// If no ID provided, show all active convoys
 	if len(args) == 0 {
+		if convoyStatusSchedule {
+			return fmt.Errorf("--schedule requires a convoy ID")
+		}
+		if convoyStatusTree {
+			return fmt.Errorf("--tree requires a convoy ID")
+		}
 		return showAllConvoyStatus(townBeads)
 	}
 
@@ -1552,6 +1567,13 @@ func runConvoyStatus(cmd *cobra.Command, args []string) error {
 		convoyID = resolved
 	}
 
+	if convoyStatusSchedule {
+		return showConvoyScheduleStatus(townBeads, convoyID)
+	}
+	if convoyStatusTree {
+		return showConvoyTree(townBeads, convoyID)
+	}
+
 	// Get convoy details
 	showArgs := []string{"show", convoyID, "--json"}
 	showCmd := exec.Command("bd", showArgs...)
@@ -1960,6 +1982,8 @@ type trackedIssueInfo struct {
 	Labels    []string `json:"labels,omitempty"`     // Bead labels (propagated from trackedDependency)
 	Worker    string   `json:"worker,omitempty"`     // Worker currently assigned (e.g., gastown/nux)
 	WorkerAge string   `json:"worker_age,omitempty"` // How long worker has been on this issue
+	Priority  int       `json:"-"` // Bead priority (0 = highest); lowestBeadPriority if unknown
+	UpdatedAt time.Time `json:"-"` // Last update; zero if unknown
 }
 
 // trackedDependency is dep-list data enriched with fresh issue details.
@@ -1971,6 +1995,8 @@ type trackedDependency struct {
 	Assignee       string   `json:"assignee"`
 	DependencyType string   `json:"dependency_type"`
 	Labels         []string `json:"labels"`
+	Priority       *int     `json:"priority"`
+	UpdatedAt      string   `json:"updated_at"`
 	Blocked        bool     `json:"-"`
 }
 
@@ -1993,6 +2019,12 @@ func applyFreshIssueDetails(dep *trackedDependency, details *issueDetails) {
 	// labels are empty clears stale queue labels that would otherwise
 	// suppress stranded issue detection.
 	dep.Labels = details.Labels
+	if details.Priority != nil {
+		dep.Priority = details.Priority
+	}
+	if details.UpdatedAt != "" {
+		dep.UpdatedAt = details.UpdatedAt
+	}
 }
 
 // getTrackedIssues uses bd dep list to get issues tracked by a convoy.
@@ -2056,6 +2088,11 @@ func getTrackedIssues(townBeads, convoyID string) ([]trackedIssueInfo, error) {
 			Blocked:   dep.Blocked,
 			Assignee:  dep.Assignee,
 			Labels:    dep.Labels,
+			Priority:  lowestBeadPriority,
+			UpdatedAt: parseBeadsTimestamp(dep.UpdatedAt),
+		}
+		if dep.Priority != nil {
+			info.Priority = *dep.Priority
 		}
 
 		// Add worker info if available
@@ -2085,6 +2122,8 @@ type issueDetailsJSON struct {
 	BlockedBy      []string          `json:"blocked_by"`
 	BlockedByCount int               `json:"blocked_by_count"`
 	Dependencies   []issueDependency `json:"dependencies"`
+	Priority       *int              `json:"priority"`
+	UpdatedAt      string            `json:"updated_at"`
 }
 
 func (issue issueDetailsJSON) toIssueDetails() *issueDetails {
@@ -2098,6 +2137,8 @@ func (issue issueDetailsJSON) toIssueDetails() *issueDetails {
 		BlockedBy:      issue.BlockedBy,
 		BlockedByCount: issue.BlockedByCount,
 		Dependencies:   issue.Dependencies,
+		Priority:       issue.Priority,
+		UpdatedAt:      issue.UpdatedAt,
 	}
 }
 
@@ -2151,6 +2192,8 @@ type issueDetails struct {
 	BlockedBy      []string
 	BlockedByCount int
 	Dependencies   []issueDependency
+	Priority       *int   // nil if bd didn't report one
+	UpdatedAt      string // bd timestamp; empty if not reported
 }
 
 func (d issueDetails) IsBlocked() bool {
@@ -0,0 +1,98 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/steveyegge/gastown/internal/style"
+)
+
+// convoyStatusSchedule switches gt convoy status to a scheduler tally.
+var convoyStatusSchedule bool
+
+func init() {
+	convoyStatusCmd.Flags().BoolVar(&convoyStatusSchedule, "schedule", false, "Tally tracked issues by scheduler state (scheduled, dispatched, blocked, closed)")
+}
+
+// Scheduler states for a convoy's tracked issues. Each issue is counted once,
+// in the first state that applies.
+const (
+	convoyIssueClosed      = "closed"
+	convoyIssueDispatched  = "dispatched"
+	convoyIssueScheduled   = "scheduled"
+	convoyIssueBlocked     = "blocked"
+	convoyIssueUnscheduled = "unscheduled"
+)
+
+// convoyScheduleStates lists the states in display order.
+var convoyScheduleStates = []string{
+	convoyIssueScheduled,
+	convoyIssueDispatched,
+	convoyIssueBlocked,
+	convoyIssueUnscheduled,
+	convoyIssueClosed,
+}
+
+// classifyConvoyIssue returns the scheduler state of a tracked issue.
+func classifyConvoyIssue(t trackedIssueInfo, scheduled bool) string {
+	switch {
+	case t.Status == "closed" || t.Status == "tombstone":
+		return convoyIssueClosed
+	case t.Assignee != "" || t.Status == "in_progress" || t.Status == "hooked":
+		return convoyIssueDispatched
+	case scheduled:
+		return convoyIssueScheduled
+	case t.Blocked:
+		return convoyIssueBlocked
+	default:
+		return convoyIssueUnscheduled
+	}
+}
+
+// tallyConvoySchedule counts tracked issues by scheduler state.
+func tallyConvoySchedule(tracked []trackedIssueInfo, scheduledSet map[string]bool) map[string]int {
+	counts := make(map[string]int, len(convoyScheduleStates))
+	for _, state := range convoyScheduleStates {
+		counts[state] = 0
+	}
+	for _, t := range tracked {
+		counts[classifyConvoyIssue(t, scheduledSet[t.ID])]++
+	}
+	return counts
+}
+
+// showConvoyScheduleStatus prints how a convoy's tracked issues stand with
+// the scheduler. Read-only.
+func showConvoyScheduleStatus(townBeads, convoyID string) error {
+	if err := verifyBeadExists(convoyID); err != nil {
+		return fmt.Errorf("convoy '%s' not found", convoyID)
+	}
+
+	tracked, err := getTrackedIssues(townBeads, convoyID)
+	if err != nil {
+		return fmt.Errorf("getting tracked issues: %w", err)
+	}
+
+	var beadIDs []string
+	for _, t := range tracked {
+		beadIDs = append(beadIDs, t.ID)
+	}
+	counts := tallyConvoySchedule(tracked, areScheduled(beadIDs))
+
+	if convoyStatusJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(struct {
+			ConvoyID string         `json:"convoy_id"`
+			Total    int            `json:"total"`
+			Counts   map[string]int `json:"counts"`
+		}{convoyID, len(tracked), counts})
+	}
+
+	fmt.Printf("%s %s (%d tracked)\n", style.Bold.Render("Schedule:"), convoyID, len(tracked))
+	for _, state := range convoyScheduleStates {
+		fmt.Printf("  %-12s %d\n", state+":", counts[state])
+	}
+	return nil
+}
@@ -0,0 +1,40 @@
+package cmd
+
+import "testing"
+
+func TestTallyConvoySchedule(t *testing.T) {
+	tracked := []trackedIssueInfo{
+		{ID: "gt-1", Status: "open"},                                     // scheduled
+		{ID: "gt-2", Status: "open"},                                     // scheduled
+		{ID: "gt-3", Status: "hooked", Assignee: "gastown/polecats/nux"}, // dispatched
+		{ID: "gt-4", Status: "closed"},                                   // closed
+		{ID: "gt-5", Status: "closed"},                                   // closed, stale schedule entry
+		{ID: "gt-6", Status: "open", Blocked: true},                      // blocked
+		{ID: "gt-7", Status: "open", Blocked: true},                      // scheduled, waiting on blockers
+		{ID: "gt-8", Status: "open"},                                     // unscheduled
+	}
+	scheduled := map[string]bool{"gt-1": true, "gt-2": true, "gt-5": true, "gt-7": true}
+
+	got := tallyConvoySchedule(tracked, scheduled)
+	want := map[string]int{
+		convoyIssueScheduled:   3,
+		convoyIssueDispatched:  1,
+		convoyIssueBlocked:     1,
+		convoyIssueUnscheduled: 1,
+		convoyIssueClosed:      2,
+	}
+	for state, n := range want {
+		if got[state] != n {
+			t.Errorf("%s = %d, want %d (all: %v)", state, got[state], n, got)
+		}
+	}
+}
+
+func TestTallyConvoySchedule_EmptyReportsZeroes(t *testing.T) {
+	got := tallyConvoySchedule(nil, nil)
+	for _, state := range convoyScheduleStates {
+		if n, ok := got[state]; !ok || n != 0 {
+			t.Errorf("%s = %d (present=%v), want 0", state, n, ok)
+		}
+	}
+}
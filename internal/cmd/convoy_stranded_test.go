@@ -74,6 +74,22 @@ func TestApplyFreshIssueDetails_SetsBlockedFlag(t *testing.T) {
 	}
 }
 
+func TestApplyFreshIssueDetails_PriorityAndUpdatedAt(t *testing.T) {
+	stale := 3
+	dep := trackedDependency{ID: "gt-123", Status: "open", Priority: &stale, UpdatedAt: "2026-01-01T00:00:00Z"}
+
+	applyFreshIssueDetails(&dep, &issueDetails{ID: "gt-123", Status: "open"})
+	if *dep.Priority != 3 || dep.UpdatedAt != "2026-01-01T00:00:00Z" {
+		t.Errorf("details without priority/updated_at overwrote dep: priority %d, updated_at %q", *dep.Priority, dep.UpdatedAt)
+	}
+
+	fresh := 0
+	applyFreshIssueDetails(&dep, &issueDetails{ID: "gt-123", Status: "open", Priority: &fresh, UpdatedAt: "2026-03-10T12:00:00Z"})
+	if *dep.Priority != 0 || dep.UpdatedAt != "2026-03-10T12:00:00Z" {
+		t.Errorf("priority %d, updated_at %q; want the fresh P0 and 2026-03-10T12:00:00Z", *dep.Priority, dep.UpdatedAt)
+	}
+}
+
 func TestIssueDetailsIsBlocked(t *testing.T) {
 	tests := []struct {
 		name string
@@ -0,0 +1,191 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/style"
+)
+
+// convoyStatusTree switches gt convoy status to a dependency tree annotated
+// with each issue's scheduler state.
+var convoyStatusTree bool
+
+func init() {
+	convoyStatusCmd.Flags().BoolVar(&convoyStatusTree, "tree", false, "Show tracked issues as a dependency tree with scheduler state and blockers")
+}
+
+// convoyTreeNode is one tracked issue in a convoy's dependency tree.
+// Children are the tracked issues it blocks; each issue appears once, under
+// its first blocker (by ID) in the convoy.
+type convoyTreeNode struct {
+	ID        string            `json:"id"`
+	Title     string            `json:"title"`
+	State     string            `json:"state"`
+	BlockedBy []string          `json:"blocked_by,omitempty"` // Open blockers, in or out of the convoy
+	Children  []*convoyTreeNode `json:"children,omitempty"`
+}
+
+// buildConvoyTree arranges tracked issues into a forest by their blocking
+// dependencies (see isBlockingDepType). deps maps an issue ID to its
+// dependencies as returned by getBeadInfo; scheduled is the set of issues
+// with an open sling context. Issues with no blocker in the convoy are roots,
+// as is the lowest ID on any blocking cycle, so every tracked issue is shown.
+func buildConvoyTree(tracked []trackedIssueInfo, deps map[string][]beads.IssueDep, scheduled map[string]bool) []*convoyTreeNode {
+	nodes := make(map[string]*convoyTreeNode, len(tracked))
+	for _, t := range tracked {
+		nodes[t.ID] = &convoyTreeNode{ID: t.ID, Title: t.Title}
+	}
+
+	parent := make(map[string]string)
+	for _, t := range tracked {
+		node := nodes[t.ID]
+		var inConvoy []string
+		for _, d := range deps[t.ID] {
+			if !isBlockingDepType(d.DependencyType) {
+				continue
+			}
+			id := beads.ExtractIssueID(d.ID)
+			if _, ok := nodes[id]; ok && id != t.ID {
+				inConvoy = append(inConvoy, id)
+			}
+			if d.Status != "closed" && d.Status != "tombstone" {
+				node.BlockedBy = append(node.BlockedBy, id)
+			}
+		}
+		sort.Strings(node.BlockedBy)
+		if len(inConvoy) > 0 {
+			sort.Strings(inConvoy)
+			parent[t.ID] = inConvoy[0]
+		}
+
+		t.Blocked = t.Blocked || len(node.BlockedBy) > 0
+		node.State = classifyConvoyIssue(t, scheduled[t.ID])
+	}
+
+	children := make(map[string][]string)
+	for child, p := range parent {
+		children[p] = append(children[p], child)
+	}
+
+	ids := make([]string, 0, len(nodes))
+	for id := range nodes {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	visited := make(map[string]bool, len(nodes))
+	var attach func(id string) *convoyTreeNode
+	attach = func(id string) *convoyTreeNode {
+		visited[id] = true
+		node := nodes[id]
+		kids := children[id]
+		sort.Strings(kids)
+		for _, kid := range kids {
+			if !visited[kid] {
+				node.Children = append(node.Children, attach(kid))
+			}
+		}
+		return node
+	}
+
+	var roots []*convoyTreeNode
+	for _, id := range ids {
+		if _, ok := parent[id]; !ok {
+			roots = append(roots, attach(id))
+		}
+	}
+	// Whatever is left sits on a blocking cycle; break it at the lowest ID.
+	for _, id := range ids {
+		if !visited[id] {
+			roots = append(roots, attach(id))
+		}
+	}
+	return roots
+}
+
+// convoyStateMarker returns the status marker for a scheduler state.
+func convoyStateMarker(state string) string {
+	switch state {
+	case convoyIssueClosed:
+		return style.Success.Render("✓")
+	case convoyIssueDispatched:
+		return style.Info.Render("▶")
+	case convoyIssueScheduled:
+		return style.Info.Render("◷")
+	case convoyIssueBlocked:
+		return style.Error.Render("●")
+	default:
+		return style.Dim.Render("○")
+	}
+}
+
+// renderConvoyTree renders a convoy tree with tree-drawing connectors, one
+// issue per line: <marker> <id>: <title> [<state>] ← blocked by: <ids>
+func renderConvoyTree(roots []*convoyTreeNode) string {
+	var buf strings.Builder
+	var render func(node *convoyTreeNode, prefix string, isLast bool)
+	render = func(node *convoyTreeNode, prefix string, isLast bool) {
+		connector, childPrefix := "├── ", prefix+"│   "
+		if isLast {
+			connector, childPrefix = "└── ", prefix+"    "
+		}
+		fmt.Fprintf(&buf, "%s%s%s %s: %s %s", prefix, connector, convoyStateMarker(node.State),
+			node.ID, node.Title, style.Dim.Render("["+node.State+"]"))
+		if len(node.BlockedBy) > 0 {
+			fmt.Fprintf(&buf, " ← blocked by: %s", strings.Join(node.BlockedBy, ", "))
+		}
+		buf.WriteString("\n")
+		for i, child := range node.Children {
+			render(child, childPrefix, i == len(node.Children)-1)
+		}
+	}
+	for i, root := range roots {
+		render(root, "", i == len(roots)-1)
+	}
+	return buf.String()
+}
+
+// showConvoyTree prints a convoy's tracked issues as a dependency tree
+// annotated with scheduler state. Read-only.
+func showConvoyTree(townBeads, convoyID string) error {
+	if err := verifyBeadExists(convoyID); err != nil {
+		return fmt.Errorf("convoy '%s' not found", convoyID)
+	}
+
+	tracked, err := getTrackedIssues(townBeads, convoyID)
+	if err != nil {
+		return fmt.Errorf("getting tracked issues: %w", err)
+	}
+
+	var beadIDs []string
+	deps := make(map[string][]beads.IssueDep, len(tracked))
+	for _, t := range tracked {
+		beadIDs = append(beadIDs, t.ID)
+		info, err := getBeadInfo(t.ID)
+		if err != nil {
+			style.PrintWarning("could not read dependencies of %s: %v", t.ID, err)
+			continue
+		}
+		deps[t.ID] = info.Dependencies
+	}
+	roots := buildConvoyTree(tracked, deps, areScheduled(beadIDs))
+
+	if convoyStatusJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(struct {
+			ConvoyID string            `json:"convoy_id"`
+			Total    int               `json:"total"`
+			Tree     []*convoyTreeNode `json:"tree"`
+		}{convoyID, len(tracked), roots})
+	}
+
+	fmt.Printf("🚚 %s (%d tracked)\n", convoyID, len(tracked))
+	fmt.Print(renderConvoyTree(roots))
+	return nil
+}
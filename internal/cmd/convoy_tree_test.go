@@ -0,0 +1,88 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/gastown/internal/beads"
+)
+
+// flattenConvoyTree lists "depth:id" in render order.
+func flattenConvoyTree(nodes []*convoyTreeNode, depth int, out *[]string) {
+	for _, n := range nodes {
+		*out = append(*out, strings.Repeat(">", depth)+n.ID)
+		flattenConvoyTree(n.Children, depth+1, out)
+	}
+}
+
+func TestBuildConvoyTree(t *testing.T) {
+	// gt-1 (closed) → gt-2 (scheduled) → gt-3, gt-4; gt-4 also waits on the
+	// external gt-x. gt-5 is independent; gt-6 and gt-7 block each other.
+	tracked := []trackedIssueInfo{
+		{ID: "gt-1", Title: "schema", Status: "closed"},
+		{ID: "gt-2", Title: "api", Status: "open"},
+		{ID: "gt-3", Title: "cli", Status: "open"},
+		{ID: "gt-4", Title: "docs", Status: "hooked", Assignee: "gastown/polecats/nux"},
+		{ID: "gt-5", Title: "cleanup", Status: "open"},
+		{ID: "gt-6", Title: "ping", Status: "open"},
+		{ID: "gt-7", Title: "pong", Status: "open"},
+	}
+	deps := map[string][]beads.IssueDep{
+		"gt-2": {{ID: "gt-1", Status: "closed", DependencyType: "blocks"}},
+		"gt-3": {
+			{ID: "gt-2", Status: "open", DependencyType: "blocks"},
+			{ID: "gt-9", Status: "open", DependencyType: "related"},
+		},
+		"gt-4": {
+			{ID: "external:gt:gt-x", Status: "open", DependencyType: "waits-for"},
+			{ID: "gt-2", Status: "open", DependencyType: "blocks"},
+		},
+		"gt-6": {{ID: "gt-7", Status: "open", DependencyType: "blocks"}},
+		"gt-7": {{ID: "gt-6", Status: "open", DependencyType: "blocks"}},
+	}
+	scheduled := map[string]bool{"gt-2": true, "gt-5": true}
+
+	roots := buildConvoyTree(tracked, deps, scheduled)
+
+	var got []string
+	flattenConvoyTree(roots, 0, &got)
+	want := []string{"gt-1", ">gt-2", ">>gt-3", ">>gt-4", "gt-5", "gt-6", ">gt-7"}
+	if strings.Join(got, " ") != strings.Join(want, " ") {
+		t.Fatalf("tree = %v, want %v", got, want)
+	}
+
+	byID := make(map[string]*convoyTreeNode)
+	var index func([]*convoyTreeNode)
+	index = func(nodes []*convoyTreeNode) {
+		for _, n := range nodes {
+			byID[n.ID] = n
+			index(n.Children)
+		}
+	}
+	index(roots)
+
+	states := map[string]string{
+		"gt-1": convoyIssueClosed,
+		"gt-2": convoyIssueScheduled, // its only blocker is closed
+		"gt-3": convoyIssueBlocked,
+		"gt-4": convoyIssueDispatched,
+		"gt-5": convoyIssueScheduled,
+		"gt-6": convoyIssueBlocked,
+	}
+	for id, state := range states {
+		if byID[id].State != state {
+			t.Errorf("%s state = %q, want %q", id, byID[id].State, state)
+		}
+	}
+	if got := strings.Join(byID["gt-4"].BlockedBy, ","); got != "gt-2,gt-x" {
+		t.Errorf("gt-4 blocked by %q, want gt-2,gt-x", got)
+	}
+	if len(byID["gt-2"].BlockedBy) != 0 {
+		t.Errorf("gt-2 blocked by %v, want none (blocker closed)", byID["gt-2"].BlockedBy)
+	}
+
+	out := renderConvoyTree(roots)
+	if !strings.Contains(out, "gt-3: cli") || !strings.Contains(out, "← blocked by: gt-2") {
+		t.Errorf("rendered tree missing blocker annotation:\n%s", out)
+	}
+}
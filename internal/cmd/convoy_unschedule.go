@@ -0,0 +1,179 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+var (
+	convoyUnscheduleDryRun bool
+	convoyUnscheduleLabels []string
+)
+
+var convoyUnscheduleCmd = &cobra.Command{
+	Use:     "unschedule <convoy-id>",
+	Aliases: []string{"dequeue"},
+	Short:   "Remove a convoy's tracked issues from the scheduler",
+	Long: `Remove a convoy's scheduled issues from the scheduler without closing them.
+
+This is the inverse of 'gt sling <convoy-id>' in deferred dispatch mode: the
+sling context beads for each tracked issue are closed, so the scheduler no
+longer dispatches them. The issues themselves stay open.
+
+Issues that are already assigned or in progress are left alone.
+
+Examples:
+  gt convoy unschedule hq-cv-abc                  # Unschedule all tracked issues
+  gt convoy unschedule hq-cv-abc --label frontend # Only issues labeled "frontend"
+  gt convoy unschedule hq-cv-abc --dry-run        # Preview`,
+	Args: cobra.ExactArgs(1),
+	RunE: runConvoyUnschedule,
+}
+
+func init() {
+	convoyUnscheduleCmd.Flags().BoolVar(&convoyUnscheduleDryRun, "dry-run", false, "Show what would be unscheduled without doing it")
+	convoyUnscheduleCmd.Flags().StringArrayVar(&convoyUnscheduleLabels, "label", nil, "Only unschedule issues carrying this label (repeatable, all must match)")
+}
+
+// unscheduleCandidate is a tracked issue with open sling contexts to close.
+type unscheduleCandidate struct {
+	ID         string
+	ContextIDs []string
+}
+
+// unscheduleSkips tallies tracked issues left in place by unschedule.
+type unscheduleSkips struct {
+	Active       int // assigned or in progress
+	NotScheduled int // no open sling context
+	Label        int // filtered out by --label
+}
+
+// slingContextsByWorkBead maps each work bead ID to its open sling context IDs.
+func slingContextsByWorkBead(contexts []*beads.Issue) map[string][]string {
+	byBead := make(map[string][]string)
+	for _, ctx := range contexts {
+		fields := beads.ParseSlingContextFields(ctx.Description)
+		if fields == nil || fields.WorkBeadID == "" {
+			continue
+		}
+		byBead[fields.WorkBeadID] = append(byBead[fields.WorkBeadID], ctx.ID)
+	}
+	return byBead
+}
+
+// planConvoyUnschedule selects the tracked issues whose sling contexts should
+// be closed.
+func planConvoyUnschedule(tracked []trackedIssueInfo, contextsByBead map[string][]string, labels []string) ([]unscheduleCandidate, unscheduleSkips) {
+	var candidates []unscheduleCandidate
+	var skips unscheduleSkips
+	for _, t := range tracked {
+		if !hasAllLabels(t.Labels, labels) {
+			skips.Label++
+			continue
+		}
+		if t.Assignee != "" || t.Status == "in_progress" || t.Status == "hooked" {
+			skips.Active++
+			continue
+		}
+		contextIDs := contextsByBead[t.ID]
+		if len(contextIDs) == 0 {
+			skips.NotScheduled++
+			continue
+		}
+		candidates = append(candidates, unscheduleCandidate{ID: t.ID, ContextIDs: contextIDs})
+	}
+	return candidates, skips
+}
+
+func runConvoyUnschedule(cmd *cobra.Command, args []string) error {
+	convoyID := args[0]
+
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return err
+	}
+
+	if err := verifyBeadExists(convoyID); err != nil {
+		return fmt.Errorf("convoy '%s' not found", convoyID)
+	}
+
+	tracked, err := getTrackedIssues(filepath.Join(townRoot, ".beads"), convoyID)
+	if err != nil {
+		return fmt.Errorf("getting tracked issues: %w", err)
+	}
+
+	contexts, err := listAllSlingContexts(townRoot)
+	if err != nil {
+		return fmt.Errorf("listing sling contexts: %w", err)
+	}
+
+	candidates, skips := planConvoyUnschedule(tracked, slingContextsByWorkBead(contexts), convoyUnscheduleLabels)
+
+	if len(candidates) == 0 {
+		fmt.Printf("No scheduled issues to remove from convoy %s\n", convoyID)
+		printUnscheduleSkips(skips, "  ")
+		return nil
+	}
+
+	if convoyUnscheduleDryRun {
+		fmt.Printf("%s Would unschedule %d issue(s) from convoy %s:\n",
+			style.Bold.Render("DRY-RUN"), len(candidates), convoyID)
+		for _, c := range candidates {
+			fmt.Printf("  Would unschedule: %s (%d context(s))\n", c.ID, len(c.ContextIDs))
+		}
+		printUnscheduleSkips(skips, "")
+		return nil
+	}
+
+	townBeads := beads.NewWithBeadsDir(townRoot, filepath.Join(townRoot, ".beads"))
+	removed, err := unscheduleCandidates(candidates, func(contextID string) error {
+		return townBeads.CloseSlingContext(contextID, "unscheduled")
+	})
+
+	fmt.Printf("%s Unscheduled %d/%d issue(s) from convoy %s\n",
+		style.Bold.Render("✓"), removed, len(candidates), convoyID)
+	printUnscheduleSkips(skips, "  ")
+	if err != nil {
+		return fmt.Errorf("convoy %s: %w", convoyID, err)
+	}
+	return nil
+}
+
+// unscheduleCandidates closes every sling context of each candidate and
+// returns how many candidates were fully removed from the scheduler. If any
+// candidate is still scheduled afterwards, the error names them.
+func unscheduleCandidates(candidates []unscheduleCandidate, closeContext func(contextID string) error) (int, error) {
+	removed := 0
+	var failed []string
+	for _, c := range candidates {
+		ok := true
+		for _, ctxID := range c.ContextIDs {
+			if err := closeContext(ctxID); err != nil {
+				fmt.Printf("  %s Could not close context %s for %s: %v\n", style.Dim.Render("Warning:"), ctxID, c.ID, err)
+				ok = false
+			}
+		}
+		if ok {
+			removed++
+		} else {
+			failed = append(failed, c.ID)
+		}
+	}
+	if len(failed) > 0 {
+		return removed, fmt.Errorf("could not unschedule %d of %d issue(s): %v", len(failed), len(candidates), failed)
+	}
+	return removed, nil
+}
+
+func printUnscheduleSkips(skips unscheduleSkips, indent string) {
+	if skips.Active == 0 && skips.NotScheduled == 0 && skips.Label == 0 {
+		return
+	}
+	fmt.Printf("%sSkipped: %d assigned/in progress, %d not scheduled, %d filtered by label\n",
+		indent, skips.Active, skips.NotScheduled, skips.Label)
+}
@@ -0,0 +1,110 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/scheduler/capacity"
+)
+
+func slingContextIssue(t *testing.T, id, workBeadID string) *beads.Issue {
+	t.Helper()
+	desc, err := json.Marshal(capacity.SlingContextFields{Version: 1, WorkBeadID: workBeadID, TargetRig: "gastown"})
+	if err != nil {
+		t.Fatalf("marshal sling context: %v", err)
+	}
+	return &beads.Issue{ID: id, Description: string(desc)}
+}
+
+func TestConvoyUnschedule_RemovesScheduledIssues(t *testing.T) {
+	// Three issues were scheduled from the convoy; gt-3 has since been picked up.
+	open := map[string]*beads.Issue{
+		"hq-ctx-1": slingContextIssue(t, "hq-ctx-1", "gt-1"),
+		"hq-ctx-2": slingContextIssue(t, "hq-ctx-2", "gt-2"),
+		"hq-ctx-3": slingContextIssue(t, "hq-ctx-3", "gt-3"),
+		"hq-ctx-x": slingContextIssue(t, "hq-ctx-x", "gt-other"),
+	}
+	tracked := []trackedIssueInfo{
+		{ID: "gt-1", Status: "open", Labels: []string{"frontend"}},
+		{ID: "gt-2", Status: "open", Labels: []string{"backend"}},
+		{ID: "gt-3", Status: "in_progress", Assignee: "gastown/polecats/nux"},
+		{ID: "gt-4", Status: "open"},
+	}
+
+	listOpen := func() []*beads.Issue {
+		var out []*beads.Issue
+		for _, ctx := range open {
+			out = append(out, ctx)
+		}
+		return out
+	}
+
+	candidates, skips := planConvoyUnschedule(tracked, slingContextsByWorkBead(listOpen()), nil)
+	if len(candidates) != 2 {
+		t.Fatalf("candidates = %+v, want gt-1 and gt-2", candidates)
+	}
+	if skips.Active != 1 || skips.NotScheduled != 1 || skips.Label != 0 {
+		t.Errorf("skips = %+v, want 1 active, 1 not scheduled", skips)
+	}
+
+	removed, err := unscheduleCandidates(candidates, func(contextID string) error {
+		delete(open, contextID)
+		return nil
+	})
+	if removed != 2 || err != nil {
+		t.Errorf("unscheduleCandidates() = %d, %v; want 2, nil", removed, err)
+	}
+
+	remaining := slingContextsByWorkBead(listOpen())
+	for _, id := range []string{"gt-1", "gt-2"} {
+		if len(remaining[id]) != 0 {
+			t.Errorf("%s still scheduled: %v", id, remaining[id])
+		}
+	}
+	if len(remaining["gt-3"]) != 1 || len(remaining["gt-other"]) != 1 {
+		t.Errorf("unrelated contexts were closed: %v", remaining)
+	}
+}
+
+func TestConvoyUnschedule_LabelFilter(t *testing.T) {
+	contexts := slingContextsByWorkBead([]*beads.Issue{
+		slingContextIssue(t, "hq-ctx-1", "gt-1"),
+		slingContextIssue(t, "hq-ctx-2", "gt-2"),
+		slingContextIssue(t, "hq-ctx-2b", "gt-2"),
+	})
+	tracked := []trackedIssueInfo{
+		{ID: "gt-1", Status: "open", Labels: []string{"backend"}},
+		{ID: "gt-2", Status: "open", Labels: []string{"frontend"}},
+	}
+
+	candidates, skips := planConvoyUnschedule(tracked, contexts, []string{"frontend"})
+	if len(candidates) != 1 || candidates[0].ID != "gt-2" || len(candidates[0].ContextIDs) != 2 {
+		t.Fatalf("candidates = %+v, want gt-2 with both contexts", candidates)
+	}
+	if skips.Label != 1 {
+		t.Errorf("skips.Label = %d, want 1", skips.Label)
+	}
+}
+
+func TestConvoyUnschedule_FailedCloseIsAnError(t *testing.T) {
+	candidates := []unscheduleCandidate{
+		{ID: "gt-1", ContextIDs: []string{"hq-ctx-1"}},
+		{ID: "gt-2", ContextIDs: []string{"hq-ctx-2", "hq-ctx-2b"}},
+	}
+
+	removed, err := unscheduleCandidates(candidates, func(contextID string) error {
+		if contextID == "hq-ctx-2b" {
+			return errors.New("dolt unavailable")
+		}
+		return nil
+	})
+	if removed != 1 {
+		t.Errorf("removed = %d, want 1", removed)
+	}
+	if err == nil || !strings.Contains(err.Error(), "gt-2") {
+		t.Errorf("unscheduleCandidates() err = %v, want an error naming gt-2", err)
+	}
+}
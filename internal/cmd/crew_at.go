@@ -1,9 +1,9 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
 	"os"
-	"strings"
 
 	"github.com/spf13/cobra"
 	"github.com/steveyegge/gastown/internal/config"
@@ -304,12 +304,12 @@ func runCrewAt(cmd *cobra.Command, args []string) error {
 			}
 			if err := t.RespawnPane(paneID, startupCmd); err != nil {
 				// If pane is stale (session exists but pane doesn't), recreate the session
-				if strings.Contains(err.Error(), "can't find pane") {
+				if errors.Is(err, tmux.ErrPaneNotFound) {
 					if crewAtRetried {
 						return fmt.Errorf("stale session persists after cleanup: %w", err)
 					}
 					fmt.Printf("Stale session detected, recreating...\n")
-					if killErr := t.KillSession(sessionID); killErr != nil && killErr != tmux.ErrSessionNotFound {
+					if killErr := t.KillSession(sessionID); killErr != nil && !errors.Is(killErr, tmux.ErrSessionNotFound) {
 						return fmt.Errorf("failed to kill stale session: %w", killErr)
 					}
 					crewAtRetried = true
@@ -16,7 +16,12 @@ var (
 	doctorRig             string
 	doctorRestartSessions bool
 	doctorNoStart         bool
+	doctorDryRun          bool
 	doctorSlow            string
+	doctorJobs            int
+	doctorJSON            bool
+	doctorOnly            []string
+	doctorSkip            []string
 )
 
 var doctorCmd = &cobra.Command{
@@ -43,6 +48,7 @@ Town root protection:
 Infrastructure checks:
   - stale-binary             Check if gt binary is up to date with repo
   - beads-binary             Check that beads (bd) is installed and meets minimum version
+  - tmux-binary              Check that tmux is installed and its server is reachable
   - daemon                   Check if daemon is running (fixable)
   - boot-health              Check Boot watchdog health (vet mode)
   - town-beads-config        Verify town .beads/config.yaml exists (fixable)
@@ -85,8 +91,10 @@ Routing checks (fixable):
 Session hook checks:
   - session-hooks            Check settings.json use session-start.sh
   - claude-settings          Check Claude settings.json match templates (fixable)
+  - settings-template-drift  Detect settings.json drift from role templates (fixable)
   - deprecated-merge-queue-keys  Detect stale deprecated keys in merge_queue config (fixable)
   - stale-task-dispatch      Detect stale task-dispatch guard in settings.json (fixable)
+  - orphaned-sling-wisps     Detect sling wisps whose work bead is closed or missing (fixable)
 
 Dolt checks:
   - dolt-binary              Check that dolt is installed and meets minimum version
@@ -102,8 +110,12 @@ Patrol checks:
 
 Use --fix to attempt automatic fixes for issues that support it.
 Use --no-start with --fix to suppress starting the daemon and agents.
+Use --dry-run with --fix to preview fixes; checks that can't preview are skipped.
 Use --rig to check a specific rig instead of the entire workspace.
-Use --slow to highlight slow checks (default threshold: 1s, e.g. --slow=500ms).`,
+Use --slow to highlight slow checks (default threshold: 1s, e.g. --slow=500ms).
+Use --jobs to run up to N checks at once; fixes always run one at a time.
+Use --json to print results as a JSON array instead of human-readable text.
+Use --only or --skip with comma-separated check names to run a subset of checks.`,
 	RunE: runDoctor,
 }
 
@@ -113,7 +125,13 @@ func init() {
 	doctorCmd.Flags().StringVar(&doctorRig, "rig", "", "Check specific rig only")
 	doctorCmd.Flags().BoolVar(&doctorRestartSessions, "restart-sessions", false, "Restart patrol sessions when fixing stale settings (use with --fix)")
 	doctorCmd.Flags().BoolVar(&doctorNoStart, "no-start", false, "Suppress starting daemon/agents during --fix")
+	doctorCmd.Flags().BoolVar(&doctorDryRun, "dry-run", false, "Preview fixes without applying them (use with --fix)")
 	doctorCmd.Flags().StringVar(&doctorSlow, "slow", "", "Highlight slow checks (optional threshold, default 1s)")
+	doctorCmd.Flags().IntVarP(&doctorJobs, "jobs", "j", 1, "Number of checks to run concurrently (ignored with --fix)")
+	doctorCmd.Flags().BoolVar(&doctorJSON, "json", false, "Output results as JSON")
+	doctorCmd.Flags().StringSliceVar(&doctorOnly, "only", nil, "Run only the named checks (comma-separated)")
+	doctorCmd.Flags().StringSliceVar(&doctorSkip, "skip", nil, "Skip the named checks (comma-separated)")
+	doctorCmd.MarkFlagsMutuallyExclusive("only", "skip")
 	// Allow --slow without a value (uses default 1s)
 	doctorCmd.Flags().Lookup("slow").NoOptDefVal = "1s"
 	rootCmd.AddCommand(doctorCmd)
@@ -133,6 +151,7 @@ func runDoctor(cmd *cobra.Command, args []string) error {
 		Verbose:         doctorVerbose,
 		RestartSessions: doctorRestartSessions,
 		NoStart:         doctorNoStart,
+		DryRun:          doctorDryRun,
 	}
 
 	// Create doctor and register checks
@@ -155,6 +174,7 @@ func runDoctor(cmd *cobra.Command, args []string) error {
 	// its EnsureSettingsForRole sees stale files → returns early → sessions
 	// start with missing PATH exports. See gt-99u.
 	d.Register(doctor.NewClaudeSettingsCheck())
+	d.Register(doctor.NewSettingsTemplateCheck())
 	d.Register(doctor.NewDaemonCheck())
 	d.Register(doctor.NewBootHealthCheck())
 	d.Register(doctor.NewTownBeadsConfigCheck())
@@ -180,6 +200,7 @@ func runDoctor(cmd *cobra.Command, args []string) error {
 	d.Register(doctor.NewCloneDivergenceCheck())
 	d.Register(doctor.NewDefaultBranchAllRigsCheck())
 	d.Register(doctor.NewIdentityCollisionCheck())
+	d.Register(doctor.NewTmuxBinaryCheck())
 	d.Register(doctor.NewLinkedPaneCheck())
 	d.Register(doctor.NewThemeCheck())
 	d.Register(doctor.NewCrashReportCheck())
@@ -225,6 +246,7 @@ func runDoctor(cmd *cobra.Command, args []string) error {
 	d.Register(doctor.NewHookAttachmentValidCheck())
 	d.Register(doctor.NewHookSingletonCheck())
 	d.Register(doctor.NewOrphanedAttachmentsCheck())
+	d.Register(doctor.NewOrphanedSlingWispCheck())
 
 	// Hooks sync check
 	d.Register(doctor.NewStaleTaskDispatchCheck())
@@ -234,6 +256,7 @@ func runDoctor(cmd *cobra.Command, args []string) error {
 	d.Register(doctor.NewDoltBinaryCheck())
 	d.Register(doctor.NewDoltMetadataCheck())
 	d.Register(doctor.NewDoltServerReachableCheck())
+	d.Register(doctor.NewDoltPortCheck())
 	d.Register(doctor.NewDoltOrphanedDatabaseCheck())
 	d.Register(doctor.NewUnregisteredBeadsDirsCheck())
 	d.Register(doctor.NewNullAssigneeCheck())
@@ -256,6 +279,33 @@ func runDoctor(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	if err := d.Filter(doctorOnly, doctorSkip); err != nil {
+		return err
+	}
+
+	if doctorJobs < 1 {
+		return fmt.Errorf("--jobs must be at least 1, got %d", doctorJobs)
+	}
+	d.SetWorkers(doctorJobs)
+
+	if doctorJSON {
+		// Keep stdout for the JSON report; fix progress goes to stderr.
+		ctx.Out = os.Stderr
+		var report *doctor.Report
+		if doctorFix {
+			report = d.Fix(ctx)
+		} else {
+			report = d.Run(ctx)
+		}
+		if err := report.WriteJSON(os.Stdout); err != nil {
+			return err
+		}
+		if report.HasErrors() {
+			return NewSilentExit(1)
+		}
+		return nil
+	}
+
 	// Run checks with streaming output
 	fmt.Println() // Initial blank line
 	var report *doctor.Report
@@ -64,8 +64,11 @@ The server will run until stopped with 'gt dolt stop'.`,
 var doltStopCmd = &cobra.Command{
 	Use:   "stop",
 	Short: "Stop the Dolt server",
-	Long:  `Stop the running Dolt SQL server.`,
-	RunE:  runDoltStop,
+	Long: `Stop the running Dolt SQL server.
+
+Pending working-set changes in each database are committed before the
+server is sent SIGTERM, so nothing written since the last commit is lost.`,
+	RunE: runDoltStop,
 }
 
 var doltRestartCmd = &cobra.Command{
@@ -268,6 +271,70 @@ After migration, 'bd mol wisp list' will work and agent lifecycle
 	RunE: runDoltMigrateWisps,
 }
 
+var doltExportCmd = &cobra.Command{
+	Use:   "export <path>",
+	Short: "Export a beads database to a portable SQL dump",
+	Long: `Dump a database served by this town's Dolt server to a SQL file.
+
+The dump contains schema and data and can be moved to another machine and
+loaded with 'gt dolt import'. The town's configured Dolt server is always
+used, so the right data directory and port are targeted.
+
+Examples:
+  gt dolt export hq.sql               # Export town beads (hq)
+  gt dolt export gastown.sql --db gastown`,
+	Args: cobra.ExactArgs(1),
+	RunE: runDoltExport,
+}
+
+var doltImportCmd = &cobra.Command{
+	Use:   "import <path>",
+	Short: "Import a beads database from a SQL dump",
+	Long: `Load a SQL dump produced by 'gt dolt export' into this town's Dolt server.
+
+The database is created if it does not exist. Importing into a database that
+already has tables is refused unless --force is given, in which case the
+existing tables are dropped and replaced by the dump.
+
+Examples:
+  gt dolt import hq.sql
+  gt dolt import gastown.sql --db gastown --force`,
+	Args: cobra.ExactArgs(1),
+	RunE: runDoltImport,
+}
+
+var doltBackupCmd = &cobra.Command{
+	Use:   "backup <dest-dir>",
+	Short: "Back up every database on the Dolt server",
+	Long: `Snapshot all databases served by this town's Dolt server for disaster recovery.
+
+Each database's working set is committed and then dumped to SQL inside a new
+timestamped directory (dolt-backup-YYYYMMDD-HHMMSS) under dest-dir, alongside
+a manifest listing the databases. The server must be running.
+
+Examples:
+  gt dolt backup ~/gt-backups`,
+	Args: cobra.ExactArgs(1),
+	RunE: runDoltBackup,
+}
+
+var doltRestoreCmd = &cobra.Command{
+	Use:   "restore <backup-dir>",
+	Short: "Restore databases from a 'gt dolt backup' directory",
+	Long: `Load every database in a backup made by 'gt dolt backup' into this town's
+Dolt server.
+
+The backup directory is validated before anything is written. Restoring over
+a database that already has tables is refused unless --force is given, in
+which case the existing tables are replaced.
+
+Examples:
+  gt dolt restore ~/gt-backups/dolt-backup-20260101-120000
+  gt dolt restore ~/gt-backups/dolt-backup-20260101-120000 --force`,
+	Args: cobra.ExactArgs(1),
+	RunE: runDoltRestore,
+}
+
 var (
 	doltLogLines          int
 	doltLogFollow         bool
@@ -282,6 +349,10 @@ var (
 	doltSyncForce         bool
 	doltSyncDB            string
 	doltSyncGC            bool
+	doltExportDB          string
+	doltImportDB          string
+	doltImportForce       bool
+	doltRestoreForce      bool
 )
 
 func init() {
@@ -301,6 +372,10 @@ func init() {
 	doltCmd.AddCommand(doltRollbackCmd)
 	doltCmd.AddCommand(doltSyncCmd)
 	doltCmd.AddCommand(doltMigrateWispsCmd)
+	doltCmd.AddCommand(doltExportCmd)
+	doltCmd.AddCommand(doltImportCmd)
+	doltCmd.AddCommand(doltBackupCmd)
+	doltCmd.AddCommand(doltRestoreCmd)
 
 	doltCleanupCmd.Flags().BoolVar(&doltCleanupDry, "dry-run", false, "Preview what would be removed without making changes")
 	doltLogsCmd.Flags().IntVarP(&doltLogLines, "lines", "n", 50, "Number of lines to show")
@@ -319,6 +394,11 @@ func init() {
 	doltMigrateWispsCmd.Flags().BoolVar(&doltMigrateWispsDry, "dry-run", false, "Preview what would be migrated without making changes")
 	doltMigrateWispsCmd.Flags().StringVar(&doltMigrateWispsDB, "db", "", "Target database (default: auto-detect from rig)")
 
+	doltExportCmd.Flags().StringVar(&doltExportDB, "db", "hq", "Database to export")
+	doltImportCmd.Flags().StringVar(&doltImportDB, "db", "hq", "Database to import into")
+	doltImportCmd.Flags().BoolVar(&doltImportForce, "force", false, "Replace existing tables in a non-empty database")
+	doltRestoreCmd.Flags().BoolVar(&doltRestoreForce, "force", false, "Replace existing tables in non-empty databases")
+
 	rootCmd.AddCommand(doltCmd)
 }
 
@@ -385,10 +465,16 @@ func runDoltStop(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("Dolt server is remote (%s) — start/stop managed externally", config.HostPort())
 	}
 
-	_, pid, _ := doltserver.IsRunning(townRoot)
+	running, pid, _ := doltserver.IsRunning(townRoot)
+	if !running {
+		return fmt.Errorf("Dolt server is not running")
+	}
 
-	if err := doltserver.Stop(townRoot); err != nil {
-		return err
+	if err := doltserver.Shutdown(config); err != nil {
+		if running, _, _ := doltserver.IsRunning(townRoot); running {
+			return err
+		}
+		style.PrintWarning("some pending changes may not have been committed: %v", err)
 	}
 
 	fmt.Printf("%s Dolt server stopped (was PID %d)\n", style.Bold.Render("✓"), pid)
@@ -525,6 +611,10 @@ func runDoltStatus(cmd *cobra.Command, args []string) error {
 			}
 			fmt.Printf("  Connection: %s\n", doltserver.GetConnectionString(townRoot))
 		}
+		if status, err := doltserver.Status(config); err == nil && status.Running {
+			fmt.Printf("  Version: %s\n", status.Version)
+			fmt.Printf("  Uptime: %s\n", status.Uptime.Round(time.Second))
+		}
 
 		// Resource metrics
 		metrics := doltserver.GetHealthMetrics(townRoot)
@@ -1042,6 +1132,76 @@ func runDoltRecover(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+func runDoltExport(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+
+	running, _, _ := doltserver.IsRunning(townRoot)
+	if !running {
+		return fmt.Errorf("Dolt server is not running — start with 'gt dolt start'")
+	}
+
+	config := doltserver.DefaultConfig(townRoot)
+	if err := doltserver.Export(config, doltExportDB, args[0]); err != nil {
+		return fmt.Errorf("export failed: %w", err)
+	}
+
+	fmt.Printf("%s Exported %s to %s\n", style.Bold.Render("✓"), doltExportDB, args[0])
+	return nil
+}
+
+func runDoltImport(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+
+	running, _, _ := doltserver.IsRunning(townRoot)
+	if !running {
+		return fmt.Errorf("Dolt server is not running — start with 'gt dolt start'")
+	}
+
+	config := doltserver.DefaultConfig(townRoot)
+	if err := doltserver.Import(config, doltImportDB, args[0], doltImportForce); err != nil {
+		return fmt.Errorf("import failed: %w", err)
+	}
+
+	fmt.Printf("%s Imported %s into %s\n", style.Bold.Render("✓"), args[0], doltImportDB)
+	return nil
+}
+
+func runDoltBackup(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+
+	backupDir, err := doltserver.BackupDatabases(townRoot, args[0])
+	if err != nil {
+		return fmt.Errorf("backup failed: %w", err)
+	}
+
+	fmt.Printf("%s Backed up Dolt databases to %s\n", style.Bold.Render("✓"), backupDir)
+	return nil
+}
+
+func runDoltRestore(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+
+	opts := doltserver.RestoreOptions{Force: doltRestoreForce}
+	if err := doltserver.RestoreDatabases(townRoot, args[0], opts); err != nil {
+		return fmt.Errorf("restore failed: %w", err)
+	}
+
+	fmt.Printf("%s Restored Dolt databases from %s\n", style.Bold.Render("✓"), args[0])
+	return nil
+}
+
 func runDoltRollback(cmd *cobra.Command, args []string) error {
 	townRoot, err := workspace.FindFromCwdOrError()
 	if err != nil {
@@ -895,6 +895,11 @@ notifyWitness:
 	if len(doneErrors) > 0 {
 		bodyLines = append(bodyLines, fmt.Sprintf("Errors: %s", strings.Join(doneErrors, "; ")))
 	}
+	// Pass along follow-up beads the polecat slung for its successor
+	followUps, _ := readFollowUps(townRoot, sender)
+	if len(followUps) > 0 {
+		bodyLines = append(bodyLines, fmt.Sprintf("FollowUps: %s", strings.Join(followUps, ", ")))
+	}
 
 	doneNotification := &mail.Message{
 		To:      witnessAddr,
@@ -908,6 +913,10 @@ notifyWitness:
 		style.PrintWarning("could not notify witness: %v", err)
 	} else {
 		fmt.Printf("%s Witness notified of %s\n", style.Bold.Render("✓"), exitType)
+		if len(followUps) > 0 {
+			fmt.Printf("%s Handed off follow-ups: %s\n", style.Bold.Render("✓"), strings.Join(followUps, ", "))
+			clearFollowUps(townRoot, sender)
+		}
 	}
 
 	// Notify witness of work completion (witness is the polecat's direct supervisor).
@@ -1,12 +1,18 @@
 package cmd
 
 import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
 	"fmt"
 	"io"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/steveyegge/gastown/internal/config"
@@ -33,7 +39,8 @@ This is the canonical way to end any agent session. It handles all roles:
 
 When run without arguments, hands off the current session.
 When given a bead ID (gt-xxx, hq-xxx), hooks that work first, then restarts.
-When given a role name, hands off that role's session (and switches to it).
+When given a role name, hands off that role's session (and switches to it)
+after asking for confirmation; pass --yes to skip the prompt.
 
 Examples:
   gt handoff                          # Hand off current session
@@ -43,6 +50,11 @@ Examples:
   gt handoff -c                       # Collect state into handoff message
   gt handoff crew                     # Hand off crew session
   gt handoff mayor                    # Hand off mayor session
+  gt handoff --all --dry-run          # Preview respawning every session
+  gt handoff --rig gastown            # Respawn one rig's agents
+  gt handoff witness --rig gastown    # Hand off another rig's witness
+  gt handoff --rig gastown --crew max # Hand off a crew member from anywhere
+  gt handoff --list                   # Show each session's restart command
 
 The --collect (-c) flag gathers current state (hooked work, inbox, ready beads,
 in-progress items) and includes it in the handoff mail. This provides context
@@ -53,6 +65,22 @@ Unlike --auto (state only) or normal handoff (polecat→gt-done redirect), --cyc
 always does a full respawn regardless of role. This enables crew workers and
 polecats to get a fresh context window when the current one fills up.
 
+The --all flag respawns every Gas Town session (e.g. after a template
+rollout): mayor and deacon first, then witnesses, refineries, crew, and
+polecats. The current session, if any, is respawned last. No handoff mail
+is sent. --rig <name> does the same for a single rig's sessions. Use
+--stagger (e.g. --stagger 3s) to space out startups so every new agent
+doesn't hit Dolt and mail at the same moment.
+
+The --wait flag makes remote handoffs wait (up to --wait-timeout, default 30s)
+for the respawned pane to produce output, failing if the new session dies.
+With --all or --rig, --sequential-health does the same for each session in
+turn and stops at the first one that doesn't come up, leaving the rest
+untouched. Use it for production rollouts.
+
+Every respawn (including --dry-run previews) is appended as a JSON line to
+<town>/logs/handoff.log for later forensics.
+
 Any molecule on the hook will be auto-continued by the new session.
 The SessionStart hook runs 'gt prime' to restore context.`,
 	RunE: runHandoff,
@@ -69,8 +97,28 @@ var (
 	handoffCycle      bool
 	handoffReason     string
 	handoffNoGitCheck bool
+	handoffAll        bool
+	handoffRig        string
+	handoffExclude    []string
+	handoffWait       bool
+	handoffWaitFor    time.Duration
+	handoffYes        bool
+	handoffStagger    time.Duration
+	handoffSeqHealth  bool
+	handoffForce      bool
+	handoffList       bool
+	handoffCrew       string
+	handoffKeepAlive  bool
+	handoffTimeout    time.Duration
+	handoffRestartCmd string
 )
 
+// handoffConfirmIn is where remote handoff confirmations are read from.
+var handoffConfirmIn io.Reader = os.Stdin
+
+// handoffWaitPoll is how often --wait checks a respawned pane. Tests shorten it.
+var handoffWaitPoll = 500 * time.Millisecond
+
 func init() {
 	handoffCmd.Flags().BoolVarP(&handoffWatch, "watch", "w", true, "Switch to new session (for remote handoff)")
 	handoffCmd.Flags().BoolVarP(&handoffDryRun, "dry-run", "n", false, "Show what would be done without executing")
@@ -82,6 +130,20 @@ func init() {
 	handoffCmd.Flags().BoolVar(&handoffCycle, "cycle", false, "Auto-cycle session (for PreCompact hooks that want full session replacement)")
 	handoffCmd.Flags().StringVar(&handoffReason, "reason", "", "Reason for handoff (e.g., 'compaction', 'idle')")
 	handoffCmd.Flags().BoolVar(&handoffNoGitCheck, "no-git-check", false, "Skip git workspace cleanliness check")
+	handoffCmd.Flags().BoolVar(&handoffAll, "all", false, "Respawn every Gas Town session, current session last")
+	handoffCmd.Flags().StringVar(&handoffRig, "rig", "", "Rig for a crew/witness/refinery role argument; without one, respawn only this rig's sessions")
+	handoffCmd.Flags().StringSliceVar(&handoffExclude, "exclude", nil, "With --all/--rig, skip these sessions (session names or roles, comma-separated)")
+	handoffCmd.Flags().StringVar(&handoffCrew, "crew", "", "Crew member to hand off (implies the crew role; use with --rig)")
+	handoffCmd.Flags().BoolVar(&handoffWait, "wait", false, "After respawning a remote session, wait for its pane to come up and produce output")
+	handoffCmd.Flags().DurationVar(&handoffWaitFor, "wait-timeout", 30*time.Second, "How long --wait waits for a respawned session")
+	handoffCmd.Flags().BoolVarP(&handoffYes, "yes", "y", false, "Skip confirmation when handing off another session")
+	handoffCmd.Flags().BoolVar(&handoffList, "list", false, "List every session with the restart command a handoff would use")
+	handoffCmd.Flags().BoolVarP(&handoffForce, "force", "f", false, "Hand off a remote session even if its pane is running a command")
+	handoffCmd.Flags().BoolVar(&handoffKeepAlive, "keep-alive", false, "Respawn the agent once more if it exits with an error within a minute of starting")
+	handoffCmd.Flags().DurationVar(&handoffTimeout, "timeout", 10*time.Second, "Give up on a remote session's pane lookups, busy check, and switch-client after this long (0 = no limit); waiting for the session and the respawn itself are not bounded")
+	handoffCmd.Flags().StringVar(&handoffRestartCmd, "cmd", "", "Respawn the target session with this command instead of its normal restart command")
+	handoffCmd.Flags().DurationVar(&handoffStagger, "stagger", 0, "With --all/--rig, pause this long between sessions (e.g. 3s)")
+	handoffCmd.Flags().BoolVar(&handoffSeqHealth, "sequential-health", false, "With --all/--rig, wait for each respawned session to come up before the next, and stop at the first that doesn't")
 	rootCmd.AddCommand(handoffCmd)
 }
 
@@ -98,6 +160,27 @@ func runHandoff(cmd *cobra.Command, args []string) error {
 		handoffMessage = strings.TrimRight(string(data), "\n")
 	}
 
+	// Validate flag combinations before --auto and --cycle return early, so a
+	// flag those modes would silently ignore is rejected instead.
+	if cmd.Flags().Changed("cmd") {
+		if strings.TrimSpace(handoffRestartCmd) == "" {
+			return fmt.Errorf("--cmd must not be empty")
+		}
+		if handoffAuto || handoffCycle {
+			return fmt.Errorf("--cmd cannot be used with --auto or --cycle")
+		}
+		if handoffAll || handoffList || (handoffRig != "" && handoffCrew == "" && len(args) == 0) {
+			return fmt.Errorf("--cmd applies to a single session; it cannot be used with --all, --list, or a rig-wide handoff")
+		}
+	}
+
+	if len(handoffExclude) > 0 && !handoffAll && (handoffRig == "" || handoffCrew != "" || len(args) > 0) {
+		return fmt.Errorf("--exclude only applies to --all or a rig-wide --rig handoff")
+	}
+	if handoffSeqHealth && !handoffAll && (handoffRig == "" || handoffCrew != "" || len(args) > 0) {
+		return fmt.Errorf("--sequential-health only applies to --all or a rig-wide --rig handoff")
+	}
+
 	// --auto mode: save state only, no session cycling.
 	// Used by PreCompact hook to preserve state before compaction.
 	// Note: auto-mode exits here, before the git-status warning check below.
@@ -118,6 +201,26 @@ func runHandoff(cmd *cobra.Command, args []string) error {
 		return runHandoffCycle()
 	}
 
+	// --list mode: preview restart commands for every session, no side effects.
+	if handoffList {
+		return runHandoffList()
+	}
+
+	// --crew names a crew member, so it implies the crew role.
+	if handoffCrew != "" && len(args) == 0 {
+		args = []string{"crew"}
+	}
+
+	// --all / --rig mode: respawn every Gas Town session, or every session of
+	// one rig (e.g. after a template rollout). With a role argument, --rig
+	// instead says which rig the role belongs to.
+	if handoffAll || (handoffRig != "" && len(args) == 0) {
+		if len(args) > 0 {
+			return fmt.Errorf("cannot use --all with a bead or role argument")
+		}
+		return runHandoffAll()
+	}
+
 	// Check if we're a polecat - polecats use gt done instead.
 	// Check GT_ROLE first: coordinators (mayor, witness, etc.) may have a stale
 	// GT_POLECAT in their environment from spawning polecats. Only block if the
@@ -192,6 +295,7 @@ func runHandoff(cmd *cobra.Command, args []string) error {
 
 	// Determine target session and check for bead hook
 	targetSession := currentSession
+	hookedBead := ""
 	if len(args) > 0 {
 		arg := args[0]
 
@@ -201,13 +305,14 @@ func runHandoff(cmd *cobra.Command, args []string) error {
 			if err := hookBeadForHandoff(arg); err != nil {
 				return fmt.Errorf("hooking bead: %w", err)
 			}
+			hookedBead = arg
 			// Update subject if not set
 			if handoffSubject == "" {
 				handoffSubject = fmt.Sprintf("🪝 HOOKED: %s", arg)
 			}
 		} else {
 			// User specified a role to hand off
-			targetSession, err = resolveRoleToSession(arg)
+			targetSession, err = resolveRoleToSessionFor(arg, handoffRig, handoffCrew)
 			if err != nil {
 				return fmt.Errorf("resolving role: %w", err)
 			}
@@ -215,20 +320,27 @@ func runHandoff(cmd *cobra.Command, args []string) error {
 	}
 
 	// Build the restart command
-	restartCmd, err := buildRestartCommand(targetSession)
+	restartCmd, err := resolveRestartCommand(targetSession)
 	if err != nil {
 		return err
 	}
 
 	// If handing off a different session, we need to find its pane and respawn there
 	if targetSession != currentSession {
-		// Update tmux session env before respawn (not during dry-run — see below)
-		updateSessionEnvForHandoff(t, targetSession, "")
-		return handoffRemoteSession(t, targetSession, restartCmd)
+		err := handoffRemoteSession(t, targetSession, restartCmd, remoteHandoffOpts{watch: handoffWatch, wait: handoffWait})
+		if errors.Is(err, tmux.ErrSessionNotFound) {
+			if hint := sessionStartHint(targetSession); hint != "" {
+				fmt.Printf("Start it with: %s\n", style.Dim.Render(hint))
+			}
+		}
+		return err
 	}
 
 	// Handing off ourselves - print feedback then respawn
 	fmt.Printf("%s Handing off %s...\n", style.Bold.Render("🤝"), currentSession)
+	if handoffWait {
+		fmt.Println("Note: --wait is ignored when handing off the current session")
+	}
 
 	// Log handoff event (both townlog and events feed)
 	if townRoot, err := workspace.FindFromCwd(); err == nil && townRoot != "" {
@@ -241,10 +353,14 @@ func runHandoff(cmd *cobra.Command, args []string) error {
 		_ = events.LogFeed(events.TypeHandoff, agent, events.HandoffPayload(handoffSubject, true))
 	}
 
+	recordHandoffAudit(currentSession, restartCmd)
+
 	// Dry run mode - show what would happen (BEFORE any side effects)
 	if handoffDryRun {
 		if handoffSubject != "" || handoffMessage != "" {
-			fmt.Printf("Would send handoff mail: subject=%q (auto-hooked)\n", handoffSubject)
+			if _, err := sendHandoffMailWithMeta(handoffSubject, handoffMessage, handoffMailMeta{BeadID: hookedBead}, true); err != nil {
+				fmt.Printf("Would send handoff mail: subject=%q (auto-hooked; cannot render: %v)\n", handoffSubject, err)
+			}
 		}
 		fmt.Printf("Would execute: tmux clear-history -t %s\n", pane)
 		fmt.Printf("Would execute: tmux respawn-pane -k -t %s %s\n", pane, restartCmd)
@@ -260,7 +376,7 @@ func runHandoff(cmd *cobra.Command, args []string) error {
 
 	// Send handoff mail to self (defaults applied inside sendHandoffMail).
 	// The mail is auto-hooked so the next session picks it up.
-	beadID, err := sendHandoffMail(handoffSubject, handoffMessage)
+	beadID, err := sendHandoffMailWithMeta(handoffSubject, handoffMessage, handoffMailMeta{BeadID: hookedBead}, false)
 	if err != nil {
 		style.PrintWarning("could not send handoff mail: %v", err)
 		// Continue anyway - the respawn is more important
@@ -311,14 +427,14 @@ func runHandoff(cmd *cobra.Command, args []string) error {
 		if _, err := os.Stat(paneWorkDir); err != nil {
 			if townRoot := detectTownRootFromCwd(); townRoot != "" {
 				style.PrintWarning("pane working directory deleted, using town root")
-				return t.RespawnPaneWithWorkDir(pane, townRoot, restartCmd)
+				return t.RespawnPaneWithWorkDirAndEnv(pane, townRoot, restartCmd, handoffIdentityEnv(currentSession))
 			}
 		}
 	}
 
 	// Use respawn-pane -k to atomically kill current process and start new one
 	// Note: respawn-pane automatically resets remain-on-exit to off
-	return t.RespawnPane(pane, restartCmd)
+	return t.RespawnPaneWithEnv(pane, restartCmd, handoffIdentityEnv(currentSession))
 }
 
 // runHandoffAuto saves state without cycling the session.
@@ -342,13 +458,15 @@ func runHandoffAuto() error {
 	}
 
 	if handoffDryRun {
-		fmt.Printf("[auto-handoff] Would send mail: subject=%q\n", subject)
+		if _, err := sendHandoffMail(subject, message, true); err != nil {
+			fmt.Printf("[auto-handoff] Would send mail: subject=%q (cannot render: %v)\n", subject, err)
+		}
 		fmt.Printf("[auto-handoff] Would write handoff marker\n")
 		return nil
 	}
 
 	// Send handoff mail to self
-	beadID, err := sendHandoffMail(subject, message)
+	beadID, err := sendHandoffMail(subject, message, false)
 	if err != nil {
 		// Non-fatal — log and continue
 		fmt.Fprintf(os.Stderr, "auto-handoff: could not send mail: %v\n", err)
@@ -443,7 +561,9 @@ func runHandoffCycle() error {
 	t := tmux.NewTmux()
 
 	if handoffDryRun {
-		fmt.Printf("[cycle] Would send handoff mail: subject=%q\n", subject)
+		if _, err := sendHandoffMail(subject, message, true); err != nil {
+			fmt.Printf("[cycle] Would send handoff mail: subject=%q (cannot render: %v)\n", subject, err)
+		}
 		fmt.Printf("[cycle] Would write handoff marker\n")
 		fmt.Printf("[cycle] Would execute: tmux clear-history -t %s\n", pane)
 		fmt.Printf("[cycle] Would execute: tmux respawn-pane -k -t %s <restart-cmd>\n", pane)
@@ -451,7 +571,7 @@ func runHandoffCycle() error {
 	}
 
 	// Send handoff mail to self (auto-hooked for successor)
-	beadID, err := sendHandoffMail(subject, message)
+	beadID, err := sendHandoffMail(subject, message, false)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "handoff --cycle: could not send mail: %v\n", err)
 		// Continue — respawn is more important than mail
@@ -508,13 +628,13 @@ func runHandoffCycle() error {
 	if paneWorkDir != "" {
 		if _, err := os.Stat(paneWorkDir); err != nil {
 			if townRoot := detectTownRootFromCwd(); townRoot != "" {
-				return t.RespawnPaneWithWorkDir(pane, townRoot, restartCmd)
+				return t.RespawnPaneWithWorkDirAndEnv(pane, townRoot, restartCmd, handoffIdentityEnv(currentSession))
 			}
 		}
 	}
 
 	// Respawn pane — this atomically kills current process and starts fresh
-	return t.RespawnPane(pane, restartCmd)
+	return t.RespawnPaneWithEnv(pane, restartCmd, handoffIdentityEnv(currentSession))
 }
 
 // getCurrentTmuxSession returns the current tmux session name.
@@ -534,11 +654,25 @@ func getCurrentTmuxSession() (string, error) {
 //
 // For role shortcuts that need context (crew, witness, refinery), it auto-detects from environment.
 func resolveRoleToSession(role string) (string, error) {
+	return resolveRoleToSessionFor(role, "", "")
+}
+
+// resolveRoleToSessionFor is resolveRoleToSession with an explicit rig and
+// crew name. Non-empty overrides are used as-is instead of GT_RIG/GT_CREW or
+// cwd detection, so one rig's agents can be targeted from anywhere.
+func resolveRoleToSessionFor(role, rigOverride, crewOverride string) (string, error) {
 	// First, check if it's a path format (contains /)
 	if strings.Contains(role, "/") {
 		return resolvePathToSession(role)
 	}
 
+	rigFromEnv := func() string {
+		if rigOverride != "" {
+			return rigOverride
+		}
+		return os.Getenv("GT_RIG")
+	}
+
 	switch strings.ToLower(role) {
 	case "mayor", "may":
 		return getMayorSessionName(), nil
@@ -547,33 +681,40 @@ func resolveRoleToSession(role string) (string, error) {
 		return getDeaconSessionName(), nil
 
 	case "crew":
-		// Try to get rig and crew name from environment or cwd
-		rig := os.Getenv("GT_RIG")
-		crewName := os.Getenv("GT_CREW")
+		// Try to get rig and crew name from flags, environment, or cwd
+		rig := rigFromEnv()
+		crewName := crewOverride
+		if crewName == "" {
+			crewName = os.Getenv("GT_CREW")
+		}
 		if rig == "" || crewName == "" {
 			// Try to detect from cwd
 			detected, err := detectCrewFromCwd()
 			if err == nil {
-				rig = detected.rigName
-				crewName = detected.crewName
+				if rig == "" {
+					rig = detected.rigName
+				}
+				if crewName == "" {
+					crewName = detected.crewName
+				}
 			}
 		}
 		if rig == "" || crewName == "" {
-			return "", fmt.Errorf("cannot determine crew identity - run from crew directory or specify GT_RIG/GT_CREW")
+			return "", fmt.Errorf("cannot determine crew identity - run from crew directory, specify GT_RIG/GT_CREW, or pass --rig/--crew")
 		}
 		return session.CrewSessionName(session.PrefixFor(rig), crewName), nil
 
 	case "witness", "wit":
-		rig := os.Getenv("GT_RIG")
+		rig := rigFromEnv()
 		if rig == "" {
-			return "", fmt.Errorf("cannot determine rig - set GT_RIG or run from rig context")
+			return "", fmt.Errorf("cannot determine rig - set GT_RIG, pass --rig, or run from rig context")
 		}
 		return session.WitnessSessionName(session.PrefixFor(rig)), nil
 
 	case "refinery", "ref":
-		rig := os.Getenv("GT_RIG")
+		rig := rigFromEnv()
 		if rig == "" {
-			return "", fmt.Errorf("cannot determine rig - set GT_RIG or run from rig context")
+			return "", fmt.Errorf("cannot determine rig - set GT_RIG, pass --rig, or run from rig context")
 		}
 		return session.RefinerySessionName(session.PrefixFor(rig)), nil
 
@@ -675,10 +816,150 @@ var claudeEnvVars = []string{
 	"GT_OTEL_LOGS_URL",
 }
 
+// SessionRestartMatcher reports whether a restart builder handles a session.
+type SessionRestartMatcher func(sessionName string) bool
+
+// SessionRestartBuilder returns the command that respawns a session's pane.
+type SessionRestartBuilder func(sessionName string) (string, error)
+
+// sessionRestart pairs a matcher with the builder used for matching sessions.
+type sessionRestart struct {
+	matches SessionRestartMatcher
+	build   SessionRestartBuilder
+	// buildForAgent, if set, builds the command for an agent resolved by the
+	// caller instead of build's own lookup. Used by bulk handoff, where each
+	// target keeps its own agent.
+	buildForAgent func(sessionName string, agent handoffAgent) (string, error)
+}
+
+// sessionRestarts is consulted in order by buildRestartCommand; the first
+// matcher that accepts a session wins. The built-in role builder matches every
+// session, so it stays last and registered entries go in front of it.
+var sessionRestarts = []sessionRestart{
+	{
+		matches:       func(string) bool { return true },
+		build:         buildRoleRestartCommand,
+		buildForAgent: buildRoleRestartCommandForAgent,
+	},
+}
+
+// RegisterSessionRestart adds a restart builder for sessions accepted by
+// matcher, e.g. for a custom role whose home or runtime the built-in roles
+// don't cover. Later registrations take precedence over earlier ones and over
+// the built-ins.
+func RegisterSessionRestart(matcher SessionRestartMatcher, builder SessionRestartBuilder) {
+	sessionRestarts = append([]sessionRestart{{matches: matcher, build: builder}}, sessionRestarts...)
+}
+
 // buildRestartCommand creates the command to run when respawning a session's pane.
 // This needs to be the actual command to execute (e.g., claude), not a session attach command.
-// The command includes a cd to the correct working directory for the role.
+// Builders are looked up via sessionRestarts (see RegisterSessionRestart).
 func buildRestartCommand(sessionName string) (string, error) {
+	return buildRestartCommandWith(sessionName, nil)
+}
+
+// buildRestartCommandForAgent is buildRestartCommand for a session whose
+// agent was resolved from its own environment (see sessionHandoffAgent)
+// rather than from the caller's.
+func buildRestartCommandForAgent(sessionName string, agent handoffAgent) (string, error) {
+	return buildRestartCommandWith(sessionName, &agent)
+}
+
+func buildRestartCommandWith(sessionName string, agent *handoffAgent) (string, error) {
+	for _, r := range sessionRestarts {
+		if r.matches(sessionName) {
+			var cmd string
+			var err error
+			if agent != nil && r.buildForAgent != nil {
+				cmd, err = r.buildForAgent(sessionName, *agent)
+			} else {
+				cmd, err = r.build(sessionName)
+			}
+			if err != nil || !handoffKeepAlive {
+				return cmd, err
+			}
+			return wrapKeepAlive(cmd, keepAliveWindow), nil
+		}
+	}
+	return "", fmt.Errorf("no restart command registered for session %s", sessionName)
+}
+
+// handoffAgent is the agent a handoff respawns a session with: its GT_AGENT
+// name and the GT_PROCESS_NAMES used for liveness detection. Either may be
+// empty, meaning the role's configured default.
+type handoffAgent struct {
+	name         string
+	processNames string
+}
+
+// callerHandoffAgent returns the agent of the session running this command,
+// falling back to sessionName's tmux environment for GT_AGENT, since exec env
+// vars may not propagate through all agent runtimes.
+func callerHandoffAgent(t sessionEnvTmux, sessionName string) handoffAgent {
+	name, inEnv := os.LookupEnv("GT_AGENT")
+	if !inEnv {
+		if val, err := t.GetEnvironment(sessionName, "GT_AGENT"); err == nil {
+			name = val
+		}
+	}
+	return handoffAgent{name: name, processNames: os.Getenv("GT_PROCESS_NAMES")}
+}
+
+// sessionHandoffAgent returns the agent recorded in sessionName's own tmux
+// environment, ignoring the caller's, so handing off another session keeps
+// that session's agent.
+func sessionHandoffAgent(t sessionEnvTmux, sessionName string) handoffAgent {
+	var agent handoffAgent
+	if val, err := t.GetEnvironment(sessionName, "GT_AGENT"); err == nil {
+		agent.name = val
+	}
+	if val, err := t.GetEnvironment(sessionName, "GT_PROCESS_NAMES"); err == nil {
+		agent.processNames = val
+	}
+	return agent
+}
+
+// resolveRestartCommand returns the --cmd override for sessionName if one was
+// given, otherwise buildRestartCommand's command. The override is used
+// verbatim (apart from --keep-alive wrapping), skipping the per-role mapping.
+func resolveRestartCommand(sessionName string) (string, error) {
+	if handoffRestartCmd == "" {
+		return buildRestartCommand(sessionName)
+	}
+	style.PrintWarning("--cmd bypasses the normal restart command for %s", sessionName)
+	if handoffKeepAlive {
+		return wrapKeepAlive(handoffRestartCmd, keepAliveWindow), nil
+	}
+	return handoffRestartCmd, nil
+}
+
+// keepAliveWindow is how soon after starting an agent must fail for
+// --keep-alive to respawn it. Later exits are treated as deliberate.
+const keepAliveWindow = 60 * time.Second
+
+// wrapKeepAlive wraps restartCmd so that if it exits non-zero within window
+// of starting, it is run once more before the pane gives up. This rides out
+// transient failures right after a respawn (e.g. Dolt restarting) without
+// looping forever on a persistently broken agent. restartCmd runs in a
+// subshell, so its cd, exports, and exec stay contained.
+func wrapKeepAlive(restartCmd string, window time.Duration) string {
+	secs := int(window.Seconds())
+	return fmt.Sprintf("for gt_try in 1 2; do gt_start=$(date +%%s); ( %s ); gt_rc=$?; "+
+		"if [ $gt_rc -eq 0 ] || [ $(( $(date +%%s) - gt_start )) -ge %d ] || [ $gt_try -eq 2 ]; then break; fi; "+
+		"echo \"gt: agent exited with status $gt_rc within %ds; respawning once\" >&2; sleep 2; done; exit $gt_rc",
+		restartCmd, secs, secs)
+}
+
+// buildRoleRestartCommand is the built-in restart builder for Gas Town roles.
+// The command includes a cd to the correct working directory for the role.
+// The agent is the caller's (see callerHandoffAgent).
+func buildRoleRestartCommand(sessionName string) (string, error) {
+	return buildRoleRestartCommandForAgent(sessionName, callerHandoffAgent(tmux.NewTmux(), sessionName))
+}
+
+// buildRoleRestartCommandForAgent builds the built-in restart command for
+// sessionName running agent.
+func buildRoleRestartCommandForAgent(sessionName string, agent handoffAgent) (string, error) {
 	// Detect town root from current directory
 	townRoot := detectTownRootFromCwd()
 	if townRoot == "" {
@@ -697,6 +978,12 @@ func buildRestartCommand(sessionName string) (string, error) {
 		return "", fmt.Errorf("cannot parse session name %q: %w", sessionName, err)
 	}
 	gtRole := identity.GTRole()
+	beaconRecipient := identity.BeaconAddress()
+	if identity.Role == session.RoleDeacon && identity.Name == "boot" {
+		// Boot shares the deacon identity but has its own role and settings.
+		gtRole = "deacon/boot"
+		beaconRecipient = "boot"
+	}
 	simpleRole := config.ExtractSimpleRole(gtRole)
 
 	// Derive rigPath from session identity for --settings flag resolution
@@ -709,7 +996,7 @@ func buildRestartCommand(sessionName string) (string, error) {
 	// Use FormatStartupBeacon instead of bare "gt prime" which confuses agents
 	// The SessionStart hook handles context injection (gt prime --hook)
 	beacon := session.FormatStartupBeacon(session.BeaconConfig{
-		Recipient: identity.BeaconAddress(),
+		Recipient: beaconRecipient,
 		Sender:    "self",
 		Topic:     "handoff",
 	})
@@ -721,19 +1008,9 @@ func buildRestartCommand(sessionName string) (string, error) {
 	// 4. run claude with the startup beacon (triggers immediate context loading)
 	// Use exec to ensure clean process replacement.
 	//
-	// Check if current session is using a non-default agent (GT_AGENT env var).
-	// If so, preserve it across handoff by using the override variant.
-	// Fall back to tmux session environment if process env doesn't have it,
-	// since exec env vars may not propagate through all agent runtimes.
-	currentAgent, agentInEnv := os.LookupEnv("GT_AGENT")
-	if !agentInEnv {
-		// GT_AGENT not in process env at all — try tmux session environment
-		// as fallback, since exec env vars may not propagate through all runtimes.
-		t := tmux.NewTmux()
-		if val, err := t.GetEnvironment(sessionName, "GT_AGENT"); err == nil && val != "" {
-			currentAgent = val
-		}
-	}
+	// If the session is using a non-default agent (GT_AGENT), preserve it
+	// across handoff by using the override variant.
+	currentAgent := agent.name
 	var runtimeCmd string
 	if currentAgent != "" {
 		var err error
@@ -791,7 +1068,7 @@ func buildRestartCommand(sessionName string) (string, error) {
 	// Without this, custom agents that shadow built-in presets (e.g., custom
 	// "codex" running "opencode") would revert to GT_AGENT-based lookup after
 	// handoff, causing false liveness failures.
-	if processNames := os.Getenv("GT_PROCESS_NAMES"); processNames != "" {
+	if processNames := agent.processNames; processNames != "" {
 		// Preserve existing process names from environment
 		exports = append(exports, "GT_PROCESS_NAMES="+processNames)
 	} else if currentAgent != "" {
@@ -827,36 +1104,81 @@ func buildRestartCommand(sessionName string) (string, error) {
 	return fmt.Sprintf("cd %s && exec %s", workDir, runtimeCmd), nil
 }
 
-// updateSessionEnvForHandoff updates the tmux session environment with the
-// agent name and process names for liveness detection. IsAgentAlive reads
-// GT_PROCESS_NAMES from the tmux session env (via tmux show-environment), not
-// from shell exports in the pane. Without this, post-handoff liveness checks
-// would use stale values from the previous agent.
-func updateSessionEnvForHandoff(t *tmux.Tmux, sessionName, agentOverride string) {
-	// Resolve current agent using the same priority as buildRestartCommandWithAgent
-	var currentAgent string
-	if agentOverride != "" {
-		currentAgent = agentOverride
-	} else {
-		currentAgent = os.Getenv("GT_AGENT")
-		if currentAgent == "" {
-			if val, err := t.GetEnvironment(sessionName, "GT_AGENT"); err == nil && val != "" {
-				currentAgent = val
-			}
-		}
+// handoffIdentityEnv returns the GT_* variables that identify the agent in
+// sessionName, as config.AgentEnv sets them at first start. They are passed
+// to respawn-pane so a recycled pane can re-detect its identity (e.g. crew
+// reading GT_RIG/GT_CREW). Returns nil if the session name can't be parsed.
+func handoffIdentityEnv(sessionName string) map[string]string {
+	identity, err := session.ParseSessionName(sessionName)
+	if err != nil {
+		return nil
+	}
+	env := map[string]string{"GT_ROLE": identity.GTRole()}
+	if identity.Role == session.RoleDeacon && identity.Name == "boot" {
+		env["GT_ROLE"] = "deacon/boot"
+	}
+	if identity.Rig != "" {
+		env["GT_RIG"] = identity.Rig
 	}
+	switch identity.Role {
+	case session.RoleCrew:
+		env["GT_CREW"] = identity.Name
+	case session.RolePolecat:
+		env["GT_POLECAT"] = identity.Name
+	}
+	return env
+}
 
-	if currentAgent == "" {
-		return
+// sessionStartHint returns the command that starts the agent for
+// sessionName, or "" if there isn't one (polecats are started by sling).
+func sessionStartHint(sessionName string) string {
+	identity, err := session.ParseSessionName(sessionName)
+	if err != nil {
+		return ""
 	}
+	switch identity.Role {
+	case session.RoleMayor:
+		return "gt mayor start"
+	case session.RoleDeacon:
+		if identity.Name == "boot" {
+			return "gt boot spawn"
+		}
+		return "gt deacon start"
+	case session.RoleWitness:
+		return "gt witness start " + identity.Rig
+	case session.RoleRefinery:
+		return "gt refinery start " + identity.Rig
+	case session.RoleCrew:
+		return "gt crew start " + identity.Rig + " " + identity.Name
+	}
+	return ""
+}
 
-	// Update GT_AGENT in session env
-	_ = t.SetEnvironment(sessionName, "GT_AGENT", currentAgent)
+// confirmHandoffIdentity checks the rig/crew/polecat a remote session reports
+// in its own tmux environment against the identity parsed from its name,
+// which is what the respawned agent will get. A mismatch means the session
+// was started by hand or renamed; warn so it isn't silently re-identified.
+func confirmHandoffIdentity(t handoffTmux, sessionName string) {
+	want := handoffIdentityEnv(sessionName)
+	for _, name := range []string{"GT_RIG", "GT_CREW", "GT_POLECAT"} {
+		got, err := t.SessionEnv(sessionName, name)
+		if err != nil || got == "" || got == want[name] {
+			continue
+		}
+		style.PrintWarning("%s reports %s=%s, but will be respawned as %s=%q", sessionName, name, got, name, want[name])
+	}
+}
 
-	// Resolve and update GT_PROCESS_NAMES in session env
-	// When switching agents, recompute from config. When preserving, use env value.
-	var processNames string
+// updateSessionEnvForHandoff updates the tmux session environment with the
+// agent name and process names for liveness detection. IsAgentAlive reads
+// GT_PROCESS_NAMES from the tmux session env (via tmux show-environment), not
+// from shell exports in the pane. Without this, post-handoff liveness checks
+// would use stale values from the previous agent.
+func updateSessionEnvForHandoff(t sessionEnvTmux, sessionName, agentOverride string) {
+	// Resolve current agent using the same priority as buildRoleRestartCommand
+	agent := callerHandoffAgent(t, sessionName)
 	if agentOverride != "" {
+		agent = handoffAgent{name: agentOverride}
 		// Agent is changing — resolve config to get the command for process name resolution
 		townRoot := detectTownRootFromCwd()
 		if townRoot != "" {
@@ -865,23 +1187,29 @@ func updateSessionEnvForHandoff(t *tmux.Tmux, sessionName, agentOverride string)
 			if err == nil && identity.Rig != "" {
 				rigPath = filepath.Join(townRoot, identity.Rig)
 			}
-			rc, _, err := config.ResolveAgentConfigWithOverride(townRoot, rigPath, currentAgent)
+			rc, _, err := config.ResolveAgentConfigWithOverride(townRoot, rigPath, agentOverride)
 			if err == nil {
-				resolved := config.ResolveProcessNames(currentAgent, rc.Command)
-				processNames = strings.Join(resolved, ",")
+				resolved := config.ResolveProcessNames(agentOverride, rc.Command)
+				agent.processNames = strings.Join(resolved, ",")
 			}
 		}
 	}
-	if processNames == "" {
-		// Preserve existing value or compute from current agent
-		if pn := os.Getenv("GT_PROCESS_NAMES"); pn != "" {
-			processNames = pn
-		} else {
-			resolved := config.ResolveProcessNames(currentAgent, "")
-			processNames = strings.Join(resolved, ",")
-		}
+	setSessionAgentEnv(t, sessionName, agent)
+}
+
+// setSessionAgentEnv records agent as GT_AGENT and GT_PROCESS_NAMES in
+// sessionName's tmux environment, computing the process names from the agent
+// when they are unknown. Does nothing when the agent is unknown.
+func setSessionAgentEnv(t sessionEnvTmux, sessionName string, agent handoffAgent) {
+	if agent.name == "" {
+		return
 	}
+	_ = t.SetEnvironment(sessionName, "GT_AGENT", agent.name)
 
+	processNames := agent.processNames
+	if processNames == "" {
+		processNames = strings.Join(config.ResolveProcessNames(agent.name, ""), ",")
+	}
 	_ = t.SetEnvironment(sessionName, "GT_PROCESS_NAMES", processNames)
 }
 
@@ -901,6 +1229,10 @@ func sessionWorkDir(sessionName, townRoot string) (string, error) {
 	case sessionName == deaconSession:
 		return townRoot + "/deacon", nil
 
+	case sessionName == session.BootSessionName():
+		// Boot is the deacon's triage dog: ~/gt/deacon/dogs/boot/
+		return townRoot + "/deacon/dogs/boot", nil
+
 	case strings.Contains(sessionName, "-crew-"):
 		// gt-<rig>-crew-<name> -> <townRoot>/<rig>/crew/<name>
 		rig, name, _, ok := parseCrewSessionName(sessionName)
@@ -968,35 +1300,143 @@ func detectTownRootFromCwd() string {
 	return ""
 }
 
+// sessionEnvTmux is the subset of tmux operations used to read and update a
+// session's environment.
+type sessionEnvTmux interface {
+	GetEnvironment(session, key string) (string, error)
+	SetEnvironment(session, key, value string) error
+}
+
+// handoffTmux is the subset of tmux operations used to respawn a remote
+// session. Satisfied by *tmux.Tmux; tests substitute a fake.
+type handoffTmux interface {
+	paneWatcher
+	sessionEnvTmux
+	WaitForSession(name string, timeout time.Duration) error
+	SetRemainOnExit(pane string, on bool) error
+	KillPaneProcesses(pane string) error
+	ClearHistory(pane string) error
+	GetPaneWorkDir(session string) (string, error)
+	SessionEnv(session, name string) (string, error)
+	RespawnPaneWithEnv(pane, command string, env map[string]string) error
+	RespawnPaneWithWorkDirAndEnv(pane, workDir, command string, env map[string]string) error
+}
+
+// confirmHandoff asks question, reading the answer from in. Anything other
+// than y/yes — including empty input or EOF — is No.
+func confirmHandoff(in io.Reader, question string) bool {
+	fmt.Printf("%s [y/N]: ", question)
+	answer, _ := bufio.NewReader(in).ReadString('\n')
+	answer = strings.TrimSpace(strings.ToLower(answer))
+	return answer == "y" || answer == "yes"
+}
+
+// handoffSessionWait is how long handoffRemoteSession waits for the target
+// session to exist.
+const handoffSessionWait = 2 * time.Second
+
+// confirmRemoteHandoff asks the user to confirm handing off sessions, once
+// for all of them, unless --yes or --dry-run is set. Declining is an error,
+// so the command exits non-zero.
+func confirmRemoteHandoff(sessions ...string) error {
+	if handoffDryRun || handoffYes {
+		return nil
+	}
+	question := fmt.Sprintf("Hand off %s? Its running agent will be killed and respawned.", style.Bold.Render(sessions[0]))
+	if len(sessions) > 1 {
+		fmt.Printf("Sessions to hand off: %s\n", strings.Join(sessions, ", "))
+		question = fmt.Sprintf("Hand off %d sessions? Their running agents will be killed and respawned.", len(sessions))
+	}
+	if !confirmHandoff(handoffConfirmIn, question) {
+		return fmt.Errorf("handoff of %s cancelled", strings.Join(sessions, ", "))
+	}
+	return nil
+}
+
+// remoteHandoffOpts controls how handoffRemoteSession hands off a session.
+type remoteHandoffOpts struct {
+	// confirmed skips the confirmation prompt because the caller already
+	// asked, e.g. once for a whole bulk handoff.
+	confirmed bool
+	// watch switches the client to the target session after the respawn.
+	watch bool
+	// wait waits for the respawned pane to come up, failing if it doesn't.
+	wait bool
+	// agent, if set, is recorded in the target's session env instead of the
+	// caller's agent.
+	agent *handoffAgent
+}
+
 // handoffRemoteSession respawns a different session and optionally switches to it.
-func handoffRemoteSession(t *tmux.Tmux, targetSession, restartCmd string) error {
-	// Check if target session exists
-	exists, err := t.HasSession(targetSession)
-	if err != nil {
-		return fmt.Errorf("checking session: %w", err)
+// Unless --yes, --dry-run, or opts.confirmed is set, the user must confirm first.
+func handoffRemoteSession(t handoffTmux, targetSession, restartCmd string, opts remoteHandoffOpts) error {
+	if !opts.confirmed {
+		if err := confirmRemoteHandoff(targetSession); err != nil {
+			return err
+		}
 	}
-	if !exists {
-		return fmt.Errorf("session '%s' not found - is the agent running?", targetSession)
+
+	// Check if target session exists, allowing for a session that is
+	// mid-respawn from an earlier handoff
+	if err := t.WaitForSession(targetSession, handoffSessionWait); err != nil {
+		if errors.Is(err, tmux.ErrSessionNotFound) {
+			return fmt.Errorf("%w: %s (is the agent running?)", tmux.ErrSessionNotFound, targetSession)
+		}
+		return fmt.Errorf("checking session: %w", err)
 	}
+	confirmHandoffIdentity(t, targetSession)
 
 	// Get the pane ID for the target session
-	targetPane, err := getSessionPane(targetSession)
+	out, err := runHandoffTmux("list-panes", "-t", targetSession, "-F", "#{pane_id}")
+	if err != nil {
+		return fmt.Errorf("getting target pane: %w", err)
+	}
+	targetPane, err := firstPaneID(out)
 	if err != nil {
 		return fmt.Errorf("getting target pane: %w", err)
 	}
+	// Respawning the wrong pane would kill another agent, so confirm the
+	// pane really belongs to the session we mean to hand off.
+	out, err = runHandoffTmux("display-message", "-p", "-t", targetPane, "#{session_name}")
+	if err != nil {
+		return fmt.Errorf("checking target pane %s: %w", targetPane, err)
+	}
+	if paneSession := strings.TrimSpace(string(out)); paneSession != targetSession {
+		return fmt.Errorf("pane %s belongs to session %q, not %s; refusing to respawn it", targetPane, paneSession, targetSession)
+	}
+
+	// Respawning kills whatever is running in the pane. Refuse if that's
+	// something other than the idle shell or agent, unless forced.
+	if busy, err := paneIsBusy(targetPane); err != nil {
+		style.PrintWarning("could not check whether %s is busy: %v", targetSession, err)
+	} else if busy && !handoffForce {
+		if !handoffDryRun {
+			return fmt.Errorf("session %s is running a command; use --force to hand off anyway", targetSession)
+		}
+		style.PrintWarning("%s is running a command; handoff would require --force", targetSession)
+	}
 
 	fmt.Printf("%s Handing off %s...\n", style.Bold.Render("🤝"), targetSession)
 
 	// Dry run mode
 	if handoffDryRun {
+		recordHandoffAudit(targetSession, restartCmd)
 		fmt.Printf("Would execute: tmux clear-history -t %s\n", targetPane)
 		fmt.Printf("Would execute: tmux respawn-pane -k -t %s %s\n", targetPane, restartCmd)
-		if handoffWatch {
+		if opts.watch {
 			fmt.Printf("Would execute: tmux switch-client -t %s\n", targetSession)
 		}
 		return nil
 	}
 
+	// Update the session env for liveness detection only once the handoff is
+	// certain to go ahead, so a refused or dry-run handoff leaves it as it was.
+	if opts.agent != nil {
+		setSessionAgentEnv(t, targetSession, *opts.agent)
+	} else {
+		updateSessionEnvForHandoff(t, targetSession, "")
+	}
+
 	// Set remain-on-exit so the pane survives process death during handoff.
 	// Without this, killing processes causes tmux to destroy the pane before
 	// we can respawn it. This is essential for tmux session reuse.
@@ -1024,36 +1464,130 @@ func handoffRemoteSession(t *tmux.Tmux, targetSession, restartCmd string) error
 			if _, statErr := os.Stat(paneWorkDir); statErr != nil {
 				if townRoot := detectTownRootFromCwd(); townRoot != "" {
 					style.PrintWarning("pane working directory deleted, using town root")
-					return t.RespawnPaneWithWorkDir(targetPane, townRoot, restartCmd)
+					return t.RespawnPaneWithWorkDirAndEnv(targetPane, townRoot, restartCmd, handoffIdentityEnv(targetSession))
 				}
 			}
 		}
-		return t.RespawnPane(targetPane, restartCmd)
+		return t.RespawnPaneWithEnv(targetPane, restartCmd, handoffIdentityEnv(targetSession))
 	}()
 	if respawnErr != nil {
 		return fmt.Errorf("respawning pane: %w", respawnErr)
 	}
+	// Audit only a respawn that happened; a failed one is not a handoff.
+	recordHandoffAudit(targetSession, restartCmd)
+
+	if opts.wait {
+		fmt.Printf("Waiting up to %s for %s to come up...\n", handoffWaitFor, targetSession)
+		if err := waitForPaneReady(t, targetSession, targetPane, handoffWaitFor); err != nil {
+			return err
+		}
+		fmt.Printf("%s %s is up\n", style.Bold.Render("✓"), targetSession)
+	}
 
 	// If --watch, switch to that session
-	if handoffWatch {
+	if opts.watch {
 		fmt.Printf("Switching to %s...\n", targetSession)
 		// Use tmux switch-client to move our view to the target session
-		if err := exec.Command("tmux", "-u", "switch-client", "-t", targetSession).Run(); err != nil {
+		if _, err := runHandoffTmux("-u", "switch-client", "-t", targetSession); err != nil {
 			// Non-fatal - they can manually switch
-			fmt.Printf("Note: Could not auto-switch (use: tmux switch-client -t %s)\n", targetSession)
+			fmt.Printf("Note: Could not auto-switch (use: tmux switch-client -t %s): %v\n", targetSession, err)
 		}
 	}
 
 	return nil
 }
 
+// paneWatcher is the subset of tmux operations used to watch a respawned pane.
+type paneWatcher interface {
+	HasSession(name string) (bool, error)
+	IsPaneDead(pane string) (bool, error)
+	CapturePane(session string, lines int) (string, error)
+}
+
+// paneTailLines is how much of a dead pane's output is quoted in errors.
+const paneTailLines = 20
+
+// waitForPaneReady polls a freshly respawned pane until it shows output,
+// failing if the session disappears, the pane's process dies, or nothing
+// appears within timeout.
+func waitForPaneReady(t paneWatcher, sessionName, pane string, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	for {
+		exists, err := t.HasSession(sessionName)
+		if err != nil {
+			return fmt.Errorf("checking session: %w", err)
+		}
+		if !exists {
+			return fmt.Errorf("session %s exited after respawn", sessionName)
+		}
+		dead, err := t.IsPaneDead(pane)
+		if err != nil {
+			return fmt.Errorf("checking pane %s: %w", pane, err)
+		}
+		if dead {
+			if out, err := t.CapturePane(pane, paneTailLines); err == nil && strings.TrimSpace(out) != "" {
+				return fmt.Errorf("session %s: pane %s died after respawn; last output:\n%s", sessionName, pane, strings.TrimSpace(out))
+			}
+			return fmt.Errorf("session %s: pane %s died after respawn", sessionName, pane)
+		}
+		if out, err := t.CapturePane(pane, 50); err == nil && strings.TrimSpace(out) != "" {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("session %s produced no output within %s", sessionName, timeout)
+		}
+		time.Sleep(handoffWaitPoll)
+	}
+}
+
+// paneCurrentCommand returns the foreground command of a tmux pane.
+// Replaced in tests.
+var paneCurrentCommand = func(pane string) (string, error) {
+	out, err := runHandoffTmux("display-message", "-p", "-t", pane, "#{pane_current_command}")
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// paneIsBusy reports whether a pane's foreground process is something other
+// than an idle shell or an agent runtime (claude, node, or any other
+// registered agent's process), e.g. a long-running build started by hand.
+func paneIsBusy(pane string) (bool, error) {
+	cmd, err := paneCurrentCommand(pane)
+	if err != nil {
+		return false, err
+	}
+	if cmd == "" {
+		return false, nil
+	}
+	for _, shell := range constants.SupportedShells {
+		if cmd == shell {
+			return false, nil
+		}
+	}
+	for _, agent := range config.ListAgentPresets() {
+		for _, name := range config.GetProcessNames(agent) {
+			if cmd == name {
+				return false, nil
+			}
+		}
+	}
+	return true, nil
+}
+
 // getSessionPane returns the pane identifier for a session's main pane.
 func getSessionPane(sessionName string) (string, error) {
 	// Get the pane ID for the first pane in the session
-	out, err := exec.Command("tmux", "list-panes", "-t", sessionName, "-F", "#{pane_id}").Output()
+	out, err := runHandoffTmux("list-panes", "-t", sessionName, "-F", "#{pane_id}")
 	if err != nil {
 		return "", err
 	}
+	return firstPaneID(out)
+}
+
+// firstPaneID returns the first pane ID in tmux list-panes output.
+func firstPaneID(out []byte) (string, error) {
 	lines := strings.Split(strings.TrimSpace(string(out)), "\n")
 	if len(lines) == 0 || lines[0] == "" {
 		return "", fmt.Errorf("no panes found in session")
@@ -1061,9 +1595,80 @@ func getSessionPane(sessionName string) (string, error) {
 	return lines[0], nil
 }
 
+// handoffExecCommand builds the tmux commands run by runHandoffTmux. Tests
+// replace it to simulate a wedged tmux server.
+var handoffExecCommand = exec.CommandContext
+
+// runHandoffTmux runs a tmux command for a remote handoff, giving up after
+// --timeout so a wedged tmux server can't hang the handoff forever.
+// A non-positive --timeout disables the limit.
+func runHandoffTmux(args ...string) ([]byte, error) {
+	var ctx context.Context
+	var cancel context.CancelFunc
+	if handoffTimeout > 0 {
+		ctx, cancel = context.WithTimeout(context.Background(), handoffTimeout)
+	} else {
+		ctx, cancel = context.WithCancel(context.Background())
+	}
+	defer cancel()
+
+	cmd := handoffExecCommand(ctx, "tmux", args...)
+	cmd.WaitDelay = time.Second // don't wait on orphaned pipes after a kill
+	out, err := cmd.Output()
+	if ctx.Err() == context.DeadlineExceeded {
+		return nil, fmt.Errorf("tmux %s timed out after %s (is the tmux server wedged?)", strings.Join(args, " "), handoffTimeout)
+	}
+	return out, err
+}
+
+// handoffMailMeta is the structured metadata attached to a handoff mail so
+// inboxes can group handoffs for the same work.
+type handoffMailMeta struct {
+	BeadID    string // Work bead the handoff is about (empty if none)
+	FromAgent string // Sending agent identity; resolved from the environment if empty
+}
+
+// ThreadID returns the mail thread for this handoff. It is derived from the
+// bead so every handoff of the same work lands in one thread; handoffs with
+// no bead share a per-agent thread.
+func (m handoffMailMeta) ThreadID() string {
+	key := m.BeadID
+	if key == "" {
+		key = m.FromAgent
+	}
+	sum := sha256.Sum256([]byte("handoff:" + key))
+	return "thread-" + hex.EncodeToString(sum[:6])
+}
+
+// labels returns the mail labels for this handoff, in the mail router's
+// label format (from:X, thread:X) plus bead:X for the work bead.
+func (m handoffMailMeta) labels() []string {
+	labels := []string{"from:" + m.FromAgent, "gt:message", "thread:" + m.ThreadID()}
+	if m.BeadID != "" {
+		labels = append(labels, "bead:"+m.BeadID)
+	}
+	return labels
+}
+
+// body appends a plain-text trailer naming the bead, so the metadata stays
+// readable for humans reading the mail.
+func (m handoffMailMeta) body(message string) string {
+	if m.BeadID == "" {
+		return message
+	}
+	return message + "\n\nBead: " + m.BeadID
+}
+
 // sendHandoffMail sends a handoff mail to self and auto-hooks it.
-// Returns the created bead ID and any error.
-func sendHandoffMail(subject, message string) (string, error) {
+// Returns the created bead ID and any error. With dryRun, the rendered mail is
+// printed instead and nothing is delivered.
+func sendHandoffMail(subject, message string, dryRun bool) (string, error) {
+	return sendHandoffMailWithMeta(subject, message, handoffMailMeta{}, dryRun)
+}
+
+// sendHandoffMailWithMeta is sendHandoffMail with structured metadata
+// (work bead, sender, and a thread ID stable per bead).
+func sendHandoffMailWithMeta(subject, message string, meta handoffMailMeta, dryRun bool) (string, error) {
 	// Build subject with handoff prefix if not already present
 	if subject == "" {
 		subject = "🤝 HANDOFF: Session cycling"
@@ -1084,6 +1689,14 @@ func sendHandoffMail(subject, message string) (string, error) {
 
 	// Normalize identity to match mailbox query format
 	agentID = mail.AddressToIdentity(agentID)
+	if meta.FromAgent == "" {
+		meta.FromAgent = agentID
+	}
+
+	if dryRun {
+		printHandoffMail(os.Stdout, agentID, subject, message, meta)
+		return "", nil
+	}
 
 	// Detect town root for beads location
 	townRoot := detectTownRootFromCwd()
@@ -1091,9 +1704,6 @@ func sendHandoffMail(subject, message string) (string, error) {
 		return "", fmt.Errorf("cannot detect town root")
 	}
 
-	// Build labels for mail metadata (matches mail router format)
-	labels := fmt.Sprintf("from:%s", agentID)
-
 	// Create mail bead directly using bd create with --silent to get the ID
 	// Mail goes to town-level beads (hq- prefix)
 	// Flags go first, then -- to end flag parsing, then the positional subject.
@@ -1101,9 +1711,9 @@ func sendHandoffMail(subject, message string) (string, error) {
 	args := []string{
 		"create",
 		"--assignee", agentID,
-		"-d", message,
+		"-d", meta.body(message),
 		"--priority", "1", // high — handoffs should float above normal mail
-		"--labels", labels + ",gt:message",
+		"--labels", strings.Join(meta.labels(), ","), // matches mail router format
 		"--actor", agentID,
 		"--ephemeral", // Handoff mail is ephemeral
 		"--silent",    // Output only the bead ID
@@ -1150,6 +1760,17 @@ func sendHandoffMail(subject, message string) (string, error) {
 	return beadID, nil
 }
 
+// printHandoffMail renders the mail sendHandoffMail would create, for dry runs.
+func printHandoffMail(w io.Writer, agentID, subject, message string, meta handoffMailMeta) {
+	fmt.Fprintf(w, "Would send handoff mail (auto-hooked):\n")
+	fmt.Fprintf(w, "  To:      %s\n", agentID)
+	fmt.Fprintf(w, "  Subject: %s\n", subject)
+	fmt.Fprintf(w, "  Labels:  %s\n", strings.Join(meta.labels(), ","))
+	for _, line := range strings.Split(meta.body(message), "\n") {
+		fmt.Fprintf(w, "  | %s\n", line)
+	}
+}
+
 // warnHandoffGitStatus checks the current workspace for uncommitted or unpushed
 // work and prints a warning if found. Non-blocking — handoff continues regardless.
 // Skips .beads/ changes since those are managed by Dolt and not a concern.
@@ -0,0 +1,332 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/session"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/tmux"
+)
+
+// handoffRoleOrder ranks roles for --all so that town-level coordinators come
+// back first, then rig infrastructure, then workers.
+var handoffRoleOrder = map[session.Role]int{
+	session.RoleMayor:    0,
+	session.RoleDeacon:   1,
+	session.RoleWitness:  2,
+	session.RoleRefinery: 3,
+	session.RoleCrew:     4,
+	session.RolePolecat:  5,
+}
+
+// sessionsToHandoff returns the Gas Town sessions that --all should respawn,
+// in respawn order (see orderHandoffSessions).
+func sessionsToHandoff(t *tmux.Tmux, currentSession string) ([]string, error) {
+	all, err := t.ListGastownSessions(session.IsKnownSession)
+	if err != nil {
+		return nil, fmt.Errorf("listing sessions: %w", err)
+	}
+	return orderHandoffSessions(all, currentSession), nil
+}
+
+// orderHandoffSessions sorts Gas Town sessions into respawn order by role.
+// currentSession (if it is among them) is always last so the loop doesn't
+// kill the process running it before it finishes.
+func orderHandoffSessions(all []string, currentSession string) []string {
+	rank := func(sess string) int {
+		identity, err := session.ParseSessionName(sess)
+		if err != nil {
+			return len(handoffRoleOrder)
+		}
+		if r, ok := handoffRoleOrder[identity.Role]; ok {
+			return r
+		}
+		return len(handoffRoleOrder)
+	}
+
+	var sessions []string
+	includeCurrent := false
+	for _, sess := range all {
+		if sess == currentSession {
+			includeCurrent = true
+			continue
+		}
+		sessions = append(sessions, sess)
+	}
+	sort.SliceStable(sessions, func(i, j int) bool {
+		ri, rj := rank(sessions[i]), rank(sessions[j])
+		if ri != rj {
+			return ri < rj
+		}
+		return sessions[i] < sessions[j]
+	})
+	if includeCurrent {
+		sessions = append(sessions, currentSession)
+	}
+	return sessions
+}
+
+// sessionsForRig returns the sessions in all that belong to rig (its witness,
+// refinery, crew, and polecats), preserving order. Town-level sessions such
+// as the mayor and deacon never match.
+func sessionsForRig(all []string, rig string) []string {
+	var matched []string
+	for _, sess := range all {
+		identity, err := session.ParseSessionName(sess)
+		if err != nil || identity.Rig == "" {
+			continue
+		}
+		if identity.Rig == rig {
+			matched = append(matched, sess)
+		}
+	}
+	return matched
+}
+
+// excludeHandoffSessions drops the sessions named by exclude from sessions,
+// preserving order. Each name matches a session exactly or, via resolve, as a
+// role shorthand such as "mayor" or "witness". A name that cannot be resolved
+// or matches none of the sessions is an error, so a typo never respawns a
+// session the caller meant to keep.
+func excludeHandoffSessions(sessions, exclude []string, resolve func(string) (string, error)) (kept, excluded []string, err error) {
+	present := make(map[string]bool, len(sessions))
+	for _, sess := range sessions {
+		present[sess] = true
+	}
+
+	skip := make(map[string]bool)
+	for _, name := range exclude {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		if present[name] {
+			skip[name] = true
+			continue
+		}
+		resolved, err := resolve(name)
+		if err != nil {
+			return nil, nil, fmt.Errorf("--exclude %s: %w", name, err)
+		}
+		if !present[resolved] {
+			return nil, nil, fmt.Errorf("--exclude %s: no session %s among those being handed off", name, resolved)
+		}
+		skip[resolved] = true
+	}
+
+	for _, sess := range sessions {
+		if skip[sess] {
+			excluded = append(excluded, sess)
+			continue
+		}
+		kept = append(kept, sess)
+	}
+	return kept, excluded, nil
+}
+
+// runHandoffList prints every Gas Town session (or, with --rig, one rig's
+// sessions) alongside the restart command a handoff would use. Sessions that
+// cannot be resolved are reported rather than skipped.
+func runHandoffList() error {
+	t := tmux.NewTmux()
+	sessions, err := sessionsToHandoff(t, "")
+	if err != nil {
+		return err
+	}
+	if handoffRig != "" {
+		sessions = sessionsForRig(sessions, handoffRig)
+	}
+	if len(sessions) == 0 {
+		fmt.Println("No Gas Town sessions found")
+		return nil
+	}
+	resolve := func(sess string) (string, error) {
+		return buildRestartCommandForAgent(sess, sessionHandoffAgent(t, sess))
+	}
+	if unresolved := listHandoffTargets(os.Stdout, sessions, resolve); unresolved > 0 {
+		fmt.Printf("\n%d session(s) cannot be handed off\n", unresolved)
+	}
+	return nil
+}
+
+// listHandoffTargets writes each session and its resolved restart command to
+// w, using resolve (buildRestartCommandForAgent in production). Returns the
+// number of sessions that failed to resolve.
+func listHandoffTargets(w io.Writer, sessions []string, resolve func(string) (string, error)) int {
+	unresolved := 0
+	for _, sess := range sessions {
+		restartCmd, err := resolve(sess)
+		if err != nil {
+			unresolved++
+			fmt.Fprintf(w, "%s %s\n    %s\n", style.Warning.Render("⚠"), sess, err)
+			continue
+		}
+		fmt.Fprintf(w, "%s %s\n    %s\n", style.Success.Render("✓"), sess, style.Dim.Render(restartCmd))
+	}
+	return unresolved
+}
+
+// runHandoffAll respawns every Gas Town session (or, with --rig, every session
+// of one rig) with a fresh agent, using the same restart command a
+// single-session handoff would. No handoff mail is
+// sent; hooked work is picked up by each new session's SessionStart hook.
+func runHandoffAll() error {
+	t := tmux.NewTmux()
+
+	currentSession := ""
+	if tmux.IsInsideTmux() {
+		currentSession, _ = getCurrentTmuxSession()
+	}
+
+	sessions, err := sessionsToHandoff(t, currentSession)
+	if err != nil {
+		return err
+	}
+	if handoffRig != "" {
+		sessions = sessionsForRig(sessions, handoffRig)
+		if len(sessions) == 0 {
+			return fmt.Errorf("no sessions found for rig %q", handoffRig)
+		}
+	}
+	if len(handoffExclude) > 0 {
+		var excluded []string
+		sessions, excluded, err = excludeHandoffSessions(sessions, handoffExclude, func(name string) (string, error) {
+			return resolveRoleToSessionFor(name, handoffRig, "")
+		})
+		if err != nil {
+			return err
+		}
+		if len(excluded) > 0 {
+			fmt.Printf("Excluding: %s\n", strings.Join(excluded, ", "))
+		}
+	}
+	if len(sessions) == 0 {
+		return fmt.Errorf("no Gas Town sessions found")
+	}
+
+	// Bulk handoff asks for confirmation once up front rather than per
+	// session, and never switches the client to each target.
+	if err := confirmRemoteHandoff(sessions...); err != nil {
+		return err
+	}
+	// --sequential-health waits on each session like --wait, and stops the
+	// group at the first one that doesn't come up.
+	opts := remoteHandoffOpts{confirmed: true, wait: handoffWait || handoffSeqHealth}
+
+	fmt.Printf("%s Handing off %d session(s)...\n", style.Bold.Render("🤝"), len(sessions))
+
+	loop := handoffLoop{stagger: handoffStagger, sleepFn: time.Sleep, stopOnFailure: handoffSeqHealth}
+	failed := loop.run(sessions, func(sess string) error {
+		if sess == currentSession {
+			restartCmd, err := buildRestartCommand(sess)
+			if err != nil {
+				return err
+			}
+			// Handing off ourselves comes last: respawn kills this process,
+			// so report the other sessions' failures first.
+			if err := handoffFailureSummary(loop.failed, len(sessions)); err != nil {
+				style.PrintWarning("%v", err)
+			}
+			return handoffCurrentPane(t, sess, restartCmd)
+		}
+		// Every other session keeps its own agent, not the caller's.
+		agent := sessionHandoffAgent(t, sess)
+		restartCmd, err := buildRestartCommandForAgent(sess, agent)
+		if err != nil {
+			return err
+		}
+		sessOpts := opts
+		sessOpts.agent = &agent
+		return handoffRemoteSession(t, sess, restartCmd, sessOpts)
+	})
+
+	err = handoffFailureSummary(failed, len(sessions))
+	if len(loop.skipped) > 0 {
+		return fmt.Errorf("%w; stopped before %s, which were not handed off", err, strings.Join(loop.skipped, ", "))
+	}
+	return err
+}
+
+// handoffFailureSummary records the sessions a bulk handoff of total sessions
+// failed to hand off in the audit log and returns them as an error, or nil if
+// none failed.
+func handoffFailureSummary(failed []string, total int) error {
+	if len(failed) == 0 {
+		return nil
+	}
+	recordHandoffFailures(failed)
+	return fmt.Errorf("%d of %d session(s) failed to hand off: %v", len(failed), total, failed)
+}
+
+// handoffLoop hands off sessions one at a time, optionally pausing between
+// them so a bulk respawn doesn't hit Dolt and mail with every agent at once.
+type handoffLoop struct {
+	stagger time.Duration
+	sleepFn func(time.Duration)
+	// stopOnFailure ends the run at the first failure instead of moving on.
+	stopOnFailure bool
+
+	// failed holds the sessions that have failed so far in the current run.
+	failed []string
+	// skipped holds the sessions never tried because stopOnFailure ended
+	// the run early.
+	skipped []string
+}
+
+// run calls handoff for each session in order, sleeping for the stagger
+// between sessions (but not after the last). Failures are reported and
+// skipped, or with stopOnFailure end the run; run returns the sessions that
+// failed.
+func (l *handoffLoop) run(sessions []string, handoff func(string) error) []string {
+	l.failed, l.skipped = nil, nil
+	for i, sess := range sessions {
+		if i > 0 && l.stagger > 0 && !handoffDryRun {
+			l.sleepFn(l.stagger)
+		}
+		if err := handoff(sess); err != nil {
+			style.PrintWarning("%s: %v", sess, err)
+			l.failed = append(l.failed, sess)
+			if l.stopOnFailure {
+				l.skipped = append(l.skipped, sessions[i+1:]...)
+				break
+			}
+		}
+	}
+	return l.failed
+}
+
+// handoffCurrentPane respawns the pane running this command. Used as the last
+// step of --all.
+func handoffCurrentPane(t *tmux.Tmux, currentSession, restartCmd string) error {
+	pane := os.Getenv("TMUX_PANE")
+	if pane == "" {
+		return fmt.Errorf("TMUX_PANE not set - cannot hand off %s", currentSession)
+	}
+
+	fmt.Printf("%s Handing off %s...\n", style.Bold.Render("🤝"), currentSession)
+
+	if handoffDryRun {
+		recordHandoffAudit(currentSession, restartCmd)
+		fmt.Printf("Would execute: tmux clear-history -t %s\n", pane)
+		fmt.Printf("Would execute: tmux respawn-pane -k -t %s %s\n", pane, restartCmd)
+		return nil
+	}
+
+	updateSessionEnvForHandoff(t, currentSession, "")
+	if err := t.ClearHistory(pane); err != nil {
+		style.PrintWarning("could not clear history: %v", err)
+	}
+	if err := t.SetRemainOnExit(pane, true); err != nil {
+		style.PrintWarning("could not set remain-on-exit: %v", err)
+	}
+	// A successful respawn kills this process, so audit just before it. If
+	// the respawn fails we're still here, and the caller's failure summary
+	// records the session as not handed off.
+	recordHandoffAudit(currentSession, restartCmd)
+	return t.RespawnPaneWithEnv(pane, restartCmd, handoffIdentityEnv(currentSession))
+}
@@ -0,0 +1,363 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestOrderHandoffSessions(t *testing.T) {
+	setupHandoffTestRegistry(t)
+
+	all := []string{
+		"gt-crew-max",
+		"gt-refinery",
+		"gt-Toast",
+		"hq-mayor",
+		"gt-witness",
+		"gt-crew-joe",
+		"hq-deacon",
+	}
+
+	t.Run("orders by role", func(t *testing.T) {
+		got := orderHandoffSessions(all, "")
+		want := []string{"hq-mayor", "hq-deacon", "gt-witness", "gt-refinery", "gt-crew-joe", "gt-crew-max", "gt-Toast"}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("orderHandoffSessions() = %v, want %v", got, want)
+		}
+	})
+
+	t.Run("current session last", func(t *testing.T) {
+		got := orderHandoffSessions(all, "hq-mayor")
+		want := []string{"hq-deacon", "gt-witness", "gt-refinery", "gt-crew-joe", "gt-crew-max", "gt-Toast", "hq-mayor"}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("orderHandoffSessions() = %v, want %v", got, want)
+		}
+	})
+
+	t.Run("current session outside Gas Town is ignored", func(t *testing.T) {
+		got := orderHandoffSessions(all, "scratch")
+		if len(got) != len(all) {
+			t.Errorf("orderHandoffSessions() = %v, want only the %d Gas Town sessions", got, len(all))
+		}
+		for _, s := range got {
+			if s == "scratch" {
+				t.Errorf("non-Gas Town session included: %v", got)
+			}
+		}
+	})
+}
+
+func TestSessionsForRig(t *testing.T) {
+	setupHandoffTestRegistry(t)
+
+	all := []string{"hq-mayor", "gt-witness", "gt-refinery", "gt-crew-max", "gt-Toast", "hq-deacon", "scratch"}
+
+	got := sessionsForRig(all, "gastown")
+	want := []string{"gt-witness", "gt-refinery", "gt-crew-max", "gt-Toast"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("sessionsForRig(gastown) = %v, want %v", got, want)
+	}
+
+	if got := sessionsForRig(all, "beads"); len(got) != 0 {
+		t.Errorf("sessionsForRig(beads) = %v, want none", got)
+	}
+}
+
+func TestExcludeHandoffSessions_NeverRespawned(t *testing.T) {
+	setupHandoffTestRegistry(t)
+
+	sessions := []string{"hq-mayor", "hq-deacon", "gt-witness", "gt-refinery", "gt-crew-max"}
+	resolve := func(name string) (string, error) { return resolveRoleToSessionFor(name, "gastown", "") }
+
+	kept, excluded, err := excludeHandoffSessions(sessions, []string{"mayor", "gt-crew-max", "witness"}, resolve)
+	if err != nil {
+		t.Fatalf("excludeHandoffSessions() error = %v", err)
+	}
+	if want := []string{"hq-mayor", "gt-witness", "gt-crew-max"}; !reflect.DeepEqual(excluded, want) {
+		t.Errorf("excluded = %v, want %v", excluded, want)
+	}
+
+	fake := &fakeHandoffTmux{}
+	loop := handoffLoop{sleepFn: func(time.Duration) {}}
+	loop.run(kept, func(sess string) error { return fake.RespawnPane(sess, "exec claude") })
+	for _, sess := range fake.respawned {
+		for _, ex := range excluded {
+			if sess == ex {
+				t.Errorf("excluded session %s was respawned", sess)
+			}
+		}
+	}
+	if want := []string{"hq-deacon", "gt-refinery"}; !reflect.DeepEqual(fake.respawned, want) {
+		t.Errorf("respawned = %v, want %v", fake.respawned, want)
+	}
+}
+
+func TestExcludeHandoffSessions_UnresolvableName(t *testing.T) {
+	resolve := func(string) (string, error) { return "", errors.New("cannot determine rig") }
+	if _, _, err := excludeHandoffSessions([]string{"hq-mayor"}, []string{"witness"}, resolve); err == nil {
+		t.Fatal("expected error for an exclude that cannot be resolved")
+	}
+}
+
+func TestExcludeHandoffSessions_NoMatchingSession(t *testing.T) {
+	resolve := func(name string) (string, error) { return "gt-" + name, nil }
+	_, _, err := excludeHandoffSessions([]string{"hq-mayor", "gt-refinery"}, []string{"witness"}, resolve)
+	if err == nil || !strings.Contains(err.Error(), "gt-witness") {
+		t.Fatalf("err = %v, want an error naming the unmatched session gt-witness", err)
+	}
+}
+
+func TestRunHandoff_ExcludeRequiresBulkMode(t *testing.T) {
+	origExclude := handoffExclude
+	t.Cleanup(func() { handoffExclude = origExclude })
+	handoffExclude = []string{"mayor"}
+
+	err := runHandoff(handoffCmd, nil)
+	if err == nil || !strings.Contains(err.Error(), "--exclude") {
+		t.Fatalf("runHandoff() err = %v, want --exclude rejected outside --all/--rig", err)
+	}
+}
+
+func TestHandoffLoop_Stagger(t *testing.T) {
+	var slept []time.Duration
+	var order []string
+	loop := handoffLoop{
+		stagger: 3 * time.Second,
+		sleepFn: func(d time.Duration) { slept = append(slept, d) },
+	}
+
+	failed := loop.run([]string{"hq-deacon", "gt-witness", "gt-refinery"}, func(sess string) error {
+		order = append(order, fmt.Sprintf("%s after %d sleeps", sess, len(slept)))
+		if sess == "gt-witness" {
+			return errors.New("boom")
+		}
+		return nil
+	})
+
+	wantSleeps := []time.Duration{3 * time.Second, 3 * time.Second}
+	if !reflect.DeepEqual(slept, wantSleeps) {
+		t.Errorf("sleeps = %v, want %v (none after the last session)", slept, wantSleeps)
+	}
+	wantOrder := []string{"hq-deacon after 0 sleeps", "gt-witness after 1 sleeps", "gt-refinery after 2 sleeps"}
+	if !reflect.DeepEqual(order, wantOrder) {
+		t.Errorf("order = %v, want %v", order, wantOrder)
+	}
+	if !reflect.DeepEqual(failed, []string{"gt-witness"}) {
+		t.Errorf("failed = %v, want [gt-witness]", failed)
+	}
+}
+
+func TestHandoffLoop_NoStagger(t *testing.T) {
+	loop := handoffLoop{sleepFn: func(time.Duration) { t.Error("unexpected sleep with zero stagger") }}
+	loop.run([]string{"a", "b"}, func(string) error { return nil })
+}
+
+func TestHandoffLoop_StopOnFailure(t *testing.T) {
+	loop := handoffLoop{sleepFn: func(time.Duration) {}, stopOnFailure: true}
+	var tried []string
+	failed := loop.run([]string{"hq-deacon", "gt-witness", "gt-refinery"}, func(sess string) error {
+		tried = append(tried, sess)
+		if sess == "gt-witness" {
+			return errors.New("boom")
+		}
+		return nil
+	})
+	if !reflect.DeepEqual(tried, []string{"hq-deacon", "gt-witness"}) {
+		t.Errorf("tried = %v, want the run to stop after gt-witness", tried)
+	}
+	if !reflect.DeepEqual(failed, []string{"gt-witness"}) {
+		t.Errorf("failed = %v, want [gt-witness]", failed)
+	}
+	if !reflect.DeepEqual(loop.skipped, []string{"gt-refinery"}) {
+		t.Errorf("skipped = %v, want [gt-refinery]", loop.skipped)
+	}
+}
+
+// sickPaneTmux is a fakeHandoffTmux whose sick pane is dead after respawn.
+type sickPaneTmux struct {
+	fakeHandoffTmux
+	sick string
+}
+
+func (f *sickPaneTmux) IsPaneDead(pane string) (bool, error) { return pane == f.sick, nil }
+
+// TestSequentialHealth_StopsAtUnhealthySession runs a group handoff the way
+// --sequential-health does: the second of three sessions never comes up, so
+// the third must not be touched.
+func TestSequentialHealth_StopsAtUnhealthySession(t *testing.T) {
+	origYes, origDry, origWaitFor, origPoll := handoffYes, handoffDryRun, handoffWaitFor, handoffWaitPoll
+	origExec, origPane := handoffExecCommand, paneCurrentCommand
+	t.Cleanup(func() {
+		handoffYes, handoffDryRun, handoffWaitFor, handoffWaitPoll = origYes, origDry, origWaitFor, origPoll
+		handoffExecCommand, paneCurrentCommand = origExec, origPane
+	})
+	t.Chdir(t.TempDir()) // keep the handoff audit log out of any real town
+	handoffYes, handoffDryRun = true, false
+	handoffWaitFor, handoffWaitPoll = time.Second, time.Millisecond
+
+	panes := map[string]string{"hq-deacon": "%1", "gt-witness": "%2", "gt-refinery": "%3"}
+	handoffExecCommand = func(ctx context.Context, _ string, args ...string) *exec.Cmd {
+		target := ""
+		for i, arg := range args {
+			if arg == "-t" && i+1 < len(args) {
+				target = args[i+1]
+			}
+		}
+		if args[0] == "display-message" {
+			for sess, pane := range panes {
+				if pane == target {
+					return exec.CommandContext(ctx, "echo", sess)
+				}
+			}
+		}
+		return exec.CommandContext(ctx, "echo", panes[target])
+	}
+	paneCurrentCommand = func(string) (string, error) { return "bash", nil }
+
+	fake := &sickPaneTmux{sick: "%2"}
+	fake.output = "ready"
+	opts := remoteHandoffOpts{confirmed: true, wait: true}
+	loop := handoffLoop{sleepFn: func(time.Duration) {}, stopOnFailure: true}
+	failed := loop.run([]string{"hq-deacon", "gt-witness", "gt-refinery"}, func(sess string) error {
+		return handoffRemoteSession(fake, sess, "exec claude", opts)
+	})
+
+	if !reflect.DeepEqual(fake.respawned, []string{"%1", "%2"}) {
+		t.Errorf("respawned panes = %v, want [%%1 %%2] and gt-refinery untouched", fake.respawned)
+	}
+	if !reflect.DeepEqual(failed, []string{"gt-witness"}) {
+		t.Errorf("failed = %v, want [gt-witness]", failed)
+	}
+	if !reflect.DeepEqual(loop.skipped, []string{"gt-refinery"}) {
+		t.Errorf("skipped = %v, want [gt-refinery]", loop.skipped)
+	}
+}
+
+func TestRunHandoff_SequentialHealthRequiresBulkMode(t *testing.T) {
+	origSeq := handoffSeqHealth
+	t.Cleanup(func() { handoffSeqHealth = origSeq })
+	handoffSeqHealth = true
+
+	err := runHandoff(handoffCmd, []string{"mayor"})
+	if err == nil || !strings.Contains(err.Error(), "--sequential-health") {
+		t.Fatalf("runHandoff() err = %v, want --sequential-health rejected outside --all/--rig", err)
+	}
+}
+
+func TestListHandoffTargets_ReportsUnknown(t *testing.T) {
+	resolve := func(sess string) (string, error) {
+		if sess == "hq-boot" {
+			return "", fmt.Errorf("unknown session type: %s", sess)
+		}
+		return "cd /town && exec claude", nil
+	}
+
+	var buf bytes.Buffer
+	unresolved := listHandoffTargets(&buf, []string{"hq-mayor", "hq-boot"}, resolve)
+	if unresolved != 1 {
+		t.Errorf("unresolved = %d, want 1", unresolved)
+	}
+	out := buf.String()
+	if !strings.Contains(out, "hq-boot") || !strings.Contains(out, "unknown session type") {
+		t.Errorf("unknown session not reported, output:\n%s", out)
+	}
+	if !strings.Contains(out, "hq-mayor") || !strings.Contains(out, "exec claude") {
+		t.Errorf("resolved session missing restart command, output:\n%s", out)
+	}
+}
+
+func TestHandoffLoop_FailuresVisibleBeforeLastSession(t *testing.T) {
+	loop := handoffLoop{sleepFn: func(time.Duration) {}}
+	var seenBeforeSelf []string
+	loop.run([]string{"gt-witness", "gt-refinery", "hq-mayor"}, func(sess string) error {
+		if sess == "hq-mayor" {
+			// The self-handoff kills the process, so it must see the
+			// earlier failures before it runs.
+			seenBeforeSelf = append([]string(nil), loop.failed...)
+			return nil
+		}
+		if sess == "gt-witness" {
+			return errors.New("boom")
+		}
+		return nil
+	})
+	if !reflect.DeepEqual(seenBeforeSelf, []string{"gt-witness"}) {
+		t.Errorf("failures seen before the last session = %v, want [gt-witness]", seenBeforeSelf)
+	}
+}
+
+func TestHandoffFailureSummary_RecordsAudit(t *testing.T) {
+	townRoot := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(townRoot, "mayor"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(townRoot, "mayor", "town.json"), []byte(`{"name":"test"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	t.Chdir(townRoot)
+
+	if err := handoffFailureSummary(nil, 3); err != nil {
+		t.Errorf("handoffFailureSummary(nil) = %v, want nil", err)
+	}
+	err := handoffFailureSummary([]string{"gt-witness"}, 3)
+	if err == nil || !strings.Contains(err.Error(), "1 of 3 session(s) failed") {
+		t.Errorf("handoffFailureSummary() = %v, want the N of M summary", err)
+	}
+
+	data, readErr := os.ReadFile(filepath.Join(townRoot, handoffAuditFile))
+	if readErr != nil {
+		t.Fatalf("audit log not written: %v", readErr)
+	}
+	var entry handoffAuditEntry
+	if err := json.Unmarshal(bytes.TrimSpace(data), &entry); err != nil {
+		t.Fatalf("malformed audit log %q: %v", data, err)
+	}
+	if !reflect.DeepEqual(entry.Failed, []string{"gt-witness"}) {
+		t.Errorf("audit entry failed = %v, want [gt-witness]", entry.Failed)
+	}
+}
+
+func TestBuildRestartCommandForAgent_KeepsTargetAgent(t *testing.T) {
+	setupHandoffTestRegistry(t)
+	townRoot := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(townRoot, "mayor"), 0755); err != nil {
+		t.Fatalf("mkdir mayor: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(townRoot, "mayor", "town.json"), []byte(`{"name":"gastown"}`), 0644); err != nil {
+		t.Fatalf("write town.json: %v", err)
+	}
+	t.Chdir(townRoot)
+	t.Setenv("GT_TOWN_ROOT", "")
+	t.Setenv("GT_ROOT", "")
+	// The caller runs gemini; the target session runs codex.
+	t.Setenv("GT_AGENT", "gemini")
+	t.Setenv("GT_PROCESS_NAMES", "gemini")
+	fake := &fakeHandoffTmux{env: map[string]string{"GT_AGENT": "codex"}}
+
+	agent := sessionHandoffAgent(fake, "gt-witness")
+	if agent.name != "codex" || agent.processNames != "" {
+		t.Fatalf("sessionHandoffAgent() = %+v, want the target's codex agent", agent)
+	}
+	cmd, err := buildRestartCommandForAgent("gt-witness", agent)
+	if err != nil {
+		t.Fatalf("buildRestartCommandForAgent: %v", err)
+	}
+	if !strings.Contains(cmd, "GT_AGENT=codex") || strings.Contains(cmd, "gemini") {
+		t.Errorf("restart command should keep the target's agent, got: %q", cmd)
+	}
+
+	setSessionAgentEnv(fake, "gt-witness", agent)
+	if fake.setEnv["GT_AGENT"] != "codex" || strings.Contains(fake.setEnv["GT_PROCESS_NAMES"], "gemini") {
+		t.Errorf("session env = %v, want the target's codex agent", fake.setEnv)
+	}
+}
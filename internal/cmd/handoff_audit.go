@@ -0,0 +1,67 @@
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// handoffAuditFile is the append-only record of session respawns, relative
+// to the town root. One JSON object per line.
+const handoffAuditFile = "logs/handoff.log"
+
+// handoffAuditEntry is one line of the handoff audit log.
+type handoffAuditEntry struct {
+	Timestamp      time.Time `json:"timestamp"`
+	Session        string    `json:"session"`
+	RestartCommand string    `json:"restart_command"`
+	Agent          string    `json:"agent"`
+	DryRun         bool      `json:"dry_run"`
+	// Failed lists the sessions a bulk handoff could not hand off. Set only
+	// on the summary entry recorded by recordHandoffFailures.
+	Failed []string `json:"failed,omitempty"`
+}
+
+// recordHandoffAudit appends a line to the town's handoff audit log recording
+// who respawned which session with what command. Best-effort: failures are
+// ignored so auditing never blocks a handoff.
+func recordHandoffAudit(sessionName, restartCmd string) {
+	appendHandoffAudit(handoffAuditEntry{
+		Session:        sessionName,
+		RestartCommand: restartCmd,
+	})
+}
+
+// recordHandoffFailures appends a summary line listing the sessions a bulk
+// handoff failed to hand off. Best-effort, like recordHandoffAudit.
+func recordHandoffFailures(failed []string) {
+	appendHandoffAudit(handoffAuditEntry{Failed: failed})
+}
+
+// appendHandoffAudit stamps entry and appends it to the town's audit log.
+func appendHandoffAudit(entry handoffAuditEntry) {
+	townRoot := detectTownRootFromCwd()
+	if townRoot == "" {
+		return
+	}
+
+	entry.Timestamp = time.Now().UTC()
+	entry.Agent = detectSender()
+	entry.DryRun = handoffDryRun
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return
+	}
+
+	path := filepath.Join(townRoot, handoffAuditFile)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return
+	}
+	defer f.Close()
+	_, _ = f.Write(append(line, '\n'))
+}
@@ -0,0 +1,122 @@
+package cmd
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/steveyegge/gastown/internal/tmux"
+)
+
+func TestRecordHandoffAudit_DryRun(t *testing.T) {
+	townRoot := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(townRoot, "mayor"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(townRoot, "mayor", "town.json"), []byte(`{"name":"test"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	t.Chdir(townRoot)
+	t.Setenv("GT_ROLE", "mayor")
+	t.Setenv("TMUX_PANE", "%7")
+
+	origDry := handoffDryRun
+	handoffDryRun = true
+	t.Cleanup(func() { handoffDryRun = origDry })
+
+	if err := handoffCurrentPane(tmux.NewTmux(), "hq-mayor", "exec claude"); err != nil {
+		t.Fatalf("handoffCurrentPane() = %v", err)
+	}
+
+	f, err := os.Open(filepath.Join(townRoot, handoffAuditFile))
+	if err != nil {
+		t.Fatalf("audit log not written: %v", err)
+	}
+	defer f.Close()
+
+	var lines []handoffAuditEntry
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var entry handoffAuditEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			t.Fatalf("malformed audit line %q: %v", scanner.Text(), err)
+		}
+		lines = append(lines, entry)
+	}
+	if len(lines) != 1 {
+		t.Fatalf("got %d audit lines, want 1", len(lines))
+	}
+	got := lines[0]
+	if got.Session != "hq-mayor" || got.RestartCommand != "exec claude" || !got.DryRun {
+		t.Errorf("audit entry = %+v, want session hq-mayor, command %q, dry_run true", got, "exec claude")
+	}
+	if got.Agent != "mayor/" {
+		t.Errorf("audit agent = %q, want %q", got.Agent, "mayor/")
+	}
+	if got.Timestamp.IsZero() {
+		t.Error("audit entry has no timestamp")
+	}
+}
+
+// TestHandoffRemoteSession_AuditsOnlyRespawned checks that a remote handoff
+// is audited once its pane respawns, and not at all when the respawn fails.
+func TestHandoffRemoteSession_AuditsOnlyRespawned(t *testing.T) {
+	origYes, origDry, origWait, origForce := handoffYes, handoffDryRun, handoffWait, handoffForce
+	origExec, origPane := handoffExecCommand, paneCurrentCommand
+	t.Cleanup(func() {
+		handoffYes, handoffDryRun, handoffWait, handoffForce = origYes, origDry, origWait, origForce
+		handoffExecCommand, paneCurrentCommand = origExec, origPane
+	})
+	handoffYes, handoffDryRun, handoffWait, handoffForce = true, false, false, false
+	handoffExecCommand = fakeHandoffPaneLookup("%1", "hq-mayor")
+	paneCurrentCommand = func(string) (string, error) { return "bash", nil }
+
+	tests := []struct {
+		name       string
+		respawnErr error
+		wantLines  int
+	}{
+		{name: "respawn fails", respawnErr: errors.New("no server running"), wantLines: 0},
+		{name: "respawned", wantLines: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			townRoot := t.TempDir()
+			if err := os.MkdirAll(filepath.Join(townRoot, "mayor"), 0755); err != nil {
+				t.Fatal(err)
+			}
+			if err := os.WriteFile(filepath.Join(townRoot, "mayor", "town.json"), []byte(`{"name":"test"}`), 0644); err != nil {
+				t.Fatal(err)
+			}
+			t.Chdir(townRoot)
+
+			fake := &fakeHandoffTmux{respawnErr: tt.respawnErr}
+			err := handoffRemoteSession(fake, "hq-mayor", "exec claude", remoteHandoffOpts{})
+			if (err != nil) != (tt.respawnErr != nil) {
+				t.Fatalf("handoffRemoteSession() = %v, want error %v", err, tt.respawnErr != nil)
+			}
+
+			var lines []handoffAuditEntry
+			if f, err := os.Open(filepath.Join(townRoot, handoffAuditFile)); err == nil {
+				scanner := bufio.NewScanner(f)
+				for scanner.Scan() {
+					var entry handoffAuditEntry
+					if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+						t.Fatalf("malformed audit line %q: %v", scanner.Text(), err)
+					}
+					lines = append(lines, entry)
+				}
+				f.Close()
+			}
+			if len(lines) != tt.wantLines {
+				t.Fatalf("got %d audit lines (%+v), want %d", len(lines), lines, tt.wantLines)
+			}
+			if tt.wantLines > 0 && (lines[0].Session != "hq-mayor" || lines[0].DryRun) {
+				t.Errorf("audit entry = %+v, want a real handoff of hq-mayor", lines[0])
+			}
+		})
+	}
+}
@@ -1,14 +1,21 @@
 package cmd
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"reflect"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/mail"
 	"github.com/steveyegge/gastown/internal/session"
+	"github.com/steveyegge/gastown/internal/tmux"
 	"github.com/steveyegge/gastown/internal/workspace"
 )
 
@@ -84,6 +91,28 @@ func TestSessionWorkDir(t *testing.T) {
 			wantDir:     townRoot + "/gastown/refinery/rig",
 			wantErr:     false,
 		},
+		{
+			name:        "polecat runs from its worktree",
+			sessionName: "gt-Toast",
+			wantDir:     townRoot + "/gastown/polecats/Toast",
+			wantErr:     false,
+		},
+		{
+			name:        "boot runs from deacon dogs directory",
+			sessionName: "hq-boot",
+			wantDir:     townRoot + "/deacon/dogs/boot",
+			wantErr:     false,
+		},
+		{
+			name:        "overseer is not a restartable session",
+			sessionName: "hq-overseer",
+			wantErr:     true,
+		},
+		{
+			name:        "unregistered prefix is unknown",
+			sessionName: "scratch",
+			wantErr:     true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -174,6 +203,143 @@ func TestBuildRestartCommand_UsesRoleAgentsWhenNoAgentOverride(t *testing.T) {
 	}
 }
 
+func TestBuildRestartCommand_BootAndPolecat(t *testing.T) {
+	setupHandoffTestRegistry(t)
+
+	townRoot := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(townRoot, "mayor"), 0755); err != nil {
+		t.Fatalf("mkdir mayor: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(townRoot, "mayor", "town.json"), []byte(`{"name":"gastown"}`), 0644); err != nil {
+		t.Fatalf("write town.json: %v", err)
+	}
+	t.Chdir(townRoot)
+	t.Setenv("GT_AGENT", "")
+	t.Setenv("GT_TOWN_ROOT", "")
+	t.Setenv("GT_ROOT", "")
+
+	tests := []struct {
+		sessionName string
+		wantDir     string
+		wantRole    string
+	}{
+		{"hq-boot", townRoot + "/deacon/dogs/boot", "GT_ROLE=deacon/boot"},
+		{"gt-Toast", townRoot + "/gastown/polecats/Toast", "GT_ROLE=gastown/polecats/Toast"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.sessionName, func(t *testing.T) {
+			cmd, err := buildRestartCommand(tt.sessionName)
+			if err != nil {
+				t.Fatalf("buildRestartCommand(%q): %v", tt.sessionName, err)
+			}
+			if !strings.HasPrefix(cmd, "cd "+tt.wantDir+" ") {
+				t.Errorf("restart command should cd to %s, got: %q", tt.wantDir, cmd)
+			}
+			if !strings.Contains(cmd, tt.wantRole) {
+				t.Errorf("restart command missing %s, got: %q", tt.wantRole, cmd)
+			}
+		})
+	}
+
+	if _, err := buildRestartCommand("hq-overseer"); err == nil {
+		t.Error("expected error for unknown session type")
+	}
+}
+
+func TestBuildRestartCommand_RegisteredBuilderWins(t *testing.T) {
+	setupHandoffTestRegistry(t)
+	saved := sessionRestarts
+	t.Cleanup(func() { sessionRestarts = saved })
+
+	townRoot := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(townRoot, "mayor"), 0755); err != nil {
+		t.Fatalf("mkdir mayor: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(townRoot, "mayor", "town.json"), []byte(`{"name":"gastown"}`), 0644); err != nil {
+		t.Fatalf("write town.json: %v", err)
+	}
+	t.Chdir(townRoot)
+	t.Setenv("GT_AGENT", "")
+	t.Setenv("GT_TOWN_ROOT", "")
+	t.Setenv("GT_ROOT", "")
+
+	RegisterSessionRestart(
+		func(name string) bool { return strings.HasPrefix(name, "hq-") && name != "hq-boot" },
+		func(name string) (string, error) { return "exec custom-agent " + name, nil },
+	)
+
+	// hq-overseer has no built-in restart command; the custom builder handles it.
+	cmd, err := buildRestartCommand("hq-overseer")
+	if err != nil {
+		t.Fatalf("buildRestartCommand(hq-overseer): %v", err)
+	}
+	if cmd != "exec custom-agent hq-overseer" {
+		t.Errorf("got %q, want the registered builder's command", cmd)
+	}
+
+	// Sessions the custom matcher rejects still use the built-in role builder.
+	cmd, err = buildRestartCommand("hq-boot")
+	if err != nil {
+		t.Fatalf("buildRestartCommand(hq-boot): %v", err)
+	}
+	if !strings.HasPrefix(cmd, "cd "+townRoot+"/deacon/dogs/boot ") {
+		t.Errorf("built-in boot restart command not used, got: %q", cmd)
+	}
+}
+
+func TestResolveRoleToSessionFor(t *testing.T) {
+	setupHandoffTestRegistry(t)
+	t.Chdir(t.TempDir()) // outside any crew directory
+
+	t.Run("explicit overrides win over env", func(t *testing.T) {
+		t.Setenv("GT_RIG", "beads")
+		t.Setenv("GT_CREW", "joe")
+		got, err := resolveRoleToSessionFor("crew", "gastown", "max")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != "gt-crew-max" {
+			t.Errorf("crew = %q, want %q", got, "gt-crew-max")
+		}
+		got, err = resolveRoleToSessionFor("witness", "gastown", "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != "gt-witness" {
+			t.Errorf("witness = %q, want %q", got, "gt-witness")
+		}
+	})
+
+	t.Run("env fallback", func(t *testing.T) {
+		t.Setenv("GT_RIG", "gastown")
+		t.Setenv("GT_CREW", "joe")
+		got, err := resolveRoleToSessionFor("crew", "", "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != "gt-crew-joe" {
+			t.Errorf("crew = %q, want %q", got, "gt-crew-joe")
+		}
+		got, err = resolveRoleToSessionFor("refinery", "", "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != "gt-refinery" {
+			t.Errorf("refinery = %q, want %q", got, "gt-refinery")
+		}
+	})
+
+	t.Run("neither is an error", func(t *testing.T) {
+		t.Setenv("GT_RIG", "")
+		t.Setenv("GT_CREW", "")
+		for _, role := range []string{"crew", "witness", "refinery"} {
+			if _, err := resolveRoleToSessionFor(role, "", ""); err == nil {
+				t.Errorf("resolveRoleToSessionFor(%q) expected error with no rig", role)
+			}
+		}
+	})
+}
+
 func TestDetectTownRootFromCwd_EnvFallback(t *testing.T) {
 	// Save original env vars and restore after test
 	origTownRoot := os.Getenv("GT_TOWN_ROOT")
@@ -571,3 +737,611 @@ func TestHandoffProcessNames(t *testing.T) {
 		}
 	})
 }
+
+// fakePaneWatcher simulates a respawned pane for waitForPaneReady tests.
+type fakePaneWatcher struct {
+	dieAfter int    // polls before the pane dies; <0 never dies
+	output   string // pane content once alive
+	polls    int
+}
+
+func (f *fakePaneWatcher) HasSession(string) (bool, error) { return true, nil }
+
+func (f *fakePaneWatcher) IsPaneDead(string) (bool, error) {
+	f.polls++
+	return f.dieAfter >= 0 && f.polls > f.dieAfter, nil
+}
+
+func (f *fakePaneWatcher) CapturePane(string, int) (string, error) { return f.output, nil }
+
+func TestWaitForPaneReady(t *testing.T) {
+	origPoll := handoffWaitPoll
+	handoffWaitPoll = time.Millisecond
+	t.Cleanup(func() { handoffWaitPoll = origPoll })
+
+	t.Run("pane dies", func(t *testing.T) {
+		err := waitForPaneReady(&fakePaneWatcher{dieAfter: 2}, "gt-witness", "%1", time.Second)
+		if err == nil || !strings.Contains(err.Error(), "died") {
+			t.Fatalf("waitForPaneReady() = %v, want pane died error", err)
+		}
+	})
+
+	t.Run("pane dies with output", func(t *testing.T) {
+		err := waitForPaneReady(&fakePaneWatcher{dieAfter: 0, output: "panic: bad config\n"}, "gt-witness", "%1", time.Second)
+		if err == nil || !strings.Contains(err.Error(), "panic: bad config") {
+			t.Fatalf("waitForPaneReady() = %v, want error quoting the pane output", err)
+		}
+	})
+
+	t.Run("pane produces output", func(t *testing.T) {
+		err := waitForPaneReady(&fakePaneWatcher{dieAfter: -1, output: "Claude Code\n"}, "gt-witness", "%1", time.Second)
+		if err != nil {
+			t.Fatalf("waitForPaneReady() = %v, want nil", err)
+		}
+	})
+
+	t.Run("no output before timeout", func(t *testing.T) {
+		err := waitForPaneReady(&fakePaneWatcher{dieAfter: -1}, "gt-witness", "%1", 10*time.Millisecond)
+		if err == nil || !strings.Contains(err.Error(), "no output") {
+			t.Fatalf("waitForPaneReady() = %v, want timeout error", err)
+		}
+	})
+}
+
+// fakeHandoffTmux records respawns for handoffRemoteSession tests.
+type fakeHandoffTmux struct {
+	fakePaneWatcher
+	respawned  []string
+	commands   []string
+	env        map[string]string
+	waitErr    error
+	paneDir    string              // Returned by GetPaneWorkDir
+	workDirs   []string            // Working directory of each respawn ("" = unchanged)
+	envs       []map[string]string // Env passed to each respawn
+	setEnv     map[string]string   // Session env written via SetEnvironment
+	respawnErr error               // Returned by every respawn
+}
+
+func (f *fakeHandoffTmux) GetEnvironment(_, key string) (string, error) { return f.env[key], nil }
+func (f *fakeHandoffTmux) SetEnvironment(_, key, value string) error {
+	if f.setEnv == nil {
+		f.setEnv = make(map[string]string)
+	}
+	f.setEnv[key] = value
+	return nil
+}
+
+func (f *fakeHandoffTmux) WaitForSession(string, time.Duration) error { return f.waitErr }
+func (f *fakeHandoffTmux) SetRemainOnExit(string, bool) error         { return nil }
+func (f *fakeHandoffTmux) KillPaneProcesses(string) error             { return nil }
+func (f *fakeHandoffTmux) ClearHistory(string) error                  { return nil }
+func (f *fakeHandoffTmux) GetPaneWorkDir(string) (string, error)      { return f.paneDir, nil }
+func (f *fakeHandoffTmux) SessionEnv(_, name string) (string, error)  { return f.env[name], nil }
+func (f *fakeHandoffTmux) RespawnPaneWithWorkDirAndEnv(pane, workDir, command string, env map[string]string) error {
+	f.workDirs = append(f.workDirs, workDir)
+	f.envs = append(f.envs, env)
+	return f.respawn(pane, command)
+}
+func (f *fakeHandoffTmux) RespawnPaneWithEnv(pane, command string, env map[string]string) error {
+	f.workDirs = append(f.workDirs, "")
+	f.envs = append(f.envs, env)
+	return f.respawn(pane, command)
+}
+func (f *fakeHandoffTmux) RespawnPane(pane, command string) error {
+	f.workDirs = append(f.workDirs, "")
+	f.envs = append(f.envs, nil)
+	return f.respawn(pane, command)
+}
+func (f *fakeHandoffTmux) respawn(pane, command string) error {
+	f.respawned = append(f.respawned, pane)
+	f.commands = append(f.commands, command)
+	return f.respawnErr
+}
+
+func TestHandoffRemoteSession_DeclinedConfirmation(t *testing.T) {
+	origIn, origYes, origDry := handoffConfirmIn, handoffYes, handoffDryRun
+	t.Cleanup(func() { handoffConfirmIn, handoffYes, handoffDryRun = origIn, origYes, origDry })
+	handoffYes = false
+	handoffDryRun = false
+
+	for _, input := range []string{"n\n", "\n", ""} {
+		handoffConfirmIn = strings.NewReader(input)
+		fake := &fakeHandoffTmux{}
+		err := handoffRemoteSession(fake, "hq-mayor", "exec claude", remoteHandoffOpts{})
+		if err == nil || !strings.Contains(err.Error(), "cancelled") {
+			t.Fatalf("input %q: handoffRemoteSession() = %v, want a cancelled error", input, err)
+		}
+		if len(fake.respawned) != 0 {
+			t.Errorf("input %q: RespawnPane called %v, want no respawn", input, fake.respawned)
+		}
+	}
+}
+
+func TestConfirmRemoteHandoff(t *testing.T) {
+	origIn, origYes, origDry := handoffConfirmIn, handoffYes, handoffDryRun
+	t.Cleanup(func() { handoffConfirmIn, handoffYes, handoffDryRun = origIn, origYes, origDry })
+	handoffYes = false
+	handoffDryRun = false
+
+	handoffConfirmIn = strings.NewReader("y\n")
+	if err := confirmRemoteHandoff("hq-mayor"); err != nil {
+		t.Errorf("confirmed: confirmRemoteHandoff() = %v, want nil", err)
+	}
+	handoffConfirmIn = strings.NewReader("n\n")
+	if err := confirmRemoteHandoff("hq-mayor"); err == nil {
+		t.Error("declined: confirmRemoteHandoff() = nil, want a cancelled error")
+	}
+
+	// Declining a bulk handoff fails the same way as declining one session.
+	handoffConfirmIn = strings.NewReader("n\n")
+	err := confirmRemoteHandoff("hq-mayor", "hq-deacon")
+	if err == nil || err.Error() != "handoff of hq-mayor, hq-deacon cancelled" {
+		t.Errorf("declined bulk: confirmRemoteHandoff() = %v, want a cancelled error", err)
+	}
+
+	handoffYes = true
+	handoffConfirmIn = strings.NewReader("")
+	if err := confirmRemoteHandoff("hq-mayor"); err != nil {
+		t.Errorf("--yes: confirmRemoteHandoff() = %v, want nil without prompting", err)
+	}
+}
+
+func TestHandoffRemoteSession_SessionNotFound(t *testing.T) {
+	origYes, origDry := handoffYes, handoffDryRun
+	t.Cleanup(func() { handoffYes, handoffDryRun = origYes, origDry })
+	handoffYes = true
+	handoffDryRun = false
+
+	fake := &fakeHandoffTmux{waitErr: tmux.ErrSessionNotFound}
+	err := handoffRemoteSession(fake, "gt-witness", "exec claude", remoteHandoffOpts{})
+	if !errors.Is(err, tmux.ErrSessionNotFound) {
+		t.Fatalf("handoffRemoteSession() = %v, want ErrSessionNotFound", err)
+	}
+	if !strings.Contains(err.Error(), "gt-witness") {
+		t.Errorf("error %q should name the session", err)
+	}
+	if len(fake.respawned) != 0 {
+		t.Errorf("RespawnPane called %v for a missing session", fake.respawned)
+	}
+}
+
+func TestSessionStartHint(t *testing.T) {
+	setupHandoffTestRegistry(t)
+	tests := map[string]string{
+		"hq-mayor":       "gt mayor start",
+		"hq-deacon":      "gt deacon start",
+		"hq-boot":        "gt boot spawn",
+		"gt-witness":     "gt witness start gastown",
+		"gt-refinery":    "gt refinery start gastown",
+		"gt-crew-holden": "gt crew start gastown holden",
+		"gt-Toast":       "",
+		"scratch":        "",
+	}
+	for sessionName, want := range tests {
+		if got := sessionStartHint(sessionName); got != want {
+			t.Errorf("sessionStartHint(%q) = %q, want %q", sessionName, got, want)
+		}
+	}
+}
+
+func TestHandoffRemoteSession_TmuxTimeout(t *testing.T) {
+	origYes, origDry, origTimeout, origExec := handoffYes, handoffDryRun, handoffTimeout, handoffExecCommand
+	t.Cleanup(func() {
+		handoffYes, handoffDryRun, handoffTimeout, handoffExecCommand = origYes, origDry, origTimeout, origExec
+	})
+	handoffYes = true
+	handoffDryRun = false
+	handoffTimeout = 100 * time.Millisecond
+
+	// A wedged tmux server: every tmux call blocks until killed.
+	var calls [][]string
+	handoffExecCommand = func(ctx context.Context, name string, args ...string) *exec.Cmd {
+		calls = append(calls, append([]string{name}, args...))
+		return exec.CommandContext(ctx, "sleep", "30")
+	}
+
+	fake := &fakeHandoffTmux{}
+	start := time.Now()
+	err := handoffRemoteSession(fake, "hq-mayor", "exec claude", remoteHandoffOpts{})
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Fatalf("handoffRemoteSession took %v, want the --timeout to cut it short", elapsed)
+	}
+	if err == nil || !strings.Contains(err.Error(), "timed out after 100ms") {
+		t.Fatalf("handoffRemoteSession() = %v, want timeout error", err)
+	}
+	if len(calls) != 1 || calls[0][1] != "list-panes" {
+		t.Errorf("tmux calls = %v, want just the pane lookup", calls)
+	}
+	if len(fake.respawned) != 0 {
+		t.Errorf("RespawnPane called %v after the pane lookup timed out", fake.respawned)
+	}
+}
+
+func TestPaneQueries_TmuxTimeout(t *testing.T) {
+	origTimeout, origExec := handoffTimeout, handoffExecCommand
+	t.Cleanup(func() { handoffTimeout, handoffExecCommand = origTimeout, origExec })
+	handoffTimeout = 100 * time.Millisecond
+	handoffExecCommand = func(ctx context.Context, _ string, _ ...string) *exec.Cmd {
+		return exec.CommandContext(ctx, "sleep", "30")
+	}
+
+	queries := map[string]func() error{
+		"paneCurrentCommand": func() error { _, err := paneCurrentCommand("%1"); return err },
+		"getSessionPane":     func() error { _, err := getSessionPane("hq-mayor"); return err },
+	}
+	for name, query := range queries {
+		start := time.Now()
+		err := query()
+		if elapsed := time.Since(start); elapsed > 5*time.Second {
+			t.Errorf("%s took %v, want the --timeout to cut it short", name, elapsed)
+		}
+		if err == nil || !strings.Contains(err.Error(), "timed out after 100ms") {
+			t.Errorf("%s() = %v, want timeout error", name, err)
+		}
+	}
+}
+
+func TestHandoffRemoteSession_CmdOverride(t *testing.T) {
+	origYes, origDry, origWatch, origWait := handoffYes, handoffDryRun, handoffWatch, handoffWait
+	origCmd, origKeep, origExec, origPane := handoffRestartCmd, handoffKeepAlive, handoffExecCommand, paneCurrentCommand
+	t.Cleanup(func() {
+		handoffYes, handoffDryRun, handoffWatch, handoffWait = origYes, origDry, origWatch, origWait
+		handoffRestartCmd, handoffKeepAlive, handoffExecCommand, paneCurrentCommand = origCmd, origKeep, origExec, origPane
+	})
+	t.Chdir(t.TempDir()) // keep the handoff audit log out of any real town
+	handoffYes, handoffDryRun, handoffWatch, handoffWait, handoffKeepAlive = true, false, false, false, false
+	handoffExecCommand = fakeHandoffPaneLookup("%7", "hq-no-such-role")
+	paneCurrentCommand = func(string) (string, error) { return "bash", nil }
+
+	override := `cd /tmp && exec claude --model "opus" 'resume'`
+	handoffRestartCmd = override
+	restartCmd, err := resolveRestartCommand("hq-no-such-role")
+	if err != nil {
+		t.Fatalf("resolveRestartCommand() = %v, want the override without a registered builder", err)
+	}
+
+	fake := &fakeHandoffTmux{}
+	if err := handoffRemoteSession(fake, "hq-no-such-role", restartCmd, remoteHandoffOpts{}); err != nil {
+		t.Fatalf("handoffRemoteSession() = %v", err)
+	}
+	if len(fake.respawned) != 1 || fake.respawned[0] != "%7" {
+		t.Fatalf("respawned panes = %v, want [%%7]", fake.respawned)
+	}
+	if fake.commands[0] != override {
+		t.Errorf("RespawnPane command = %q, want override %q verbatim", fake.commands[0], override)
+	}
+}
+
+func TestRunHandoff_CmdRejectedWithCycle(t *testing.T) {
+	origCmd, origCycle := handoffRestartCmd, handoffCycle
+	flag := handoffCmd.Flags().Lookup("cmd")
+	t.Cleanup(func() {
+		handoffRestartCmd, handoffCycle = origCmd, origCycle
+		flag.Changed = false
+	})
+	if err := handoffCmd.Flags().Set("cmd", "exec claude"); err != nil {
+		t.Fatal(err)
+	}
+	handoffCycle = true
+
+	err := runHandoff(handoffCmd, nil)
+	if err == nil || !strings.Contains(err.Error(), "--cycle") {
+		t.Fatalf("runHandoff() err = %v, want --cmd rejected with --cycle", err)
+	}
+}
+
+func TestHandoffRemoteSession_DeletedWorkDirKeepsIdentityEnv(t *testing.T) {
+	setupHandoffTestRegistry(t)
+	origYes, origDry, origWatch, origWait := handoffYes, handoffDryRun, handoffWatch, handoffWait
+	origExec, origPane := handoffExecCommand, paneCurrentCommand
+	t.Cleanup(func() {
+		handoffYes, handoffDryRun, handoffWatch, handoffWait = origYes, origDry, origWatch, origWait
+		handoffExecCommand, paneCurrentCommand = origExec, origPane
+	})
+	townRoot := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(townRoot, "mayor"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(townRoot, "mayor", "town.json"), []byte(`{"name":"test"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	t.Chdir(townRoot)
+	handoffYes, handoffDryRun, handoffWatch, handoffWait = true, false, false, false
+	handoffExecCommand = fakeHandoffPaneLookup("%4", "gt-crew-max")
+	paneCurrentCommand = func(string) (string, error) { return "bash", nil }
+
+	fake := &fakeHandoffTmux{paneDir: filepath.Join(townRoot, "deleted-worktree")}
+	if err := handoffRemoteSession(fake, "gt-crew-max", "exec claude", remoteHandoffOpts{}); err != nil {
+		t.Fatalf("handoffRemoteSession() = %v", err)
+	}
+	if len(fake.respawned) != 1 {
+		t.Fatalf("respawned panes = %v, want one respawn", fake.respawned)
+	}
+	if fake.workDirs[0] != townRoot {
+		t.Errorf("respawn workdir = %q, want town root %q", fake.workDirs[0], townRoot)
+	}
+	want := handoffIdentityEnv("gt-crew-max")
+	if !reflect.DeepEqual(fake.envs[0], want) {
+		t.Errorf("respawn env = %v, want identity env %v", fake.envs[0], want)
+	}
+}
+
+// fakeHandoffPaneLookup answers runHandoffTmux's pane lookups: list-panes
+// reports pane, and display-message reports paneSession as its session.
+func fakeHandoffPaneLookup(pane, paneSession string) func(context.Context, string, ...string) *exec.Cmd {
+	return func(ctx context.Context, _ string, args ...string) *exec.Cmd {
+		if len(args) > 0 && args[0] == "display-message" {
+			return exec.CommandContext(ctx, "echo", paneSession)
+		}
+		return exec.CommandContext(ctx, "echo", pane)
+	}
+}
+
+func TestHandoffRemoteSession_PaneInWrongSession(t *testing.T) {
+	origYes, origDry, origExec, origPane := handoffYes, handoffDryRun, handoffExecCommand, paneCurrentCommand
+	t.Cleanup(func() {
+		handoffYes, handoffDryRun, handoffExecCommand, paneCurrentCommand = origYes, origDry, origExec, origPane
+	})
+	t.Chdir(t.TempDir())
+	handoffYes, handoffDryRun = true, false
+	// The pane resolved for the crew session actually lives in the mayor's.
+	handoffExecCommand = fakeHandoffPaneLookup("%3", "hq-mayor")
+	paneCurrentCommand = func(string) (string, error) { return "bash", nil }
+
+	fake := &fakeHandoffTmux{}
+	err := handoffRemoteSession(fake, "gt-crew-holden", "exec claude", remoteHandoffOpts{})
+	if err == nil || !strings.Contains(err.Error(), `belongs to session "hq-mayor"`) {
+		t.Fatalf("handoffRemoteSession() = %v, want wrong-session error", err)
+	}
+	if len(fake.respawned) != 0 {
+		t.Errorf("RespawnPane called %v for a pane in another session", fake.respawned)
+	}
+}
+
+func TestHandoffRemoteSession_SessionEnvUntouchedUnlessRespawned(t *testing.T) {
+	origYes, origDry, origWatch, origWait, origForce := handoffYes, handoffDryRun, handoffWatch, handoffWait, handoffForce
+	origExec, origPane := handoffExecCommand, paneCurrentCommand
+	t.Cleanup(func() {
+		handoffYes, handoffDryRun, handoffWatch, handoffWait, handoffForce = origYes, origDry, origWatch, origWait, origForce
+		handoffExecCommand, paneCurrentCommand = origExec, origPane
+	})
+	t.Chdir(t.TempDir())
+	t.Setenv("GT_AGENT", "claude")
+	handoffYes, handoffWatch, handoffWait, handoffForce = true, false, false, false
+
+	tests := []struct {
+		name        string
+		dryRun      bool
+		paneSession string
+		paneCmd     string
+		waitErr     error
+		wantEnv     bool
+	}{
+		{name: "session missing", paneSession: "hq-mayor", paneCmd: "bash", waitErr: tmux.ErrSessionNotFound},
+		{name: "pane in another session", paneSession: "hq-deacon", paneCmd: "bash"},
+		{name: "pane busy", paneSession: "hq-mayor", paneCmd: "make"},
+		{name: "dry run", dryRun: true, paneSession: "hq-mayor", paneCmd: "bash"},
+		{name: "respawned", paneSession: "hq-mayor", paneCmd: "bash", wantEnv: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handoffDryRun = tt.dryRun
+			handoffExecCommand = fakeHandoffPaneLookup("%1", tt.paneSession)
+			paneCurrentCommand = func(string) (string, error) { return tt.paneCmd, nil }
+
+			fake := &fakeHandoffTmux{waitErr: tt.waitErr}
+			_ = handoffRemoteSession(fake, "hq-mayor", "exec claude", remoteHandoffOpts{})
+			if got := fake.setEnv["GT_AGENT"] != ""; got != tt.wantEnv {
+				t.Errorf("session env written = %v (%v), want %v", got, fake.setEnv, tt.wantEnv)
+			}
+		})
+	}
+}
+
+func TestConfirmHandoff(t *testing.T) {
+	tests := []struct {
+		input string
+		want  bool
+	}{
+		{"y\n", true},
+		{"YES\n", true},
+		{"n\n", false},
+		{"\n", false},
+		{"", false},
+		{"maybe\n", false},
+	}
+	for _, tt := range tests {
+		if got := confirmHandoff(strings.NewReader(tt.input), "Hand off?"); got != tt.want {
+			t.Errorf("confirmHandoff(%q) = %v, want %v", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestPaneIsBusy(t *testing.T) {
+	orig := paneCurrentCommand
+	t.Cleanup(func() { paneCurrentCommand = orig })
+
+	tests := []struct {
+		command string
+		want    bool
+	}{
+		{"bash", false},
+		{"zsh", false},
+		{"claude", false},
+		{"node", false},
+		{"", false},
+		{"make", true},
+		{"go", true},
+	}
+	for _, tt := range tests {
+		paneCurrentCommand = func(string) (string, error) { return tt.command, nil }
+		got, err := paneIsBusy("%1")
+		if err != nil {
+			t.Fatalf("paneIsBusy(%q) error: %v", tt.command, err)
+		}
+		if got != tt.want {
+			t.Errorf("paneIsBusy with command %q = %v, want %v", tt.command, got, tt.want)
+		}
+	}
+
+	paneCurrentCommand = func(string) (string, error) { return "", errors.New("no server") }
+	if _, err := paneIsBusy("%1"); err == nil {
+		t.Error("expected error when tmux query fails")
+	}
+}
+
+func TestHandoffMailMeta(t *testing.T) {
+	meta := handoffMailMeta{BeadID: "gt-abc12", FromAgent: "gastown/witness"}
+
+	// Thread ID is stable per bead, whoever sends the handoff.
+	other := handoffMailMeta{BeadID: "gt-abc12", FromAgent: "mayor/"}
+	if meta.ThreadID() != other.ThreadID() {
+		t.Errorf("ThreadID differs for the same bead: %q vs %q", meta.ThreadID(), other.ThreadID())
+	}
+	if meta.ThreadID() == (handoffMailMeta{BeadID: "gt-def34", FromAgent: "gastown/witness"}).ThreadID() {
+		t.Error("ThreadID should differ for different beads")
+	}
+
+	// Labels round-trip through the mail router's label parser.
+	bm := &mail.BeadsMessage{ID: "hq-mail1", Labels: meta.labels()}
+	msg := bm.ToMessage()
+	if msg.From != "gastown/witness" {
+		t.Errorf("From = %q, want %q", msg.From, "gastown/witness")
+	}
+	if msg.ThreadID != meta.ThreadID() {
+		t.Errorf("ThreadID = %q, want %q", msg.ThreadID, meta.ThreadID())
+	}
+	if !bm.HasLabel("bead:gt-abc12") {
+		t.Errorf("labels %v missing bead:gt-abc12", bm.Labels)
+	}
+
+	if got := meta.body("Context cycling."); got != "Context cycling.\n\nBead: gt-abc12" {
+		t.Errorf("body = %q, want plain-text bead trailer", got)
+	}
+	if got := (handoffMailMeta{FromAgent: "mayor/"}).body("Context cycling."); got != "Context cycling." {
+		t.Errorf("body without bead = %q, want message unchanged", got)
+	}
+}
+
+func TestSendHandoffMail_DryRunPrintsWithoutDelivering(t *testing.T) {
+	townRoot := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(townRoot, "mayor"), 0755); err != nil {
+		t.Fatalf("mkdir mayor: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(townRoot, "mayor", "town.json"), []byte(`{"name":"gastown"}`), 0644); err != nil {
+		t.Fatalf("write town.json: %v", err)
+	}
+	t.Chdir(filepath.Join(townRoot, "mayor"))
+	t.Setenv(EnvGTRole, "mayor")
+	t.Setenv("GT_TOWN_ROOT", "")
+	t.Setenv("GT_ROOT", "")
+
+	binDir := t.TempDir()
+	logPath := filepath.Join(t.TempDir(), "bd.log")
+	writeBDStub(t, binDir,
+		"#!/bin/sh\necho \"$*\" >> \"${BD_LOG}\"\n",
+		"@echo off\r\necho %*>>\"%BD_LOG%\"\r\n")
+	t.Setenv("BD_LOG", logPath)
+	t.Setenv("PATH", binDir+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	var beadID string
+	var err error
+	out := captureStdout(t, func() {
+		beadID, err = sendHandoffMailWithMeta("Fixing auth", "Tests still failing.", handoffMailMeta{BeadID: "gt-abc12"}, true)
+	})
+	if err != nil {
+		t.Fatalf("sendHandoffMailWithMeta: %v", err)
+	}
+	if beadID != "" {
+		t.Errorf("dry run returned bead ID %q, want none", beadID)
+	}
+	if _, statErr := os.Stat(logPath); statErr == nil {
+		data, _ := os.ReadFile(logPath)
+		t.Errorf("dry run invoked bd: %s", data)
+	}
+	for _, want := range []string{"Would send handoff mail", "🤝 HANDOFF: Fixing auth", "bead:gt-abc12", "| Tests still failing."} {
+		if !strings.Contains(out, want) {
+			t.Errorf("dry-run output missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestHandoffIdentityEnv(t *testing.T) {
+	setupHandoffTestRegistry(t)
+
+	tests := []struct {
+		session string
+		want    map[string]string
+	}{
+		{"gt-crew-max", map[string]string{"GT_ROLE": "gastown/crew/max", "GT_RIG": "gastown", "GT_CREW": "max"}},
+		{"gt-Toast", map[string]string{"GT_ROLE": "gastown/polecats/Toast", "GT_RIG": "gastown", "GT_POLECAT": "Toast"}},
+		{"gt-witness", map[string]string{"GT_ROLE": "gastown/witness", "GT_RIG": "gastown"}},
+		{"hq-boot", map[string]string{"GT_ROLE": "deacon/boot"}},
+	}
+	for _, tt := range tests {
+		got := handoffIdentityEnv(tt.session)
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("handoffIdentityEnv(%q) = %v, want %v", tt.session, got, tt.want)
+		}
+	}
+	if got := handoffIdentityEnv("not a session"); got != nil {
+		t.Errorf("handoffIdentityEnv(unparseable) = %v, want nil", got)
+	}
+}
+
+func TestBuildRestartCommand_KeepAlive(t *testing.T) {
+	saved, savedKeep := sessionRestarts, handoffKeepAlive
+	t.Cleanup(func() { sessionRestarts, handoffKeepAlive = saved, savedKeep })
+	RegisterSessionRestart(
+		func(name string) bool { return name == "hq-overseer" },
+		func(string) (string, error) { return "exec custom-agent", nil },
+	)
+
+	handoffKeepAlive = false
+	cmd, err := buildRestartCommand("hq-overseer")
+	if err != nil {
+		t.Fatalf("buildRestartCommand: %v", err)
+	}
+	if cmd != "exec custom-agent" {
+		t.Errorf("keep-alive should be off by default, got %q", cmd)
+	}
+
+	handoffKeepAlive = true
+	cmd, err = buildRestartCommand("hq-overseer")
+	if err != nil {
+		t.Fatalf("buildRestartCommand: %v", err)
+	}
+	for _, want := range []string{"for gt_try in 1 2", "( exec custom-agent )", "-ge 60", "respawning once"} {
+		if !strings.Contains(cmd, want) {
+			t.Errorf("keep-alive command %q missing %q", cmd, want)
+		}
+	}
+}
+
+func TestWrapKeepAlive_RetriesFastFailureOnce(t *testing.T) {
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("sh not available")
+	}
+	counter := filepath.Join(t.TempDir(), "runs")
+	run := func(status int) (int, int) {
+		_ = os.Remove(counter)
+		restart := fmt.Sprintf("echo run >> %s; exit %d", counter, status)
+		err := exec.Command("sh", "-c", wrapKeepAlive(restart, time.Minute)).Run()
+		code := 0
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			code = exitErr.ExitCode()
+		} else if err != nil {
+			t.Fatalf("running wrapper: %v", err)
+		}
+		data, _ := os.ReadFile(counter)
+		return strings.Count(string(data), "run"), code
+	}
+
+	if runs, code := run(3); runs != 2 || code != 3 {
+		t.Errorf("failing agent: ran %d time(s) with exit %d, want 2 runs and exit 3", runs, code)
+	}
+	if runs, code := run(0); runs != 1 || code != 0 {
+		t.Errorf("clean exit: ran %d time(s) with exit %d, want 1 run and exit 0", runs, code)
+	}
+}
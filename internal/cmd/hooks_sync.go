@@ -172,6 +172,11 @@ func syncTarget(target hooks.Target, dryRun bool) (syncResult, error) {
 	}
 	data = append(data, '\n')
 
+	// Keep a copy of the existing file in case it was hand-tuned
+	if _, err := hooks.BackupSettings(target.Path); err != nil {
+		return 0, err
+	}
+
 	if err := os.WriteFile(target.Path, data, 0644); err != nil {
 		return 0, fmt.Errorf("writing settings: %w", err)
 	}
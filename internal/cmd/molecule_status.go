@@ -512,24 +512,11 @@ func runMoleculeStatus(cmd *cobra.Command, args []string) error {
 // Town-level agents (mayor, deacon) use trailing slash to match the format
 // used when setting assignee on hooked beads (see resolveSelfTarget in sling.go).
 func buildAgentIdentity(ctx RoleContext) string {
-	switch ctx.Role {
-	case RoleMayor:
-		return "mayor/"
-	case RoleDeacon:
-		return "deacon/"
-	case RoleBoot:
-		return "deacon/boot"
-	case RoleWitness:
-		return ctx.Rig + "/witness"
-	case RoleRefinery:
-		return ctx.Rig + "/refinery"
-	case RolePolecat:
-		return ctx.Rig + "/polecats/" + ctx.Polecat
-	case RoleCrew:
-		return ctx.Rig + "/crew/" + ctx.Polecat
-	default:
+	identity, err := agentIdentityFromRole(ctx)
+	if err != nil {
 		return ""
 	}
+	return selfAgentID(identity)
 }
 
 // getMoleculeProgressInfo gets progress info for a molecule instance.
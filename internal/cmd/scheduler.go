@@ -7,6 +7,7 @@ import (
 	"os/exec"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/steveyegge/gastown/internal/beads"
@@ -386,6 +387,7 @@ func listScheduledBeads(townRoot string) ([]scheduledBeadInfo, error) {
 	readyWorkIDs := listReadyWorkBeadIDs(townRoot)
 	workBeadInfo := batchFetchBeadInfoByIDs(townRoot, workBeadIDs)
 
+	now := time.Now()
 	seenWork := make(map[string]bool)
 	var result []scheduledBeadInfo
 	for _, ctx := range allContexts {
@@ -394,8 +396,8 @@ func listScheduledBeads(townRoot string) ([]scheduledBeadInfo, error) {
 			continue
 		}
 
-		// Exclude circuit-broken
-		if fields.DispatchFailures >= maxDispatchFailures {
+		// Exclude circuit-broken and expired
+		if fields.DispatchFailures >= maxDispatchFailures || fields.Expired(now) {
 			continue
 		}
 
@@ -1,12 +1,17 @@
 package cmd
 
 import (
+	"encoding/json"
 	"fmt"
+	"os"
 	"path/filepath"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/steveyegge/gastown/internal/beads"
 	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/util"
 	"github.com/steveyegge/gastown/internal/workspace"
 )
 
@@ -17,86 +22,308 @@ type convoyScheduleOpts struct {
 	Force       bool
 	DryRun      bool
 	NoBoot      bool
+
+	// InterleaveRigs round-robins candidates across target rigs so that a
+	// convoy skewed toward one rig doesn't front-load that rig's whole batch.
+	InterleaveRigs bool
+
+	// Labels restricts dispatch to tracked issues carrying every listed label.
+	Labels []string
+
+	// Max caps how many issues are scheduled in this invocation (0 = no limit).
+	// Candidates past the cap are left for a later run.
+	Max int
+
+	// After restricts dispatch to tracked issues updated after this time
+	// (zero = no filter), so periodic re-runs only pick up recent changes.
+	After time.Time
+
+	// Rig, when set, sends every candidate to this rig instead of resolving
+	// each issue's rig from its prefix.
+	Rig string
+
+	// TTL drops each scheduled issue if it is not dispatched within this long
+	// (0 = never). Only scheduling honors it.
+	TTL time.Duration
 }
 
-// runConvoyScheduleByID schedules all open tracked issues of a convoy.
-func runConvoyScheduleByID(convoyID string, opts convoyScheduleOpts) error {
-	townRoot, err := workspace.FindFromCwdOrError()
-	if err != nil {
-		return err
+// convoyCandidate is a tracked convoy issue selected for dispatch.
+type convoyCandidate struct {
+	ID       string
+	Title    string
+	RigName  string
+	Priority int
+	Formula  string // Formula to apply; from a formula: label or the command default
+
+	// Blocked is informational: blocked candidates are still scheduled and
+	// the scheduler dispatches them once their blockers close.
+	Blocked bool
+}
+
+// scheduleNote annotates a candidate in schedule output.
+func (c convoyCandidate) scheduleNote() string {
+	if c.Blocked {
+		return " (blocked, will auto-dispatch)"
 	}
+	return ""
+}
 
-	if err := verifyBeadExists(convoyID); err != nil {
-		return fmt.Errorf("convoy '%s' not found", convoyID)
+// formulaNote annotates a candidate whose formula differs from the default.
+func (c convoyCandidate) formulaNote(def string) string {
+	if c.Formula == def {
+		return ""
 	}
+	return " [formula: " + formulaDisplay(c.Formula) + "]"
+}
 
-	townBeads := filepath.Join(townRoot, ".beads")
-	tracked, err := getTrackedIssues(townBeads, convoyID)
-	if err != nil {
-		return fmt.Errorf("getting tracked issues: %w", err)
+// lowestBeadPriority is used for candidates whose priority can't be read, so
+// they dispatch after everything with a known priority.
+const lowestBeadPriority = 4
+
+// parseUpdatedAfter parses an --after value: either a duration back from now
+// (e.g. 6h, 2d) or a timestamp (RFC3339 or a date).
+func parseUpdatedAfter(s string, now time.Time) (time.Time, error) {
+	if d, err := parseDuration(s); err == nil {
+		return now.Add(-d), nil
 	}
+	if t := parseBeadsTimestamp(s); !t.IsZero() {
+		return t, nil
+	}
+	return time.Time{}, fmt.Errorf("invalid --after %q: want a duration (e.g. 6h, 2d) or a timestamp (e.g. 2006-01-02T15:04:05Z)", s)
+}
 
-	if len(tracked) == 0 {
-		fmt.Printf("Convoy %s has no tracked issues.\n", convoyID)
-		return nil
+// filterUpdatedAfter drops open tracked issues last updated at or before
+// cutoff, returning the rest and how many were dropped. Update times come from
+// the getTrackedIssues batch query. Closed issues pass through untouched (the
+// closed check skips them), as do issues whose update time is unknown, since
+// the remaining skip checks still guard against double dispatch.
+func filterUpdatedAfter(tracked []trackedIssueInfo, cutoff time.Time) ([]trackedIssueInfo, int) {
+	if cutoff.IsZero() {
+		return tracked, 0
 	}
+	kept := make([]trackedIssueInfo, 0, len(tracked))
+	skipped := 0
+	for _, t := range tracked {
+		if t.Status != "closed" && t.Status != "tombstone" {
+			if !t.UpdatedAt.IsZero() && !t.UpdatedAt.After(cutoff) {
+				skipped++
+				continue
+			}
+		}
+		kept = append(kept, t)
+	}
+	return kept, skipped
+}
 
-	type scheduleCandidate struct {
-		ID      string
-		Title   string
-		RigName string
+// sortByPriority orders candidates highest priority first (P0 before P1),
+// breaking ties by ID so dispatch order is stable across runs.
+func sortByPriority(candidates []convoyCandidate) {
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].Priority != candidates[j].Priority {
+			return candidates[i].Priority < candidates[j].Priority
+		}
+		return candidates[i].ID < candidates[j].ID
+	})
+}
+
+// interleaveByRig reorders candidates round-robin across rigs: the first
+// candidate of each rig, then the second of each rig, and so on. Rigs are
+// visited in order of first appearance and each rig's candidates keep their
+// relative order, so the result is deterministic for a given input.
+func interleaveByRig(candidates []convoyCandidate) []convoyCandidate {
+	var rigOrder []string
+	byRig := make(map[string][]convoyCandidate)
+	for _, c := range candidates {
+		if _, seen := byRig[c.RigName]; !seen {
+			rigOrder = append(rigOrder, c.RigName)
+		}
+		byRig[c.RigName] = append(byRig[c.RigName], c)
 	}
-	var candidates []scheduleCandidate
-	skippedClosed := 0
-	skippedAssigned := 0
-	skippedScheduled := 0
-	skippedNoRig := 0
 
-	// Batch-check scheduling status for all tracked issues (single DB query).
-	var beadIDs []string
-	for _, t := range tracked {
-		beadIDs = append(beadIDs, t.ID)
+	result := make([]convoyCandidate, 0, len(candidates))
+	for round := 0; len(result) < len(candidates); round++ {
+		for _, rig := range rigOrder {
+			if round < len(byRig[rig]) {
+				result = append(result, byRig[rig][round])
+			}
+		}
+	}
+	return result
+}
+
+// formulaLabelPrefix marks a tracked issue's formula override, e.g.
+// "formula:mol-docs-work".
+const formulaLabelPrefix = "formula:"
+
+// formulaExists reports whether a formula can be resolved.
+// Replaceable in tests.
+var formulaExists = func(name string) bool {
+	return verifyFormulaExists(name) == nil
+}
+
+// candidateFormula returns the formula for a tracked issue: the value of its
+// formula: label, or def when there is no label, the value is empty, or the
+// formula doesn't exist. With --hook-raw-bead, labels are ignored.
+func candidateFormula(beadID string, labels []string, def string, hookRawBead bool) string {
+	if hookRawBead {
+		return def
 	}
-	scheduledSet := areScheduled(beadIDs)
+	for _, l := range labels {
+		name, ok := strings.CutPrefix(l, formulaLabelPrefix)
+		if !ok {
+			continue
+		}
+		name = strings.TrimSpace(name)
+		if name == "" {
+			return def
+		}
+		if !formulaExists(name) {
+			fmt.Printf("  %s %s: formula %q from label not found, using %s\n",
+				style.Dim.Render("○"), beadID, name, formulaDisplay(def))
+			return def
+		}
+		return name
+	}
+	return def
+}
+
+// formulaDisplay names a formula for output, including the no-formula case.
+func formulaDisplay(formula string) string {
+	if formula == "" {
+		return "no formula"
+	}
+	return formula
+}
+
+// hasAllLabels reports whether labels contains every entry in required.
+func hasAllLabels(labels, required []string) bool {
+	for _, want := range required {
+		found := false
+		for _, l := range labels {
+			if l == want {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// rigResolver returns the rig resolver for a dispatch run. With Rig set,
+// every issue resolves to it and prefix-based resolution is skipped.
+func (o convoyScheduleOpts) rigResolver(townRoot string) *rigResolver {
+	rigs := newRigResolver(townRoot)
+	if o.Rig != "" {
+		style.PrintWarning("--rig %s: sending every issue there; rig auto-resolution from bead prefixes is disabled", o.Rig)
+		rigs.forced = o.Rig
+	}
+	return rigs
+}
+
+// convoyScheduleSkips counts tracked issues a schedule run left out, by reason.
+type convoyScheduleSkips struct {
+	closed, assigned, scheduled, noRig, label int
+}
+
+// selectConvoyScheduleCandidates picks the tracked issues to schedule: open,
+// carrying opts.Labels, unassigned (unless forced), not already scheduled,
+// and in a resolvable rig.
+func selectConvoyScheduleCandidates(tracked []trackedIssueInfo, scheduledSet map[string]bool, rigs *rigResolver, opts convoyScheduleOpts) ([]convoyCandidate, convoyScheduleSkips) {
+	var candidates []convoyCandidate
+	var skips convoyScheduleSkips
 
 	for _, t := range tracked {
 		if t.Status == "closed" || t.Status == "tombstone" {
-			skippedClosed++
+			skips.closed++
+			continue
+		}
+
+		if !hasAllLabels(t.Labels, opts.Labels) {
+			skips.label++
 			continue
 		}
 
 		if t.Assignee != "" && !opts.Force {
-			skippedAssigned++
+			skips.assigned++
 			continue
 		}
 
 		if scheduledSet[t.ID] {
-			skippedScheduled++
+			skips.scheduled++
 			continue
 		}
 
-		rigName := resolveRigForBead(townRoot, t.ID)
+		rigName := rigs.resolve(t.ID)
 		if rigName == "" {
-			skippedNoRig++
+			skips.noRig++
 			prefix := beads.ExtractPrefix(t.ID)
 			fmt.Printf("  %s %s: cannot resolve rig from prefix %q (town-root or unknown)\n",
 				style.Dim.Render("○"), t.ID, prefix)
 			continue
 		}
 
-		candidates = append(candidates, scheduleCandidate{ID: t.ID, Title: t.Title, RigName: rigName})
+		candidates = append(candidates, convoyCandidate{
+			ID:       t.ID,
+			Title:    t.Title,
+			RigName:  rigName,
+			Priority: t.Priority,
+			Formula:  candidateFormula(t.ID, t.Labels, opts.Formula, opts.HookRawBead),
+			Blocked:  t.Blocked,
+		})
+	}
+	return candidates, skips
+}
+
+// runConvoyScheduleByID schedules all open tracked issues of a convoy.
+func runConvoyScheduleByID(convoyID string, opts convoyScheduleOpts) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return err
+	}
+
+	if err := verifyBeadExists(convoyID); err != nil {
+		return fmt.Errorf("convoy '%s' not found", convoyID)
+	}
+
+	townBeads := filepath.Join(townRoot, ".beads")
+	tracked, err := getTrackedIssues(townBeads, convoyID)
+	if err != nil {
+		return fmt.Errorf("getting tracked issues: %w", err)
+	}
+
+	if len(tracked) == 0 {
+		fmt.Printf("Convoy %s has no tracked issues.\n", convoyID)
+		return nil
 	}
+	tracked, skippedStale := filterUpdatedAfter(tracked, opts.After)
+
+	// Batch-check scheduling status for all tracked issues (single DB query).
+	var beadIDs []string
+	for _, t := range tracked {
+		beadIDs = append(beadIDs, t.ID)
+	}
+	candidates, skips := selectConvoyScheduleCandidates(tracked, areScheduled(beadIDs), opts.rigResolver(townRoot), opts)
 
 	if len(candidates) == 0 {
 		fmt.Printf("No issues to schedule from convoy %s", convoyID)
-		if skippedClosed > 0 || skippedAssigned > 0 || skippedScheduled > 0 || skippedNoRig > 0 {
+		if skips.closed > 0 || skips.assigned > 0 || skips.scheduled > 0 || skips.noRig > 0 {
 			fmt.Printf(" (%d closed, %d assigned, %d already scheduled, %d no rig)",
-				skippedClosed, skippedAssigned, skippedScheduled, skippedNoRig)
+				skips.closed, skips.assigned, skips.scheduled, skips.noRig)
 		}
 		fmt.Println()
+		printLabelSkips(skips.label, opts.Labels, "  ")
+		printUpdatedSkips(skippedStale, opts.After, "  ")
 		return nil
 	}
 
+	sortByPriority(candidates)
+	if opts.InterleaveRigs {
+		candidates = interleaveByRig(candidates)
+	}
+
 	formula := opts.Formula
 
 	if opts.DryRun {
@@ -107,47 +334,233 @@ func runConvoyScheduleByID(convoyID string, opts convoyScheduleOpts) error {
 		} else {
 			fmt.Printf("  Hook raw beads (no formula)\n")
 		}
-		for _, c := range candidates {
-			fmt.Printf("  Would schedule: %s -> %s (%s)\n", c.ID, c.RigName, c.Title)
+		for i, c := range candidates {
+			if opts.Max > 0 && i >= opts.Max {
+				fmt.Printf("  Would defer %d issue(s) past --max %d\n", len(candidates)-i, opts.Max)
+				break
+			}
+			fmt.Printf("  Would schedule: %s [P%d] -> %s (%s)%s%s\n", c.ID, c.Priority, c.RigName, c.Title, c.formulaNote(formula), c.scheduleNote())
 		}
-		if skippedClosed > 0 || skippedAssigned > 0 || skippedScheduled > 0 || skippedNoRig > 0 {
+		if skips.closed > 0 || skips.assigned > 0 || skips.scheduled > 0 || skips.noRig > 0 {
 			fmt.Printf("\nSkipped: %d closed, %d assigned, %d already scheduled, %d no rig\n",
-				skippedClosed, skippedAssigned, skippedScheduled, skippedNoRig)
+				skips.closed, skips.assigned, skips.scheduled, skips.noRig)
 		}
+		printLabelSkips(skips.label, opts.Labels, "")
+		printUpdatedSkips(skippedStale, opts.After, "")
 		return nil
 	}
 
 	fmt.Printf("%s Scheduling %d issue(s) from convoy %s...\n",
 		style.Bold.Render("📋"), len(candidates), convoyID)
 
-	successCount := 0
-	for _, c := range candidates {
-		err := scheduleBead(c.ID, c.RigName, ScheduleOptions{
-			Formula:     formula,
+	manifest := newConvoyScheduleManifest(convoyID, time.Now())
+	successCount, deferred := scheduleConvoyCandidates(candidates, opts.Max, manifest.record(func(c convoyCandidate) error {
+		return scheduleBead(c.ID, c.RigName, ScheduleOptions{
+			Formula:     c.Formula,
 			NoConvoy:    true, // Already tracked by this convoy
 			Force:       opts.Force,
 			HookRawBead: opts.HookRawBead,
+			TTL:         opts.TTL,
 		})
-		if err != nil {
+	}))
+
+	// Best-effort: the manifest is a record for later inspection, not state.
+	if path, err := manifest.write(townRoot); err != nil {
+		style.PrintWarning("could not write schedule manifest: %v", err)
+	} else {
+		fmt.Printf("  Manifest: %s\n", path)
+	}
+
+	fmt.Printf("\n%s Scheduled %d/%d issue(s) from convoy %s\n",
+		style.Bold.Render("📊"), successCount, len(candidates)-deferred, convoyID)
+	if deferred > 0 {
+		fmt.Printf("  Deferred: %d (--max %d reached; run again to schedule more)\n", deferred, opts.Max)
+	}
+	if skips.closed > 0 || skips.assigned > 0 || skips.scheduled > 0 || skips.noRig > 0 {
+		fmt.Printf("  Skipped: %d closed, %d assigned, %d already scheduled, %d no rig\n",
+			skips.closed, skips.assigned, skips.scheduled, skips.noRig)
+	}
+	printLabelSkips(skips.label, opts.Labels, "  ")
+	printUpdatedSkips(skippedStale, opts.After, "  ")
+
+	if successCount == 0 {
+		return fmt.Errorf("all %d schedule attempts failed for convoy %s", len(candidates)-deferred, convoyID)
+	}
+	return nil
+}
+
+// scheduleConvoyCandidates schedules candidates in order until max have been
+// scheduled successfully (0 = no limit). Failed candidates don't count toward
+// max. Returns the number scheduled and the number left unattempted.
+func scheduleConvoyCandidates(candidates []convoyCandidate, max int, schedule func(convoyCandidate) error) (scheduled, deferred int) {
+	for i, c := range candidates {
+		if max > 0 && scheduled >= max {
+			return scheduled, len(candidates) - i
+		}
+		if err := schedule(c); err != nil {
 			fmt.Printf("  %s %s: %v\n", style.Dim.Render("✗"), c.ID, err)
 			continue
 		}
-		successCount++
+		if note := c.scheduleNote(); note != "" {
+			fmt.Printf("  %s %s%s\n", style.Dim.Render("○"), c.ID, note)
+		}
+		scheduled++
 	}
+	return scheduled, 0
+}
 
-	fmt.Printf("\n%s Scheduled %d/%d issue(s) from convoy %s\n",
-		style.Bold.Render("📊"), successCount, len(candidates), convoyID)
-	if skippedClosed > 0 || skippedAssigned > 0 || skippedScheduled > 0 || skippedNoRig > 0 {
-		fmt.Printf("  Skipped: %d closed, %d assigned, %d already scheduled, %d no rig\n",
-			skippedClosed, skippedAssigned, skippedScheduled, skippedNoRig)
+// convoyScheduleManifest records what one convoy schedule run attempted.
+// Written to <townRoot>/.runtime/convoys/<convoy-id>-schedule-<timestamp>.json.
+type convoyScheduleManifest struct {
+	ConvoyID    string                       `json:"convoy_id"`
+	ScheduledAt time.Time                    `json:"scheduled_at"`
+	Entries     []convoyScheduleManifestItem `json:"entries"`
+}
+
+// convoyScheduleManifestItem is one attempted candidate in a manifest.
+type convoyScheduleManifestItem struct {
+	BeadID  string `json:"bead_id"`
+	Rig     string `json:"rig"`
+	Formula string `json:"formula,omitempty"`
+	Success bool   `json:"success"`
+	Error   string `json:"error,omitempty"`
+}
+
+func newConvoyScheduleManifest(convoyID string, now time.Time) *convoyScheduleManifest {
+	return &convoyScheduleManifest{ConvoyID: convoyID, ScheduledAt: now.UTC(), Entries: []convoyScheduleManifestItem{}}
+}
+
+// record wraps schedule so each attempt is added to the manifest.
+func (m *convoyScheduleManifest) record(schedule func(convoyCandidate) error) func(convoyCandidate) error {
+	return func(c convoyCandidate) error {
+		err := schedule(c)
+		item := convoyScheduleManifestItem{BeadID: c.ID, Rig: c.RigName, Formula: c.Formula, Success: err == nil}
+		if err != nil {
+			item.Error = err.Error()
+		}
+		m.Entries = append(m.Entries, item)
+		return err
+	}
+}
+
+// write saves the manifest under townRoot and returns its path.
+func (m *convoyScheduleManifest) write(townRoot string) (string, error) {
+	name := fmt.Sprintf("%s-schedule-%s.json", m.ConvoyID, m.ScheduledAt.Format("20060102T150405Z"))
+	path := filepath.Join(townRoot, ".runtime", "convoys", name)
+	if err := util.EnsureDirAndWriteJSON(path, m); err != nil {
+		return "", err
 	}
+	return path, nil
+}
 
+// Retry policy for --requeue-failed attempts, which exist to get past
+// transient Dolt failures.
+const (
+	requeueRetryAttempts = 3
+	requeueRetryBackoff  = 500 * time.Millisecond
+)
+
+// runConvoyRequeueFailed re-schedules the issues that failed in the most
+// recent schedule run of a convoy, as recorded in its manifest. Each attempt
+// retries transient Dolt errors. A new manifest is written for this run.
+func runConvoyRequeueFailed(convoyID string, opts convoyScheduleOpts) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return err
+	}
+
+	prev, prevPath, err := latestConvoyScheduleManifest(townRoot, convoyID)
+	if err != nil {
+		return err
+	}
+	failed := prev.failedCandidates()
+	if len(failed) == 0 {
+		fmt.Printf("%s No failed issues in %s\n", style.Dim.Render("○"), prevPath)
+		return nil
+	}
+
+	if opts.DryRun {
+		fmt.Printf("%s Would requeue %d failed issue(s) from %s:\n",
+			style.Bold.Render("📋"), len(failed), prevPath)
+		for _, c := range failed {
+			fmt.Printf("  Would schedule: %s → %s (%s)\n", c.ID, c.RigName, formulaDisplay(c.Formula))
+		}
+		return nil
+	}
+
+	fmt.Printf("%s Requeueing %d failed issue(s) from convoy %s...\n",
+		style.Bold.Render("📋"), len(failed), convoyID)
+
+	manifest, successCount := requeueConvoyFailures(convoyID, failed, func(c convoyCandidate) error {
+		return scheduleBead(c.ID, c.RigName, ScheduleOptions{
+			Formula:     c.Formula,
+			NoConvoy:    true, // Already tracked by this convoy
+			Force:       opts.Force,
+			HookRawBead: opts.HookRawBead,
+			TTL:         opts.TTL,
+		})
+	})
+	if path, err := manifest.write(townRoot); err != nil {
+		style.PrintWarning("could not write schedule manifest: %v", err)
+	} else {
+		fmt.Printf("  Manifest: %s\n", path)
+	}
+
+	fmt.Printf("\n%s Requeued %d/%d issue(s) from convoy %s\n",
+		style.Bold.Render("📊"), successCount, len(failed), convoyID)
 	if successCount == 0 {
-		return fmt.Errorf("all %d schedule attempts failed for convoy %s", len(candidates), convoyID)
+		return fmt.Errorf("all %d requeue attempts failed for convoy %s", len(failed), convoyID)
 	}
 	return nil
 }
 
+// requeueConvoyFailures schedules each failed candidate, retrying transient
+// Dolt errors, and records the attempts in a fresh manifest.
+func requeueConvoyFailures(convoyID string, failed []convoyCandidate, schedule func(convoyCandidate) error) (*convoyScheduleManifest, int) {
+	manifest := newConvoyScheduleManifest(convoyID, time.Now())
+	scheduled, _ := scheduleConvoyCandidates(failed, 0, manifest.record(func(c convoyCandidate) error {
+		return beads.WithRetry(func() error { return schedule(c) }, requeueRetryAttempts, requeueRetryBackoff)
+	}))
+	return manifest, scheduled
+}
+
+// latestConvoyScheduleManifest loads the most recent schedule manifest
+// written for convoyID and returns it with its path.
+func latestConvoyScheduleManifest(townRoot, convoyID string) (*convoyScheduleManifest, string, error) {
+	pattern := filepath.Join(townRoot, ".runtime", "convoys", convoyID+"-schedule-*.json")
+	paths, err := filepath.Glob(pattern)
+	if err != nil {
+		return nil, "", fmt.Errorf("listing schedule manifests: %w", err)
+	}
+	if len(paths) == 0 {
+		return nil, "", fmt.Errorf("no schedule manifest found for convoy %s (run gt sling %s first)", convoyID, convoyID)
+	}
+	// Timestamps in the names sort chronologically.
+	sort.Strings(paths)
+	path := paths[len(paths)-1]
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, "", fmt.Errorf("reading schedule manifest: %w", err)
+	}
+	var m convoyScheduleManifest
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, "", fmt.Errorf("parsing schedule manifest %s: %w", path, err)
+	}
+	return &m, path, nil
+}
+
+// failedCandidates returns the manifest's failed entries as candidates.
+func (m *convoyScheduleManifest) failedCandidates() []convoyCandidate {
+	var failed []convoyCandidate
+	for _, e := range m.Entries {
+		if !e.Success {
+			failed = append(failed, convoyCandidate{ID: e.BeadID, RigName: e.Rig, Formula: e.Formula})
+		}
+	}
+	return failed
+}
+
 // runConvoySlingByID immediately dispatches all open tracked issues of a convoy.
 // Used when max_polecats=-1 (direct dispatch mode). Each tracked issue gets its
 // own polecat via executeSling(). Sets NoConvoy=true since issues are already tracked.
@@ -171,27 +584,29 @@ func runConvoySlingByID(convoyID string, opts convoyScheduleOpts) error {
 		fmt.Printf("Convoy %s has no tracked issues.\n", convoyID)
 		return nil
 	}
+	tracked, skippedStale := filterUpdatedAfter(tracked, opts.After)
 
-	type slingCandidate struct {
-		ID      string
-		Title   string
-		RigName string
-	}
-	var candidates []slingCandidate
+	var candidates []convoyCandidate
+	rigs := opts.rigResolver(townRoot)
 	skippedClosed := 0
 	skippedAssigned := 0
 	skippedNoRig := 0
+	skippedLabel := 0
 
 	for _, t := range tracked {
 		if t.Status == "closed" || t.Status == "tombstone" {
 			skippedClosed++
 			continue
 		}
+		if !hasAllLabels(t.Labels, opts.Labels) {
+			skippedLabel++
+			continue
+		}
 		if t.Assignee != "" && !opts.Force {
 			skippedAssigned++
 			continue
 		}
-		rigName := resolveRigForBead(townRoot, t.ID)
+		rigName := rigs.resolve(t.ID)
 		if rigName == "" {
 			skippedNoRig++
 			prefix := beads.ExtractPrefix(t.ID)
@@ -199,7 +614,13 @@ func runConvoySlingByID(convoyID string, opts convoyScheduleOpts) error {
 				style.Dim.Render("○"), t.ID, prefix)
 			continue
 		}
-		candidates = append(candidates, slingCandidate{ID: t.ID, Title: t.Title, RigName: rigName})
+		candidates = append(candidates, convoyCandidate{
+			ID:       t.ID,
+			Title:    t.Title,
+			RigName:  rigName,
+			Priority: t.Priority,
+			Formula:  candidateFormula(t.ID, t.Labels, opts.Formula, opts.HookRawBead),
+		})
 	}
 
 	if len(candidates) == 0 {
@@ -209,21 +630,34 @@ func runConvoySlingByID(convoyID string, opts convoyScheduleOpts) error {
 				skippedClosed, skippedAssigned, skippedNoRig)
 		}
 		fmt.Println()
+		printLabelSkips(skippedLabel, opts.Labels, "  ")
+		printUpdatedSkips(skippedStale, opts.After, "  ")
 		return nil
 	}
 
+	sortByPriority(candidates)
+	if opts.InterleaveRigs {
+		candidates = interleaveByRig(candidates)
+	}
+
 	formula := opts.Formula
 
 	if opts.DryRun {
 		fmt.Printf("%s Would dispatch %d issue(s) from convoy %s:\n",
 			style.Bold.Render("DRY-RUN"), len(candidates), convoyID)
-		for _, c := range candidates {
-			fmt.Printf("  Would dispatch: %s -> %s (%s)\n", c.ID, c.RigName, c.Title)
+		for i, c := range candidates {
+			if opts.Max > 0 && i >= opts.Max {
+				fmt.Printf("  Would defer %d issue(s) past --max %d\n", len(candidates)-i, opts.Max)
+				break
+			}
+			fmt.Printf("  Would dispatch: %s [P%d] -> %s (%s)%s\n", c.ID, c.Priority, c.RigName, c.Title, c.formulaNote(formula))
 		}
 		if skippedClosed > 0 || skippedAssigned > 0 || skippedNoRig > 0 {
 			fmt.Printf("\nSkipped: %d closed, %d assigned, %d no rig\n",
 				skippedClosed, skippedAssigned, skippedNoRig)
 		}
+		printLabelSkips(skippedLabel, opts.Labels, "")
+		printUpdatedSkips(skippedStale, opts.After, "")
 		return nil
 	}
 
@@ -231,8 +665,13 @@ func runConvoySlingByID(convoyID string, opts convoyScheduleOpts) error {
 		style.Bold.Render("▶"), len(candidates), convoyID)
 
 	successCount := 0
+	deferred := 0
 	successfulRigs := make(map[string]bool)
 	for i, c := range candidates {
+		if opts.Max > 0 && successCount >= opts.Max {
+			deferred = len(candidates) - i
+			break
+		}
 		if slingMaxConcurrent > 0 && i >= slingMaxConcurrent {
 			fmt.Printf("  %s Reached --max-concurrent limit (%d)\n", style.Dim.Render("○"), slingMaxConcurrent)
 			break
@@ -242,7 +681,7 @@ func runConvoySlingByID(convoyID string, opts convoyScheduleOpts) error {
 		_, err := executeSling(SlingParams{
 			BeadID:        c.ID,
 			RigName:       c.RigName,
-			FormulaName:   formula,
+			FormulaName:   c.Formula,
 			Force:         opts.Force,
 			HookRawBead:   opts.HookRawBead,
 			NoConvoy:      true, // Already tracked by this convoy
@@ -272,14 +711,35 @@ func runConvoySlingByID(convoyID string, opts convoyScheduleOpts) error {
 	}
 
 	fmt.Printf("\n%s Dispatched %d/%d issue(s) from convoy %s\n",
-		style.Bold.Render("📊"), successCount, len(candidates), convoyID)
+		style.Bold.Render("📊"), successCount, len(candidates)-deferred, convoyID)
+	if deferred > 0 {
+		fmt.Printf("  Deferred: %d (--max %d reached; run again to dispatch more)\n", deferred, opts.Max)
+	}
 	if skippedClosed > 0 || skippedAssigned > 0 || skippedNoRig > 0 {
 		fmt.Printf("  Skipped: %d closed, %d assigned, %d no rig\n",
 			skippedClosed, skippedAssigned, skippedNoRig)
 	}
+	printLabelSkips(skippedLabel, opts.Labels, "  ")
+	printUpdatedSkips(skippedStale, opts.After, "  ")
 
 	if successCount == 0 {
 		return fmt.Errorf("all %d dispatch attempts failed for convoy %s", len(candidates), convoyID)
 	}
 	return nil
 }
+
+// printUpdatedSkips reports tracked issues left out by an --after filter.
+func printUpdatedSkips(count int, cutoff time.Time, indent string) {
+	if count == 0 {
+		return
+	}
+	fmt.Printf("%sNot updated since %s: %d\n", indent, cutoff.Format(time.RFC3339), count)
+}
+
+// printLabelSkips reports tracked issues left out by a --label filter.
+func printLabelSkips(count int, labels []string, indent string) {
+	if count == 0 {
+		return
+	}
+	fmt.Printf("%sFiltered by label (%s): %d\n", indent, strings.Join(labels, ", "), count)
+}
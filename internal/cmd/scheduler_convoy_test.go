@@ -0,0 +1,522 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+func candidateIDs(candidates []convoyCandidate) []string {
+	ids := make([]string, len(candidates))
+	for i, c := range candidates {
+		ids[i] = c.ID
+	}
+	return ids
+}
+
+func TestInterleaveByRig_SkewedTowardOneRig(t *testing.T) {
+	candidates := []convoyCandidate{
+		{ID: "gt-1", RigName: "gastown"},
+		{ID: "gt-2", RigName: "gastown"},
+		{ID: "gt-3", RigName: "gastown"},
+		{ID: "gt-4", RigName: "gastown"},
+		{ID: "bd-1", RigName: "beads"},
+		{ID: "gt-5", RigName: "gastown"},
+		{ID: "wy-1", RigName: "wyvern"},
+		{ID: "bd-2", RigName: "beads"},
+	}
+
+	got := candidateIDs(interleaveByRig(candidates))
+	want := []string{"gt-1", "bd-1", "wy-1", "gt-2", "bd-2", "gt-3", "gt-4", "gt-5"}
+
+	if len(got) != len(want) {
+		t.Fatalf("interleaveByRig returned %d candidates, want %d: %v", len(got), len(want), got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("interleaveByRig order = %v, want %v", got, want)
+		}
+	}
+}
+
+func TestInterleaveByRig_CutoffSpansRigs(t *testing.T) {
+	candidates := []convoyCandidate{
+		{ID: "gt-1", RigName: "gastown"},
+		{ID: "gt-2", RigName: "gastown"},
+		{ID: "gt-3", RigName: "gastown"},
+		{ID: "bd-1", RigName: "beads"},
+		{ID: "wy-1", RigName: "wyvern"},
+	}
+
+	// A batch cut off at three should give every rig one issue.
+	first := interleaveByRig(candidates)[:3]
+	rigs := make(map[string]bool)
+	for _, c := range first {
+		rigs[c.RigName] = true
+	}
+	if len(rigs) != 3 {
+		t.Errorf("first three interleaved candidates cover %d rigs, want 3: %v", len(rigs), candidateIDs(first))
+	}
+}
+
+func TestInterleaveByRig_SingleRigAndEmpty(t *testing.T) {
+	if got := interleaveByRig(nil); len(got) != 0 {
+		t.Errorf("interleaveByRig(nil) = %v, want empty", got)
+	}
+
+	candidates := []convoyCandidate{
+		{ID: "gt-1", RigName: "gastown"},
+		{ID: "gt-2", RigName: "gastown"},
+	}
+	got := candidateIDs(interleaveByRig(candidates))
+	if got[0] != "gt-1" || got[1] != "gt-2" {
+		t.Errorf("single-rig order changed: %v", got)
+	}
+}
+
+func TestSortByPriority_MixedPriorities(t *testing.T) {
+	candidates := []convoyCandidate{
+		{ID: "gt-3", Priority: 3},
+		{ID: "gt-2", Priority: 2},
+		{ID: "gt-9", Priority: 0},
+		{ID: "gt-1", Priority: 2},
+		{ID: "gt-0", Priority: 0},
+		{ID: "gt-4", Priority: lowestBeadPriority},
+	}
+
+	sortByPriority(candidates)
+	got := candidateIDs(candidates)
+	want := []string{"gt-0", "gt-9", "gt-1", "gt-2", "gt-3", "gt-4"}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("sortByPriority order = %v, want %v", got, want)
+		}
+	}
+}
+
+func TestSortByPriority_ThenInterleaveKeepsPriorityWithinRig(t *testing.T) {
+	candidates := []convoyCandidate{
+		{ID: "gt-1", RigName: "gastown", Priority: 3},
+		{ID: "gt-2", RigName: "gastown", Priority: 0},
+		{ID: "bd-1", RigName: "beads", Priority: 2},
+		{ID: "bd-2", RigName: "beads", Priority: 1},
+	}
+
+	sortByPriority(candidates)
+	got := candidateIDs(interleaveByRig(candidates))
+	want := []string{"gt-2", "bd-2", "gt-1", "bd-1"}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("order = %v, want %v", got, want)
+		}
+	}
+}
+
+func TestHasAllLabels_SingleLabel(t *testing.T) {
+	tracked := []trackedIssueInfo{
+		{ID: "gt-1", Labels: []string{"frontend"}},
+		{ID: "gt-2", Labels: []string{"backend"}},
+		{ID: "gt-3", Labels: []string{"backend", "frontend"}},
+		{ID: "gt-4"},
+	}
+
+	var got []string
+	for _, ti := range tracked {
+		if hasAllLabels(ti.Labels, []string{"frontend"}) {
+			got = append(got, ti.ID)
+		}
+	}
+	if len(got) != 2 || got[0] != "gt-1" || got[1] != "gt-3" {
+		t.Errorf("frontend filter matched %v, want [gt-1 gt-3]", got)
+	}
+}
+
+func TestHasAllLabels_MultipleLabelsAND(t *testing.T) {
+	required := []string{"frontend", "p-urgent"}
+
+	tests := []struct {
+		labels []string
+		want   bool
+	}{
+		{[]string{"frontend", "p-urgent"}, true},
+		{[]string{"p-urgent", "docs", "frontend"}, true},
+		{[]string{"frontend"}, false},
+		{[]string{"p-urgent"}, false},
+		{nil, false},
+	}
+	for _, tt := range tests {
+		if got := hasAllLabels(tt.labels, required); got != tt.want {
+			t.Errorf("hasAllLabels(%v, %v) = %v, want %v", tt.labels, required, got, tt.want)
+		}
+	}
+
+	if !hasAllLabels(nil, nil) {
+		t.Error("no required labels should match everything")
+	}
+}
+
+func TestScheduleConvoyCandidates_MaxCapsBatch(t *testing.T) {
+	candidates := []convoyCandidate{
+		{ID: "gt-1"}, {ID: "gt-2"}, {ID: "gt-3"}, {ID: "gt-4"}, {ID: "gt-5"},
+	}
+
+	var calls []string
+	scheduled, deferred := scheduleConvoyCandidates(candidates, 2, func(c convoyCandidate) error {
+		calls = append(calls, c.ID)
+		return nil
+	})
+
+	if len(calls) != 2 || calls[0] != "gt-1" || calls[1] != "gt-2" {
+		t.Errorf("schedule calls = %v, want [gt-1 gt-2]", calls)
+	}
+	if scheduled != 2 || deferred != 3 {
+		t.Errorf("scheduled=%d deferred=%d, want 2 and 3", scheduled, deferred)
+	}
+}
+
+func TestScheduleConvoyCandidates_FailuresDontCountTowardMax(t *testing.T) {
+	candidates := []convoyCandidate{{ID: "gt-1"}, {ID: "gt-2"}, {ID: "gt-3"}, {ID: "gt-4"}}
+
+	scheduled, deferred := scheduleConvoyCandidates(candidates, 2, func(c convoyCandidate) error {
+		if c.ID == "gt-1" {
+			return fmt.Errorf("boom")
+		}
+		return nil
+	})
+	if scheduled != 2 || deferred != 1 {
+		t.Errorf("scheduled=%d deferred=%d, want 2 and 1", scheduled, deferred)
+	}
+
+	scheduled, deferred = scheduleConvoyCandidates(candidates, 0, func(convoyCandidate) error { return nil })
+	if scheduled != 4 || deferred != 0 {
+		t.Errorf("no limit: scheduled=%d deferred=%d, want 4 and 0", scheduled, deferred)
+	}
+}
+
+func TestConvoyCandidate_BlockedScheduleNote(t *testing.T) {
+	blocked := convoyCandidate{ID: "gt-1", Blocked: true}
+	if got := blocked.scheduleNote(); got != " (blocked, will auto-dispatch)" {
+		t.Errorf("blocked note = %q", got)
+	}
+	if got := (convoyCandidate{ID: "gt-2"}).scheduleNote(); got != "" {
+		t.Errorf("ready note = %q, want empty", got)
+	}
+
+	// Blocked candidates are still scheduled.
+	scheduled, _ := scheduleConvoyCandidates([]convoyCandidate{blocked}, 0, func(convoyCandidate) error { return nil })
+	if scheduled != 1 {
+		t.Errorf("blocked candidate scheduled = %d, want 1", scheduled)
+	}
+}
+
+func TestConvoyScheduleManifest_RecordsEachAttempt(t *testing.T) {
+	townRoot := t.TempDir()
+	candidates := []convoyCandidate{
+		{ID: "gt-1", RigName: "gastown", Formula: "mol-polecat-work"},
+		{ID: "bd-2", RigName: "beads", Formula: "mol-polecat-work"},
+		{ID: "gt-3", RigName: "gastown", Formula: "mol-docs-work"},
+		{ID: "gt-4", RigName: "gastown", Formula: "mol-polecat-work"}, // past --max, never attempted
+	}
+
+	manifest := newConvoyScheduleManifest("hq-cv-abc", time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC))
+	scheduleConvoyCandidates(candidates, 2, manifest.record(func(c convoyCandidate) error {
+		if c.ID == "bd-2" {
+			return fmt.Errorf("rig parked")
+		}
+		return nil
+	}))
+
+	path, err := manifest.write(townRoot)
+	if err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	if want := filepath.Join(townRoot, ".runtime", "convoys", "hq-cv-abc-schedule-20260301T120000Z.json"); path != want {
+		t.Errorf("path = %q, want %q", path, want)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("manifest not written: %v", err)
+	}
+	var got convoyScheduleManifest
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("unmarshal manifest: %v", err)
+	}
+
+	want := []convoyScheduleManifestItem{
+		{BeadID: "gt-1", Rig: "gastown", Formula: "mol-polecat-work", Success: true},
+		{BeadID: "bd-2", Rig: "beads", Formula: "mol-polecat-work", Success: false, Error: "rig parked"},
+		{BeadID: "gt-3", Rig: "gastown", Formula: "mol-docs-work", Success: true},
+	}
+	if got.ConvoyID != "hq-cv-abc" || len(got.Entries) != len(want) {
+		t.Fatalf("manifest = %+v, want %d entries for hq-cv-abc", got, len(want))
+	}
+	for i := range want {
+		if got.Entries[i] != want[i] {
+			t.Errorf("entry %d = %+v, want %+v", i, got.Entries[i], want[i])
+		}
+	}
+}
+
+func TestRequeueConvoyFailures_OnlyFailedFromLatestManifest(t *testing.T) {
+	townRoot := t.TempDir()
+
+	older := newConvoyScheduleManifest("hq-cv-abc", time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC))
+	older.Entries = append(older.Entries, convoyScheduleManifestItem{BeadID: "gt-old", Rig: "gastown", Success: false})
+	latest := newConvoyScheduleManifest("hq-cv-abc", time.Date(2026, 3, 2, 12, 0, 0, 0, time.UTC))
+	latest.Entries = append(latest.Entries,
+		convoyScheduleManifestItem{BeadID: "gt-1", Rig: "gastown", Formula: "mol-polecat-work", Success: true},
+		convoyScheduleManifestItem{BeadID: "bd-2", Rig: "beads", Formula: "mol-polecat-work", Error: "dolt crashed"},
+		convoyScheduleManifestItem{BeadID: "gt-3", Rig: "gastown", Formula: "mol-docs-work", Error: "dolt crashed"},
+	)
+	for _, m := range []*convoyScheduleManifest{older, latest} {
+		if _, err := m.write(townRoot); err != nil {
+			t.Fatalf("write: %v", err)
+		}
+	}
+
+	m, _, err := latestConvoyScheduleManifest(townRoot, "hq-cv-abc")
+	if err != nil {
+		t.Fatalf("latestConvoyScheduleManifest: %v", err)
+	}
+
+	var requeued []convoyCandidate
+	result, scheduled := requeueConvoyFailures("hq-cv-abc", m.failedCandidates(), func(c convoyCandidate) error {
+		requeued = append(requeued, c)
+		return nil
+	})
+
+	want := []convoyCandidate{
+		{ID: "bd-2", RigName: "beads", Formula: "mol-polecat-work"},
+		{ID: "gt-3", RigName: "gastown", Formula: "mol-docs-work"},
+	}
+	if !reflect.DeepEqual(requeued, want) {
+		t.Errorf("requeued = %+v, want %+v", requeued, want)
+	}
+	if scheduled != 2 || len(result.Entries) != 2 || !result.Entries[0].Success || !result.Entries[1].Success {
+		t.Errorf("scheduled = %d, manifest = %+v; want 2 successful entries", scheduled, result.Entries)
+	}
+}
+
+func TestRunConvoyRequeueFailed_DryRun(t *testing.T) {
+	townRoot := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(townRoot, "mayor"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(townRoot, "mayor", "town.json"), []byte(`{"name":"test"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	m := newConvoyScheduleManifest("hq-cv-abc", time.Date(2026, 3, 2, 12, 0, 0, 0, time.UTC))
+	m.Entries = append(m.Entries,
+		convoyScheduleManifestItem{BeadID: "gt-1", Rig: "gastown", Formula: "mol-polecat-work", Success: true},
+		convoyScheduleManifestItem{BeadID: "bd-2", Rig: "beads", Formula: "mol-polecat-work", Error: "dolt crashed"},
+		convoyScheduleManifestItem{BeadID: "gt-3", Rig: "gastown", Error: "dolt crashed"},
+	)
+	if _, err := m.write(townRoot); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	t.Chdir(townRoot)
+
+	var err error
+	output := captureStdout(t, func() {
+		err = runConvoyRequeueFailed("hq-cv-abc", convoyScheduleOpts{DryRun: true})
+	})
+	if err != nil {
+		t.Fatalf("runConvoyRequeueFailed() = %v", err)
+	}
+	for _, want := range []string{
+		"  Would schedule: bd-2 → beads (mol-polecat-work)\n",
+		"  Would schedule: gt-3 → gastown (no formula)\n",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("output missing %q:\n%s", want, output)
+		}
+	}
+	if strings.Contains(output, "gt-1") {
+		t.Errorf("dry run lists gt-1, which already succeeded:\n%s", output)
+	}
+}
+
+func TestLatestConvoyScheduleManifest_None(t *testing.T) {
+	if _, _, err := latestConvoyScheduleManifest(t.TempDir(), "hq-cv-abc"); err == nil {
+		t.Error("expected error when no manifest exists")
+	}
+}
+
+func TestCandidateFormula(t *testing.T) {
+	saved := formulaExists
+	t.Cleanup(func() { formulaExists = saved })
+	formulaExists = func(name string) bool { return name == "mol-docs-work" }
+
+	const def = "mol-polecat-work"
+	tests := []struct {
+		name        string
+		labels      []string
+		hookRawBead bool
+		want        string
+	}{
+		{"no label uses default", []string{"docs"}, false, def},
+		{"label overrides default", []string{"docs", "formula:mol-docs-work"}, false, "mol-docs-work"},
+		{"unknown formula falls back", []string{"formula:mol-nope"}, false, def},
+		{"empty formula falls back", []string{"formula:"}, false, def},
+		{"hook-raw-bead ignores label", []string{"formula:mol-docs-work"}, true, def},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := candidateFormula("gt-1", tt.labels, def, tt.hookRawBead); got != tt.want {
+				t.Errorf("candidateFormula(%v) = %q, want %q", tt.labels, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFilterUpdatedAfter(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+
+	cutoff, err := parseUpdatedAfter("6h", now)
+	if err != nil {
+		t.Fatalf("parseUpdatedAfter(6h) error = %v", err)
+	}
+	tracked := []trackedIssueInfo{
+		{ID: "gt-old", Status: "open", UpdatedAt: now.Add(-48 * time.Hour)},
+		{ID: "gt-recent", Status: "open", UpdatedAt: now.Add(-1 * time.Hour)},
+		{ID: "gt-edge", Status: "open", UpdatedAt: now.Add(-6 * time.Hour)},
+		{ID: "gt-unknown", Status: "open"},
+		{ID: "gt-done", Status: "closed", UpdatedAt: now.Add(-48 * time.Hour)},
+	}
+
+	kept, skipped := filterUpdatedAfter(tracked, cutoff)
+	var ids []string
+	for _, k := range kept {
+		ids = append(ids, k.ID)
+	}
+	if want := []string{"gt-recent", "gt-unknown", "gt-done"}; fmt.Sprint(ids) != fmt.Sprint(want) {
+		t.Errorf("kept = %v, want %v", ids, want)
+	}
+	if skipped != 2 {
+		t.Errorf("skipped = %d, want 2", skipped)
+	}
+
+	if kept, skipped := filterUpdatedAfter(tracked, time.Time{}); len(kept) != len(tracked) || skipped != 0 {
+		t.Errorf("zero cutoff: kept %d skipped %d, want all kept", len(kept), skipped)
+	}
+}
+
+func TestParseUpdatedAfter(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		in   string
+		want time.Time
+	}{
+		{"2d", now.Add(-48 * time.Hour)},
+		{"90m", now.Add(-90 * time.Minute)},
+		{"2026-03-01T08:00:00Z", time.Date(2026, 3, 1, 8, 0, 0, 0, time.UTC)},
+		{"2026-03-01", time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		got, err := parseUpdatedAfter(tt.in, now)
+		if err != nil {
+			t.Errorf("parseUpdatedAfter(%q) error = %v", tt.in, err)
+			continue
+		}
+		if !got.Equal(tt.want) {
+			t.Errorf("parseUpdatedAfter(%q) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+	if _, err := parseUpdatedAfter("yesterday", now); err == nil {
+		t.Error("parseUpdatedAfter(yesterday) should fail")
+	}
+}
+
+func TestSelectConvoyScheduleCandidates_ForcedRig(t *testing.T) {
+	tracked := []trackedIssueInfo{
+		{ID: "gt-1", Status: "open"},
+		{ID: "zz-2", Status: "open"}, // unknown prefix
+		{ID: "hq-3", Status: "open"}, // town-root prefix
+		{ID: "gt-4", Status: "closed"},
+	}
+	lookups := 0
+	rigs := &rigResolver{
+		townRoot: "/town",
+		lookup: func(_, prefix string) string {
+			lookups++
+			return map[string]string{"gt-": "gastown"}[prefix]
+		},
+		cache: make(map[string]string),
+	}
+
+	candidates, skips := selectConvoyScheduleCandidates(tracked, nil, rigs, convoyScheduleOpts{})
+	if len(candidates) != 1 || skips.noRig != 2 {
+		t.Fatalf("auto-resolve: %d candidates, %d no rig; want 1 and 2", len(candidates), skips.noRig)
+	}
+
+	lookups = 0
+	rigs.forced = "scratch"
+	candidates, skips = selectConvoyScheduleCandidates(tracked, nil, rigs, convoyScheduleOpts{Rig: "scratch"})
+	if got, want := candidateIDs(candidates), []string{"gt-1", "zz-2", "hq-3"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("candidates = %v, want %v", got, want)
+	}
+	for _, c := range candidates {
+		if c.RigName != "scratch" {
+			t.Errorf("%s rig = %q, want forced rig scratch", c.ID, c.RigName)
+		}
+	}
+	if skips.noRig != 0 {
+		t.Errorf("skipped %d for no rig with --rig set, want 0", skips.noRig)
+	}
+	if skips.closed != 1 {
+		t.Errorf("skipped %d closed, want 1", skips.closed)
+	}
+	if lookups != 0 {
+		t.Errorf("prefix lookup called %d times with a forced rig, want 0", lookups)
+	}
+}
+
+func TestGetTrackedIssues_PriorityAndUpdatedAtFromBatch(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("bd stub is a shell script")
+	}
+	dir := t.TempDir()
+	t.Chdir(dir) // outside any town, so no worker lookup
+	binDir := filepath.Join(dir, "bin")
+	if err := os.MkdirAll(binDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	logPath := filepath.Join(dir, "bd.log")
+	writeBDStub(t, binDir, `#!/bin/sh
+echo "$*" >> "`+logPath+`"
+case "$1" in
+  dep) echo '[{"id":"gt-a","status":"open","dependency_type":"tracks"},{"id":"gt-b","status":"open","dependency_type":"tracks"}]' ;;
+  show) echo '[{"id":"gt-a","status":"open","priority":0,"updated_at":"2026-03-10T12:00:00Z"},{"id":"gt-b","status":"open"}]' ;;
+esac
+`, "")
+	t.Setenv("PATH", binDir+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	tracked, err := getTrackedIssues(filepath.Join(dir, ".beads"), "hq-cv-1")
+	if err != nil {
+		t.Fatalf("getTrackedIssues() error = %v", err)
+	}
+	if len(tracked) != 2 {
+		t.Fatalf("tracked = %+v, want 2 issues", tracked)
+	}
+	if tracked[0].Priority != 0 || !tracked[0].UpdatedAt.Equal(time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)) {
+		t.Errorf("gt-a priority %d, updated %v; want P0 at 2026-03-10T12:00:00Z", tracked[0].Priority, tracked[0].UpdatedAt)
+	}
+	if tracked[1].Priority != lowestBeadPriority || !tracked[1].UpdatedAt.IsZero() {
+		t.Errorf("gt-b priority %d, updated %v; want lowest priority and zero time when bd reports neither", tracked[1].Priority, tracked[1].UpdatedAt)
+	}
+
+	calls, err := os.ReadFile(logPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := len(strings.Split(strings.TrimSpace(string(calls)), "\n")); n != 2 {
+		t.Errorf("bd called %d times, want 2 (dep list + one batch show):\n%s", n, calls)
+	}
+}
@@ -21,6 +21,7 @@ type epicScheduleOpts struct {
 	Force       bool
 	DryRun      bool
 	NoBoot      bool
+	TTL         time.Duration // Scheduling only: drop undispatched children after this long (0 = never)
 }
 
 // runEpicScheduleByID schedules all open children of an epic.
@@ -50,6 +51,7 @@ func runEpicScheduleByID(epicID string, opts epicScheduleOpts) error {
 		RigName string
 	}
 	var candidates []scheduleCandidate
+	rigs := newRigResolver(townRoot)
 	skippedClosed := 0
 	skippedAssigned := 0
 	skippedScheduled := 0
@@ -78,7 +80,7 @@ func runEpicScheduleByID(epicID string, opts epicScheduleOpts) error {
 			continue
 		}
 
-		rigName := resolveRigForBead(townRoot, c.ID)
+		rigName := rigs.resolve(c.ID)
 		if rigName == "" {
 			skippedNoRig++
 			prefix := beads.ExtractPrefix(c.ID)
@@ -130,6 +132,7 @@ func runEpicScheduleByID(epicID string, opts epicScheduleOpts) error {
 			Force:       opts.Force,
 			HookRawBead: opts.HookRawBead,
 			NoConvoy:    true, // Epic is the organizing structure
+			TTL:         opts.TTL,
 		})
 		if err != nil {
 			fmt.Printf("  %s %s: %v\n", style.Dim.Render("✗"), c.ID, err)
@@ -180,6 +183,7 @@ func runEpicSlingByID(epicID string, opts epicScheduleOpts) error {
 		RigName string
 	}
 	var candidates []slingCandidate
+	rigs := newRigResolver(townRoot)
 	skippedClosed := 0
 	skippedAssigned := 0
 	skippedNoRig := 0
@@ -193,7 +197,7 @@ func runEpicSlingByID(epicID string, opts epicScheduleOpts) error {
 			skippedAssigned++
 			continue
 		}
-		rigName := resolveRigForBead(townRoot, c.ID)
+		rigName := rigs.resolve(c.ID)
 		if rigName == "" {
 			skippedNoRig++
 			prefix := beads.ExtractPrefix(c.ID)
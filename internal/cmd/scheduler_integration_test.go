@@ -22,6 +22,7 @@ import (
 	"strings"
 	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/steveyegge/gastown/internal/beads"
 	"github.com/steveyegge/gastown/internal/config"
@@ -1097,6 +1098,68 @@ func TestSchedulerInvalidJSONContextCleanup(t *testing.T) {
 	}
 }
 
+// TestSchedulerExpiredContextCleanup verifies that a sling context past its TTL
+// is closed as "expired" during stale cleanup, while a fresh context and a
+// legacy context without a TTL stay open.
+func TestSchedulerExpiredContextCleanup(t *testing.T) {
+	hqPath, rigPath, gtBinary, env := setupSchedulerIntegrationTown(t)
+
+	now := time.Now().UTC()
+	expiredBead := createTestBead(t, rigPath, "Expired context test")
+	expiredID := createSlingContext(t, hqPath, &capacity.SlingContextFields{
+		Version:    1,
+		WorkBeadID: expiredBead,
+		TargetRig:  "testrig",
+		EnqueuedAt: now.Add(-72 * time.Hour).Format(time.RFC3339),
+		TTL:        "24h",
+	})
+	freshBead := createTestBead(t, rigPath, "Fresh context test")
+	createSlingContext(t, hqPath, &capacity.SlingContextFields{
+		Version:    1,
+		WorkBeadID: freshBead,
+		TargetRig:  "testrig",
+		EnqueuedAt: now.Format(time.RFC3339),
+		TTL:        "24h",
+	})
+	legacyBead := createTestBead(t, rigPath, "Legacy context test")
+	createSlingContext(t, hqPath, &capacity.SlingContextFields{
+		Version:    1,
+		WorkBeadID: legacyBead,
+		TargetRig:  "testrig",
+		EnqueuedAt: "2025-01-01T00:00:00Z",
+	})
+
+	// Before cleanup: the expired context is treated as absent, the fresh and
+	// legacy contexts are still scheduled.
+	listed := make(map[string]bool)
+	for _, item := range getSchedulerList(t, gtBinary, hqPath, env) {
+		if id, ok := item["id"].(string); ok {
+			listed[id] = true
+		}
+	}
+	if listed[expiredBead] {
+		t.Errorf("expired bead %s should be excluded from scheduler list", expiredBead)
+	}
+	if !listed[freshBead] || !listed[legacyBead] {
+		t.Errorf("fresh (%s) and legacy (%s) beads should be listed, got %v", freshBead, legacyBead, listed)
+	}
+
+	// Run scheduler dispatch (non-dry-run triggers cleanup before dispatch).
+	out := runGTCmdOutput(t, gtBinary, hqPath, env, "scheduler", "run")
+	t.Logf("scheduler run output:\n%s", out)
+
+	townBeads := beads.NewWithBeadsDir(hqPath, filepath.Join(hqPath, ".beads"))
+	contexts, err := townBeads.ListOpenSlingContexts()
+	if err != nil {
+		t.Fatalf("ListOpenSlingContexts failed: %v", err)
+	}
+	for _, ctx := range contexts {
+		if ctx.ID == expiredID {
+			t.Errorf("expired context %s should have been closed, but is still open", expiredID)
+		}
+	}
+}
+
 // TestSchedulerDirectConvoyDispatch verifies that gt sling <convoy-id> --dry-run
 // with max_polecats=-1 (direct mode) routes to the direct dispatch path.
 func TestSchedulerDirectConvoyDispatch(t *testing.T) {
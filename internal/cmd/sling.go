@@ -8,12 +8,14 @@ import (
 	"os/exec"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/steveyegge/gastown/internal/beads"
 	"github.com/steveyegge/gastown/internal/events"
 	"github.com/steveyegge/gastown/internal/lock"
 	"github.com/steveyegge/gastown/internal/mail"
+	"github.com/steveyegge/gastown/internal/session"
 	"github.com/steveyegge/gastown/internal/style"
 	"github.com/steveyegge/gastown/internal/telemetry"
 	"github.com/steveyegge/gastown/internal/workspace"
@@ -40,6 +42,13 @@ Auto-Convoy:
   gt sling gt-abc gastown              # Creates "Work: <issue-title>" convoy
   gt sling gt-abc gastown --no-convoy  # Skip auto-convoy creation
 
+Polecats:
+  Polecats don't respawn onto new work. When a polecat slings a bead it is
+  recorded as follow-up work instead, and gt done passes it to the witness
+  for the polecat's successor.
+
+  gt sling gt-def                      # (in a polecat) Queue gt-def for gt done
+
 Merge Strategy (--merge):
   Controls how completed work lands. Stored on the auto-convoy.
   gt sling gt-abc gastown --merge=direct  # Push branch directly to main
@@ -99,7 +108,14 @@ Batch Slinging:
 
   When multiple beads are provided with a rig target, each bead gets its own
   polecat. This parallelizes work dispatch without running gt sling N times.
-  Use --max-concurrent to throttle spawn rate and prevent Dolt server overload.`,
+  Use --max-concurrent to throttle spawn rate and prevent Dolt server overload.
+
+Convoy Dispatch:
+  gt sling hq-cv-abc                      # Dispatch all open issues in a convoy
+  gt sling hq-cv-abc --interleave-rigs    # Round-robin issues across target rigs
+  gt sling hq-cv-abc --label frontend     # Only issues labeled "frontend"
+  gt sling hq-cv-abc --max 5              # Schedule at most 5 issues this run
+  gt sling hq-cv-abc --rig scratch        # Send every issue to one rig (no prefix resolution)`,
 	Args: cobra.MinimumNArgs(1),
 	RunE: runSling,
 }
@@ -112,24 +128,36 @@ var (
 	slingVars        []string // --var flag: formula variables (key=value)
 	slingArgs        string   // --args flag: natural language instructions for executor
 	slingStdin       bool     // --stdin: read --message and/or --args from stdin
+	slingFromStdin   bool     // --from-stdin: read --message from stdin
 	slingHookRawBead bool     // --hook-raw-bead: hook raw bead without default formula (expert mode)
 
 	// Flags migrated for polecat spawning (used by sling for work assignment)
-	slingCreate        bool   // --create: create polecat if it doesn't exist
-	slingForce         bool   // --force: force spawn even if polecat has unread mail
-	slingAccount       string // --account: Claude Code account handle to use
-	slingAgent         string // --agent: override runtime agent for this sling/spawn
-	slingNoConvoy      bool   // --no-convoy: skip auto-convoy creation
-	slingOwned         bool   // --owned: mark auto-convoy as caller-managed lifecycle
-	slingNoMerge       bool   // --no-merge: skip merge queue on completion (for upstream PRs/human review)
-	slingMerge         string // --merge: merge strategy for convoy (direct/mr/local)
-	slingNoBoot        bool   // --no-boot: skip wakeRigAgents (avoid witness/refinery boot and lock contention)
-	slingMaxConcurrent int    // --max-concurrent: limit concurrent spawns in batch mode
-	slingBaseBranch    string // --base-branch: override base branch for polecat worktree
-	slingRalph         bool   // --ralph: enable Ralph Wiggum loop mode for multi-step workflows
-	slingFormula       string // --formula: override formula for dispatch (default: mol-polecat-work)
+	slingCreate        bool          // --create: create polecat if it doesn't exist
+	slingForce         bool          // --force: force spawn even if polecat has unread mail
+	slingAccount       string        // --account: Claude Code account handle to use
+	slingAgent         string        // --agent: override runtime agent for this sling/spawn
+	slingNoConvoy      bool          // --no-convoy: skip auto-convoy creation
+	slingOwned         bool          // --owned: mark auto-convoy as caller-managed lifecycle
+	slingNoMerge       bool          // --no-merge: skip merge queue on completion (for upstream PRs/human review)
+	slingMerge         string        // --merge: merge strategy for convoy (direct/mr/local)
+	slingNoBoot        bool          // --no-boot: skip wakeRigAgents (avoid witness/refinery boot and lock contention)
+	slingMaxConcurrent int           // --max-concurrent: limit concurrent spawns in batch mode
+	slingBaseBranch    string        // --base-branch: override base branch for polecat worktree
+	slingRalph         bool          // --ralph: enable Ralph Wiggum loop mode for multi-step workflows
+	slingFormula       string        // --formula: override formula for dispatch (default: mol-polecat-work)
+	slingInterleave    bool          // --interleave-rigs: round-robin convoy dispatch across rigs
+	slingLabels        []string      // --label: only dispatch convoy issues carrying all these labels
+	slingMax           int           // --max: cap issues scheduled per convoy invocation
+	slingAfter         string        // --after: only dispatch convoy issues updated since this duration/timestamp
+	slingRequeueFailed bool          // --requeue-failed: retry only the failures from the convoy's last schedule run
+	slingRig           string        // --rig: send every convoy issue to this rig instead of resolving from prefixes
+	slingTTL           time.Duration // --ttl: expire a scheduled sling context after this long
+	slingPriority      int           // --priority: set the bead's priority before hooking (-1 = leave as is)
 )
 
+// slingStdinIn is where --from-stdin reads the context message from.
+var slingStdinIn io.Reader = os.Stdin
+
 func init() {
 	slingCmd.Flags().StringVarP(&slingSubject, "subject", "s", "", "Context subject for the work")
 	slingCmd.Flags().StringVarP(&slingMessage, "message", "m", "", "Context message for the work")
@@ -138,6 +166,7 @@ func init() {
 	slingCmd.Flags().StringArrayVar(&slingVars, "var", nil, "Formula variable (key=value), can be repeated")
 	slingCmd.Flags().StringVarP(&slingArgs, "args", "a", "", "Natural language instructions for the executor (e.g., 'patch release')")
 	slingCmd.Flags().BoolVar(&slingStdin, "stdin", false, "Read --message and/or --args from stdin (avoids shell quoting issues)")
+	slingCmd.Flags().BoolVar(&slingFromStdin, "from-stdin", false, "Read the context message (--message) from stdin; subject still comes from -s")
 
 	// Flags for polecat spawning (when target is a rig)
 	slingCmd.Flags().BoolVar(&slingCreate, "create", false, "Create polecat if it doesn't exist")
@@ -153,7 +182,15 @@ func init() {
 	slingCmd.Flags().IntVar(&slingMaxConcurrent, "max-concurrent", 0, "Limit concurrent polecat spawns in batch mode (0 = no limit)")
 	slingCmd.Flags().StringVar(&slingBaseBranch, "base-branch", "", "Override base branch for polecat worktree (e.g., 'develop', 'release/v2')")
 	slingCmd.Flags().BoolVar(&slingRalph, "ralph", false, "Enable Ralph Wiggum loop mode (fresh context per step, for multi-step workflows)")
-	slingCmd.Flags().StringVar(&slingFormula, "formula", "", "Formula to apply (default: mol-polecat-work for polecat targets)")
+	slingCmd.Flags().StringVar(&slingFormula, "formula", "", "Formula to apply (default: mol-polecat-work for polecat targets; convoy issues can override with a formula:<name> label)")
+	slingCmd.Flags().BoolVar(&slingInterleave, "interleave-rigs", false, "Convoy dispatch: round-robin issues across target rigs instead of rig-by-rig")
+	slingCmd.Flags().StringVar(&slingRig, "rig", "", "Convoy dispatch: send every tracked issue to this rig instead of resolving each from its prefix")
+	slingCmd.Flags().IntVar(&slingMax, "max", 0, "Convoy dispatch: stop after N issues are scheduled or dispatched this run (0 = no limit)")
+	slingCmd.Flags().IntVar(&slingPriority, "priority", -1, "Set the bead's priority before hooking it (0=urgent ... 4=backlog; default: leave as is). Single bead, direct dispatch only")
+	slingCmd.Flags().DurationVar(&slingTTL, "ttl", 0, "Scheduled dispatch: drop the queued work if not dispatched within this long (e.g., 24h; 0 = never)")
+	slingCmd.Flags().StringVar(&slingAfter, "after", "", "Convoy dispatch: only dispatch issues updated after this (duration like 6h/2d, or a timestamp)")
+	slingCmd.Flags().BoolVar(&slingRequeueFailed, "requeue-failed", false, "Convoy scheduling: re-attempt only the issues that failed in the convoy's most recent schedule run")
+	slingCmd.Flags().StringArrayVar(&slingLabels, "label", nil, "Convoy dispatch: only dispatch issues carrying this label (repeatable, all must match)")
 
 	rootCmd.AddCommand(slingCmd)
 }
@@ -169,18 +206,19 @@ func runSling(cmd *cobra.Command, args []string) (retErr error) {
 		}
 		telemetry.RecordSling(context.Background(), bead, target, retErr)
 	}()
-	// Polecats cannot sling - check early before writing anything.
+	// Polecats cannot hook work - check early before writing anything.
 	// Check GT_ROLE first: coordinators (mayor, witness, etc.) may have a stale
-	// GT_POLECAT in their environment from spawning polecats. Only block if the
+	// GT_POLECAT in their environment from spawning polecats. Only divert if the
 	// parsed role is actually polecat (handles compound forms like
 	// "gastown/polecats/Toast"). If GT_ROLE is unset, fall back to GT_POLECAT.
+	// A polecat's bead is recorded as follow-up work for gt done instead.
 	if role := os.Getenv("GT_ROLE"); role != "" {
 		parsedRole, _, _ := parseRoleString(role)
 		if parsedRole == RolePolecat {
-			return fmt.Errorf("polecats cannot sling (use gt done for handoff)")
+			return slingAsPolecat(cmd, args)
 		}
 	} else if polecatName := os.Getenv("GT_POLECAT"); polecatName != "" {
-		return fmt.Errorf("polecats cannot sling (use gt done for handoff)")
+		return slingAsPolecat(cmd, args)
 	}
 
 	// Validate --merge flag if provided
@@ -193,6 +231,13 @@ func runSling(cmd *cobra.Command, args []string) (retErr error) {
 		}
 	}
 
+	if err := validateSlingPriority(slingPriority); err != nil {
+		return err
+	}
+	if slingRig != "" && len(args) != 1 {
+		return fmt.Errorf("--rig applies to convoy dispatch: gt sling <convoy-id> --rig <rig>")
+	}
+
 	// Disable Dolt auto-commit for all bd commands run during sling (gt-u6n6a).
 	// Under concurrent load (batch slinging), auto-commits from individual bd writes
 	// cause manifest contention and 'database is read only' errors. The Dolt server
@@ -207,6 +252,16 @@ func runSling(cmd *cobra.Command, args []string) (retErr error) {
 		}
 	}()
 
+	// Handle --from-stdin: read the context message from a file or pipe
+	if slingFromStdin {
+		if slingStdin {
+			return fmt.Errorf("cannot use --from-stdin with --stdin")
+		}
+		if err := readSlingMessage(slingStdinIn); err != nil {
+			return err
+		}
+	}
+
 	// Handle --stdin: read message/args from stdin (avoids shell quoting issues)
 	if slingStdin {
 		if slingMessage != "" && slingArgs != "" {
@@ -255,6 +310,14 @@ func runSling(cmd *cobra.Command, args []string) (retErr error) {
 	if deferErr != nil {
 		return deferErr
 	}
+	// --priority is applied just before hooking, which only the direct
+	// single-bead path does; everywhere else it would be silently dropped.
+	if slingPriority >= 0 && deferred {
+		return fmt.Errorf("--priority is not supported with deferred dispatch (scheduler.max_polecats > 0)\nSet it first: bd update <bead> --priority %d", slingPriority)
+	}
+	if slingPriority >= 0 && len(args) > 2 {
+		return fmt.Errorf("--priority applies to a single bead, not batch sling")
+	}
 
 	// Batch mode detection: multiple beads with optional rig target
 	// Pattern A (explicit rig):  gt sling gt-abc gt-def gt-ghi gastown
@@ -316,6 +379,7 @@ func runSling(cmd *cobra.Command, args []string) (retErr error) {
 				Agent:       slingAgent,
 				HookRawBead: slingHookRawBead,
 				Ralph:       slingRalph,
+				TTL:         slingTTL,
 			})
 		}
 	}
@@ -355,6 +419,7 @@ func runSling(cmd *cobra.Command, args []string) (retErr error) {
 			Agent:       slingAgent,
 			HookRawBead: slingHookRawBead,
 			Ralph:       slingRalph,
+			TTL:         slingTTL,
 		})
 	}
 
@@ -391,6 +456,7 @@ func runSling(cmd *cobra.Command, args []string) (retErr error) {
 				Agent:       slingAgent,
 				HookRawBead: slingHookRawBead,
 				Ralph:       slingRalph,
+				TTL:         slingTTL,
 			})
 		}
 		// Non-rig target in deferred mode — reject to prevent bypassing capacity control
@@ -408,31 +474,77 @@ func runSling(cmd *cobra.Command, args []string) (retErr error) {
 				if err := validateNoTaskOnlySchedulerFlags(cmd, "convoy"); err != nil {
 					return err
 				}
-				if deferred {
-					return runConvoyScheduleByID(args[0], convoyScheduleOpts{
-						Formula:     formula,
+				if slingRig != "" {
+					if _, isRig := IsRigName(slingRig); !isRig {
+						return fmt.Errorf("--rig: '%s' is not a known rig", slingRig)
+					}
+					if slingRequeueFailed {
+						return fmt.Errorf("--rig cannot be used with --requeue-failed (failures retry on their recorded rig)")
+					}
+				}
+				if slingMax != 0 && slingRequeueFailed {
+					return fmt.Errorf("--max cannot be used with --requeue-failed (every recorded failure is retried)")
+				}
+				if slingRequeueFailed {
+					if !deferred {
+						return fmt.Errorf("--requeue-failed requires deferred dispatch (scheduler.max_polecats > 0)")
+					}
+					return runConvoyRequeueFailed(args[0], convoyScheduleOpts{
 						HookRawBead: slingHookRawBead,
 						Force:       slingForce,
 						DryRun:      slingDryRun,
+						TTL:         slingTTL,
+					})
+				}
+				var after time.Time
+				if slingAfter != "" {
+					if after, err = parseUpdatedAfter(slingAfter, time.Now()); err != nil {
+						return err
+					}
+				}
+				if deferred {
+					return runConvoyScheduleByID(args[0], convoyScheduleOpts{
+						Formula:        formula,
+						HookRawBead:    slingHookRawBead,
+						Force:          slingForce,
+						DryRun:         slingDryRun,
+						InterleaveRigs: slingInterleave,
+						Labels:         slingLabels,
+						Max:            slingMax,
+						After:          after,
+						Rig:            slingRig,
+						TTL:            slingTTL,
 					})
 				}
 				return runConvoySlingByID(args[0], convoyScheduleOpts{
-					Formula:     formula,
-					HookRawBead: slingHookRawBead,
-					Force:       slingForce,
-					DryRun:      slingDryRun,
-					NoBoot:      slingNoBoot,
+					Formula:        formula,
+					HookRawBead:    slingHookRawBead,
+					Force:          slingForce,
+					DryRun:         slingDryRun,
+					NoBoot:         slingNoBoot,
+					InterleaveRigs: slingInterleave,
+					Labels:         slingLabels,
+					Max:            slingMax,
+					After:          after,
+					Rig:            slingRig,
 				})
 			case "epic":
 				if err := validateNoTaskOnlySchedulerFlags(cmd, "epic"); err != nil {
 					return err
 				}
+				if slingRig != "" {
+					return fmt.Errorf("--rig applies to convoy dispatch, not epics")
+				}
+				if slingMax != 0 {
+					return fmt.Errorf("--max applies to convoy dispatch, not epics")
+				}
 				if deferred {
 					return runEpicScheduleByID(args[0], epicScheduleOpts{
 						Formula:     formula,
 						HookRawBead: slingHookRawBead,
 						Force:       slingForce,
 						DryRun:      slingDryRun,
+						TTL:         slingTTL,
 					})
 				}
 				return runEpicSlingByID(args[0], epicScheduleOpts{
@@ -444,6 +556,9 @@ func runSling(cmd *cobra.Command, args []string) (retErr error) {
 				})
 			}
 		}
+		if slingRig != "" {
+			return fmt.Errorf("--rig applies to convoy dispatch; for a single bead use: gt sling %s %s", args[0], slingRig)
+		}
 		// task bead with deferred + no rig: error — must specify a rig
 		if deferred {
 			return fmt.Errorf("deferred dispatch requires a rig target: gt sling %s <rig>", args[0])
@@ -453,6 +568,9 @@ func runSling(cmd *cobra.Command, args []string) (retErr error) {
 	// 2-bead auto-resolve: gt sling gt-abc gt-def
 	if len(args) == 2 && allBeadIDs(args) {
 		if _, isRig := IsRigName(args[1]); !isRig {
+			if slingPriority >= 0 {
+				return fmt.Errorf("--priority applies to a single bead, not batch sling")
+			}
 			rigName, err := resolveRigFromBeadIDs(args, filepath.Dir(townBeadsDir))
 			if err != nil {
 				return err
@@ -491,6 +609,9 @@ func runSling(cmd *cobra.Command, args []string) (retErr error) {
 				// Standalone formula mode: gt sling <formula> [target]
 				// Standalone formula: deferred dispatch is handled above (formula-on-bead),
 				// so no scheduler check needed here.
+				if slingPriority >= 0 {
+					return fmt.Errorf("--priority applies to a bead, not a standalone formula (use --on <bead>)")
+				}
 				return runSlingFormula(args)
 			}
 			// Not a formula either - check if it looks like a bead ID (routing issue workaround).
@@ -534,6 +655,11 @@ func runSling(cmd *cobra.Command, args []string) (retErr error) {
 		return fmt.Errorf("refusing to sling deferred bead %s: %q\nDeferred work should not consume polecat slots. Use --force to override", beadID, info.Title)
 	}
 
+	// Guard against slinging finished work (closed or tombstoned beads).
+	if err := verifyBeadSlingable(beadID, info, slingForce); err != nil {
+		return err
+	}
+
 	originalStatus := info.Status
 	originalAssignee := info.Assignee
 	force := slingForce // local copy to avoid mutating package-level flag
@@ -636,7 +762,8 @@ func runSling(cmd *cobra.Command, args []string) (retErr error) {
 	// Cross-rig guard: prevent slinging beads to polecats in the wrong rig (gt-myecw).
 	// Polecats work in their rig's worktree and cannot fix code owned by another rig.
 	// Skip for self-sling (user knows what they're doing) and --force overrides.
-	if strings.Contains(targetAgent, "/polecats/") && !force && !isSelfSling {
+	targetIdentity, _ := parseAgentID(targetAgent)
+	if targetIdentity != nil && targetIdentity.Role == session.RolePolecat && !force && !isSelfSling {
 		if err := checkCrossRigGuard(beadID, targetAgent, townRoot); err != nil {
 			return err
 		}
@@ -662,10 +789,9 @@ func runSling(cmd *cobra.Command, args []string) (retErr error) {
 		}
 
 		// Extract rig name from assignee (e.g., "gastown/polecats/Toast" -> "gastown")
-		assigneeParts := strings.Split(info.Assignee, "/")
-		if len(assigneeParts) >= 3 && assigneeParts[1] == "polecats" {
-			oldRigName := assigneeParts[0]
-			oldPolecatName := assigneeParts[2]
+		if old, err := parseAgentID(info.Assignee); err == nil && old.Role == session.RolePolecat {
+			oldRigName := old.Rig
+			oldPolecatName := old.Name
 
 			// Send LIFECYCLE:Shutdown to witness - will auto-nuke if clean,
 			// otherwise create cleanup wisp for manual intervention
@@ -774,6 +900,9 @@ func runSling(cmd *cobra.Command, args []string) (retErr error) {
 		} else {
 			fmt.Printf("Would run: bd update %s --status=hooked --assignee=%s\n", beadID, targetAgent)
 		}
+		if slingPriority >= 0 {
+			fmt.Printf("Would run: bd update %s --priority %d\n", beadID, slingPriority)
+		}
 		if slingSubject != "" {
 			fmt.Printf("  subject (in nudge): %s\n", slingSubject)
 		}
@@ -787,13 +916,21 @@ func runSling(cmd *cobra.Command, args []string) (retErr error) {
 		return nil
 	}
 
+	// Bump priority before hooking so the restarted agent sees it as top work.
+	if slingPriority >= 0 {
+		if err := bumpBeadPriority(beadID, slingPriority, beads.ResolveHookDir(townRoot, beadID, hookWorkDir)); err != nil {
+			return err
+		}
+		fmt.Printf("%s Set %s priority to P%d\n", style.Bold.Render("✓"), beadID, slingPriority)
+	}
+
 	// Formula-on-bead mode: instantiate formula and bond to original bead
 	if formulaName != "" {
 		fmt.Printf("  Instantiating formula %s...\n", formulaName)
 
 		// Auto-inject rig command vars as defaults (user --var flags override)
-		if parts := strings.SplitN(targetAgent, "/", 2); len(parts) >= 1 && parts[0] != "" {
-			rigCmdVars := loadRigCommandVars(townRoot, parts[0])
+		if targetIdentity != nil && targetIdentity.Rig != "" {
+			rigCmdVars := loadRigCommandVars(townRoot, targetIdentity.Rig)
 			slingVars = append(rigCmdVars, slingVars...)
 		}
 
@@ -838,7 +975,7 @@ func runSling(cmd *cobra.Command, args []string) (retErr error) {
 	// Hook the bead with retry and verification.
 	// See: https://github.com/steveyegge/gastown/issues/148
 	hookDir := beads.ResolveHookDir(townRoot, beadID, hookWorkDir)
-	if err := hookBeadWithRetry(beadID, targetAgent, hookDir); err != nil {
+	if err := hookBeadWithRetry(beadID, targetAgent, hookDir, force); err != nil {
 		return err
 	}
 
@@ -847,6 +984,7 @@ func runSling(cmd *cobra.Command, args []string) (retErr error) {
 	// Log sling event to activity feed
 	actor := detectActor()
 	_ = events.LogFeed(events.TypeSling, actor, events.SlingPayload(beadID, targetAgent))
+	recordSlingHistory(townRoot, actor, beadID, slingSubject, targetAgent)
 
 	// Update agent bead's hook_bead field (ZFC: agents track their current work)
 	// Skip if hook was already set atomically during polecat spawn - avoids "agent bead not found"
@@ -947,10 +1085,11 @@ func checkCrossRigGuard(beadID, targetAgent, townRoot string) error {
 	}
 
 	// Extract target rig from agent path (e.g., "gastown/polecats/Toast" → "gastown")
-	targetRig := strings.SplitN(targetAgent, "/", 2)[0]
-	if targetRig == "" {
+	target, err := parseAgentID(targetAgent)
+	if err != nil || target.Rig == "" {
 		return nil
 	}
+	targetRig := target.Rig
 
 	beadRig := beads.GetRigNameForPrefix(townRoot, beadPrefix)
 
@@ -1032,3 +1171,17 @@ func rollbackSlingArtifacts(spawnInfo *SpawnedPolecatInfo, beadID, hookWorkDir s
 	// 2. Clean up the spawned polecat (worktree, agent bead, etc.)
 	cleanupSpawnedPolecat(spawnInfo, spawnInfo.RigName)
 }
+
+// readSlingMessage sets slingMessage from in for --from-stdin. The body is
+// kept as-is apart from trailing newlines, so multi-line descriptions survive.
+func readSlingMessage(in io.Reader) error {
+	if slingMessage != "" {
+		return fmt.Errorf("cannot use --from-stdin with --message/-m")
+	}
+	data, err := io.ReadAll(in)
+	if err != nil {
+		return fmt.Errorf("reading stdin: %w", err)
+	}
+	slingMessage = strings.TrimRight(string(data), "\n")
+	return nil
+}
@@ -133,6 +133,12 @@ func executeSling(params SlingParams) (*SlingResult, error) {
 		return result, fmt.Errorf("bead %s is deferred (use --force to override)", params.BeadID)
 	}
 
+	// Guard against slinging finished work (closed or tombstoned beads).
+	if err := verifyBeadSlingable(params.BeadID, info, explicitForce); err != nil {
+		result.ErrMsg = info.Status
+		return result, err
+	}
+
 	// Send LIFECYCLE:Shutdown to the witness when force-stealing a bead from a
 	// live polecat. Without this, the old polecat becomes a zombie — still running
 	// but unaware it lost its hook. Mirrors the same logic in runSling (sling.go).
@@ -271,7 +277,7 @@ func executeSling(params SlingParams) (*SlingResult, error) {
 
 	// 7. Hook bead with retry
 	hookDir := beads.ResolveHookDir(townRoot, beadToHook, hookWorkDir)
-	if err := hookBeadWithRetry(beadToHook, targetAgent, hookDir); err != nil {
+	if err := hookBeadWithRetry(beadToHook, targetAgent, hookDir, params.Force); err != nil {
 		// Clean up orphaned polecat to avoid leaving spawned-but-unhookable polecats
 		cleanupSpawnedPolecat(spawnInfo, params.RigName)
 		result.ErrMsg = "hook failed"
@@ -283,6 +289,7 @@ func executeSling(params SlingParams) (*SlingResult, error) {
 	// 8. Log sling event
 	actor := detectActor()
 	_ = events.LogFeed(events.TypeSling, actor, events.SlingPayload(beadToHook, targetAgent))
+	recordSlingHistory(townRoot, actor, beadToHook, "", targetAgent)
 
 	// 9. Update agent hook_bead state
 	updateAgentHookBead(targetAgent, beadToHook, hookWorkDir, beadsDir)
@@ -0,0 +1,118 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/constants"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+// followUpsPath returns the file where a polecat's follow-up beads are
+// recorded for its successor.
+// Path: <townRoot>/.runtime/followups/<sender>
+func followUpsPath(townRoot, sender string) string {
+	safe := strings.ReplaceAll(strings.Trim(sender, "/"), "/", "_")
+	return filepath.Join(townRoot, constants.DirRuntime, "followups", safe)
+}
+
+// recordFollowUp appends beadID to the sender's follow-up list. Beads that are
+// already recorded are not duplicated.
+func recordFollowUp(townRoot, sender, beadID string) error {
+	existing, err := readFollowUps(townRoot, sender)
+	if err != nil {
+		return err
+	}
+	for _, id := range existing {
+		if id == beadID {
+			return nil
+		}
+	}
+
+	path := followUpsPath(townRoot, sender)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("creating follow-ups directory: %w", err)
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("opening follow-ups file: %w", err)
+	}
+	defer f.Close()
+	if _, err := fmt.Fprintln(f, beadID); err != nil {
+		return fmt.Errorf("writing follow-up: %w", err)
+	}
+	return nil
+}
+
+// readFollowUps returns the beads recorded for the sender, in the order they
+// were slung. A missing file means no follow-ups.
+func readFollowUps(townRoot, sender string) ([]string, error) {
+	data, err := os.ReadFile(followUpsPath(townRoot, sender))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("reading follow-ups: %w", err)
+	}
+	var ids []string
+	for _, line := range strings.Split(string(data), "\n") {
+		if id := strings.TrimSpace(line); id != "" {
+			ids = append(ids, id)
+		}
+	}
+	return ids, nil
+}
+
+// clearFollowUps removes the sender's follow-up list once it has been handed off.
+func clearFollowUps(townRoot, sender string) {
+	_ = os.Remove(followUpsPath(townRoot, sender))
+}
+
+// slingAsPolecat handles `gt sling` from a polecat session. Polecats never
+// respawn onto new work — their lifecycle ends with `gt done` — so instead of
+// hooking the bead it is recorded as a follow-up that gt done passes to the
+// witness in its POLECAT_DONE notification. Only --dry-run is honored; the
+// other sling flags configure dispatch, which a follow-up doesn't do.
+func slingAsPolecat(cmd *cobra.Command, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("polecats cannot sling (use gt done for handoff)")
+	}
+	if len(args) > 1 {
+		return fmt.Errorf("polecats cannot sling to a target (use gt sling %s to record a follow-up for gt done)", args[0])
+	}
+	flags := cmd.Flags().NFlag()
+	if cmd.Flags().Changed("dry-run") {
+		flags--
+	}
+	if flags > 0 {
+		return fmt.Errorf("polecats can only record follow-up work; sling flags other than --dry-run are not supported (use gt sling %s)", args[0])
+	}
+
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+
+	beadID := args[0]
+	if err := verifyBeadExists(beadID); err != nil {
+		return err
+	}
+
+	if slingDryRun {
+		fmt.Printf("Would record %s as follow-up work for gt done\n", beadID)
+		return nil
+	}
+
+	if err := recordFollowUp(townRoot, detectSender(), beadID); err != nil {
+		return err
+	}
+
+	fmt.Printf("%s Recorded %s as follow-up work\n", style.Bold.Render("✓"), beadID)
+	fmt.Printf("  Polecats don't respawn onto new work; gt done will hand %s\n", beadID)
+	fmt.Printf("  to the witness for your successor.\n")
+	return nil
+}
@@ -0,0 +1,145 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestRecordFollowUp(t *testing.T) {
+	townRoot := t.TempDir()
+	sender := "gastown/polecats/alpha"
+
+	for _, id := range []string{"gt-aaa", "gt-bbb", "gt-aaa"} {
+		if err := recordFollowUp(townRoot, sender, id); err != nil {
+			t.Fatalf("recordFollowUp(%s): %v", id, err)
+		}
+	}
+
+	got, err := readFollowUps(townRoot, sender)
+	if err != nil {
+		t.Fatalf("readFollowUps: %v", err)
+	}
+	if strings.Join(got, ",") != "gt-aaa,gt-bbb" {
+		t.Errorf("readFollowUps = %v, want [gt-aaa gt-bbb]", got)
+	}
+
+	other, err := readFollowUps(townRoot, "gastown/polecats/beta")
+	if err != nil || len(other) != 0 {
+		t.Errorf("other sender follow-ups = %v, %v; want none", other, err)
+	}
+
+	clearFollowUps(townRoot, sender)
+	if got, _ := readFollowUps(townRoot, sender); len(got) != 0 {
+		t.Errorf("after clear, follow-ups = %v, want none", got)
+	}
+}
+
+// TestSlingPolecatRecordsFollowUp verifies that a polecat sling records the
+// bead for gt done instead of hooking it or touching the tmux pane.
+func TestSlingPolecatRecordsFollowUp(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("shell stubs not supported on windows")
+	}
+
+	townRoot := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(townRoot, "mayor"), 0755); err != nil {
+		t.Fatalf("mkdir mayor: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(townRoot, "mayor", "town.json"), []byte(`{"name":"test"}`), 0644); err != nil {
+		t.Fatalf("write town.json: %v", err)
+	}
+
+	// bd show answers for gt-follow only; any other bd or tmux invocation
+	// (hooking, respawning) is logged as a failure.
+	binDir := t.TempDir()
+	callLog := filepath.Join(t.TempDir(), "calls.log")
+	logCall := "echo \"$0 $*\" >> \"" + callLog + "\"\nexit 0\n"
+	bdStub := "#!/bin/sh\nif [ \"$1\" = show ]; then\n  [ \"$2\" = gt-follow ] || exit 1\n  echo '[{\"id\":\"gt-follow\"}]'\n  exit 0\nfi\n" + logCall
+	stubs := map[string]string{"bd": bdStub, "tmux": "#!/bin/sh\n" + logCall}
+	for name, stub := range stubs {
+		if err := os.WriteFile(filepath.Join(binDir, name), []byte(stub), 0755); err != nil {
+			t.Fatalf("write %s stub: %v", name, err)
+		}
+	}
+	t.Setenv("PATH", binDir+string(os.PathListSeparator)+os.Getenv("PATH"))
+	t.Setenv("GT_ROLE", "")
+	t.Setenv("GT_POLECAT", "alpha")
+	t.Setenv("TMUX_PANE", "")
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("getwd: %v", err)
+	}
+	t.Cleanup(func() { _ = os.Chdir(cwd) })
+	if err := os.Chdir(townRoot); err != nil {
+		t.Fatalf("chdir: %v", err)
+	}
+
+	if err := runSling(slingCmd, []string{"gt-follow"}); err != nil {
+		t.Fatalf("runSling: %v", err)
+	}
+
+	got, err := readFollowUps(townRoot, detectSender())
+	if err != nil {
+		t.Fatalf("readFollowUps: %v", err)
+	}
+	if len(got) != 1 || got[0] != "gt-follow" {
+		t.Errorf("recorded follow-ups = %v, want [gt-follow]", got)
+	}
+
+	if data, err := os.ReadFile(callLog); err == nil && len(data) > 0 {
+		t.Errorf("polecat sling should not run bd or tmux (no hook, no respawn), got:\n%s", data)
+	}
+
+	if err := runSling(slingCmd, []string{"gt-follow", "gastown"}); err == nil {
+		t.Error("polecat sling with a target should fail")
+	}
+	if err := runSling(slingCmd, []string{"gt-missing"}); err == nil {
+		t.Error("polecat sling of a nonexistent bead should fail")
+	}
+	if got, _ := readFollowUps(townRoot, detectSender()); len(got) != 1 {
+		t.Errorf("follow-ups after failed slings = %v, want only gt-follow", got)
+	}
+}
+
+func TestSlingAsPolecat_Flags(t *testing.T) {
+	origDryRun, origForce := slingDryRun, slingForce
+	t.Cleanup(func() {
+		slingDryRun, slingForce = origDryRun, origForce
+		for _, name := range []string{"dry-run", "force"} {
+			slingCmd.Flags().Lookup(name).Changed = false
+		}
+	})
+	townRoot := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(townRoot, "mayor"), 0755); err != nil {
+		t.Fatalf("mkdir mayor: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(townRoot, "mayor", "town.json"), []byte(`{"name":"test"}`), 0644); err != nil {
+		t.Fatalf("write town.json: %v", err)
+	}
+	t.Chdir(townRoot)
+	binDir := t.TempDir()
+	writeBDStub(t, binDir, "#!/bin/sh\necho '[{\"id\":\"gt-follow\"}]'\n", "@echo [{\"id\":\"gt-follow\"}]\r\n")
+	t.Setenv("PATH", binDir+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	if err := slingCmd.Flags().Set("dry-run", "true"); err != nil {
+		t.Fatal(err)
+	}
+	if err := slingAsPolecat(slingCmd, []string{"gt-follow"}); err != nil {
+		t.Fatalf("--dry-run: slingAsPolecat() = %v", err)
+	}
+	if got, _ := readFollowUps(townRoot, detectSender()); len(got) != 0 {
+		t.Errorf("--dry-run recorded follow-ups %v, want none", got)
+	}
+
+	if err := slingCmd.Flags().Set("force", "true"); err != nil {
+		t.Fatal(err)
+	}
+	err := slingAsPolecat(slingCmd, []string{"gt-follow"})
+	if err == nil || !strings.Contains(err.Error(), "--dry-run") {
+		t.Errorf("--force: slingAsPolecat() = %v, want unsupported flag error", err)
+	}
+}
@@ -173,7 +173,7 @@ func runSlingFormula(args []string) error {
 	// Step 3: Hook the wisp bead with retry and verification.
 	// See: https://github.com/steveyegge/gastown/issues/148
 	hookDir := beads.ResolveHookDir(townRoot, wispRootID, "")
-	if err := hookBeadWithRetry(wispRootID, targetAgent, hookDir); err != nil {
+	if err := hookBeadWithRetry(wispRootID, targetAgent, hookDir, slingForce); err != nil {
 		return err
 	}
 	fmt.Printf("%s Attached to hook (status=hooked)\n", style.Bold.Render("✓"))
@@ -183,6 +183,7 @@ func runSlingFormula(args []string) error {
 	payload := events.SlingPayload(wispRootID, targetAgent)
 	payload["formula"] = formulaName
 	_ = events.LogFeed(events.TypeSling, actor, payload)
+	recordSlingHistory(townRoot, actor, wispRootID, slingSubject, targetAgent)
 
 	// Update agent bead's hook_bead field (ZFC: agents track their current work)
 	// Note: formula slinging uses town root as workDir (no polecat-specific path)
@@ -23,6 +23,7 @@ import (
 	"github.com/steveyegge/gastown/internal/style"
 	"github.com/steveyegge/gastown/internal/telemetry"
 	"github.com/steveyegge/gastown/internal/tmux"
+	"github.com/steveyegge/gastown/internal/wisp"
 	"github.com/steveyegge/gastown/internal/workspace"
 )
 
@@ -82,6 +83,8 @@ type beadInfo struct {
 	Labels       []string         `json:"labels,omitempty"`
 	Dependencies []beads.IssueDep `json:"dependencies,omitempty"`
 	IssueType    string           `json:"issue_type,omitempty"`
+	Priority     int              `json:"priority"`
+	UpdatedAt    string           `json:"updated_at,omitempty"`
 }
 
 // isDeferredBead checks whether a bead should be rejected from slinging because
@@ -100,6 +103,21 @@ func isDeferredBead(info *beadInfo) bool {
 	return false
 }
 
+// verifyBeadSlingable rejects beads that are finished (closed or tombstoned).
+// Slinging them hands the agent work that no longer exists, which confuses
+// the session that picks it up. force overrides the check, e.g. to reopen
+// work deliberately.
+func verifyBeadSlingable(beadID string, info *beadInfo, force bool) error {
+	if force {
+		return nil
+	}
+	switch info.Status {
+	case "closed", "tombstone":
+		return fmt.Errorf("refusing to sling %s bead %s: %q\nUse --force to sling it anyway", info.Status, beadID, info.Title)
+	}
+	return nil
+}
+
 // collectExistingMolecules returns all molecule wisp IDs attached to a bead.
 // Checks both dependency bonds (ground truth from bd mol bond) and the
 // description's attached_molecule field (metadata pointer). Wisp IDs are
@@ -442,14 +460,40 @@ func isSessionYoung(sessionName string, maxAge time.Duration) bool {
 	return time.Since(time.Unix(createdUnix, 0)) < maxAge
 }
 
+// cloneRootMarkers are directories that mark a clone root when git can't
+// tell us, e.g. in a fresh worktree that hasn't been initialized yet.
+var cloneRootMarkers = []string{wisp.WispConfigDir, ".gastown"}
+
 // detectCloneRoot finds the root of the current git clone.
 func detectCloneRoot() (string, error) {
-	cmd := exec.Command("git", "rev-parse", "--show-toplevel")
-	out, err := cmd.Output()
+	cwd, err := os.Getwd()
 	if err != nil {
-		return "", fmt.Errorf("not in a git repository")
+		return "", fmt.Errorf("getting current directory: %w", err)
+	}
+	return detectCloneRootFrom(cwd)
+}
+
+// detectCloneRootFrom finds the clone root for dir: the git toplevel if dir
+// is in a git repository, otherwise the nearest ancestor holding a
+// cloneRootMarkers directory.
+func detectCloneRootFrom(dir string) (string, error) {
+	cmd := exec.Command("git", "rev-parse", "--show-toplevel")
+	cmd.Dir = dir
+	if out, err := cmd.Output(); err == nil {
+		return strings.TrimSpace(string(out)), nil
 	}
-	return strings.TrimSpace(string(out)), nil
+
+	for d := dir; ; d = filepath.Dir(d) {
+		for _, marker := range cloneRootMarkers {
+			if info, err := os.Stat(filepath.Join(d, marker)); err == nil && info.IsDir() {
+				return d, nil
+			}
+		}
+		if filepath.Dir(d) == d {
+			break
+		}
+	}
+	return "", fmt.Errorf("not in a git repository and no %s directory found", strings.Join(cloneRootMarkers, " or "))
 }
 
 // detectActor returns the current agent's actor string for event logging.
@@ -931,16 +975,79 @@ func isHookedAgentDead(assignee string) bool {
 	return !alive
 }
 
+// validateSlingPriority checks a --priority value. -1 (the default) leaves the
+// bead's priority unchanged.
+func validateSlingPriority(priority int) error {
+	if priority < -1 || priority > 4 {
+		return fmt.Errorf("invalid --priority %d: must be 0-4", priority)
+	}
+	return nil
+}
+
+// bumpBeadPriority sets a bead's priority via bd update, run from dir so the
+// bead's database is found.
+func bumpBeadPriority(beadID string, priority int, dir string) error {
+	if err := BdCmd("update", beadID, "--priority", strconv.Itoa(priority)).Dir(dir).Run(); err != nil {
+		return fmt.Errorf("setting priority of %s: %w", beadID, err)
+	}
+	return nil
+}
+
+// hookConflictError reports a bead held by a live agent other than the one
+// trying to hook it — usually a sign that identity detection misfired.
+type hookConflictError struct {
+	BeadID string
+	Status string
+	Owner  string
+	Target string
+}
+
+func (e *hookConflictError) Error() string {
+	return fmt.Sprintf("bead %s is already %s to %s (live), refusing to hook it to %s\nUse --force to take it over",
+		e.BeadID, e.Status, e.Owner, e.Target)
+}
+
+// checkHookOwner returns a hookConflictError when info shows beadID held by a
+// different agent whose session is still alive. Re-hooking to the same agent
+// is allowed, as is taking over a hook whose owner is dead.
+func checkHookOwner(beadID, targetAgent string, info *beadInfo, isDead func(string) bool) error {
+	if info == nil {
+		return nil
+	}
+	owner := normalizeAgentID(info.Assignee)
+	if owner == "" || owner == normalizeAgentID(targetAgent) {
+		return nil
+	}
+	if info.Status != "hooked" && info.Status != "in_progress" && info.Status != "pinned" {
+		return nil
+	}
+	if isDead(info.Assignee) {
+		return nil
+	}
+	return &hookConflictError{BeadID: beadID, Status: info.Status, Owner: info.Assignee, Target: targetAgent}
+}
+
 // hookBeadWithRetry hooks a bead to a target agent with exponential backoff retry
 // and post-hook verification. This ensures the hook sticks even under Dolt concurrency.
 // Fails fast on configuration/initialization errors (gt-2ra).
+// Unless force is set, the bead is re-read first and hooking fails if another
+// live agent already holds it (see checkHookOwner).
 // See: https://github.com/steveyegge/gastown/issues/148
-func hookBeadWithRetry(beadID, targetAgent, hookDir string) error {
+func hookBeadWithRetry(beadID, targetAgent, hookDir string, force bool) error {
 	const maxRetries = 10
 	const baseBackoff = 500 * time.Millisecond
 	const maxBackoff = 30 * time.Second
 	skipVerify := os.Getenv("GT_TEST_SKIP_HOOK_VERIFY") != ""
 
+	if !force {
+		// Lookup errors fall through: the update below surfaces real DB problems.
+		if current, err := getBeadInfo(beadID); err == nil {
+			if err := checkHookOwner(beadID, targetAgent, current, isHookedAgentDeadFn); err != nil {
+				return err
+			}
+		}
+	}
+
 	var lastErr error
 	for attempt := 1; attempt <= maxRetries; attempt++ {
 		err := BdCmd("update", beadID, "--status=hooked", "--assignee="+targetAgent).
@@ -3,6 +3,7 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"os/exec"
 	"path/filepath"
 	"strings"
 	"testing"
@@ -140,6 +141,32 @@ func TestIsDeferredBead(t *testing.T) {
 	}
 }
 
+func TestVerifyBeadSlingable(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  string
+		force   bool
+		wantErr bool
+	}{
+		{"open bead", "open", false, false},
+		{"hooked bead", "hooked", false, false},
+		{"closed bead rejected", "closed", false, true},
+		{"closed bead with force", "closed", true, false},
+		{"tombstone bead rejected", "tombstone", false, true},
+		{"tombstone bead with force", "tombstone", true, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			info := &beadInfo{Title: "Some work", Status: tt.status}
+			err := verifyBeadSlingable("gt-abc", info, tt.force)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("verifyBeadSlingable(status=%q, force=%v) = %v, wantErr %v", tt.status, tt.force, err, tt.wantErr)
+			}
+		})
+	}
+}
+
 func TestIsSlingConfigError(t *testing.T) {
 	tests := []struct {
 		name string
@@ -166,3 +193,91 @@ func TestIsSlingConfigError(t *testing.T) {
 		})
 	}
 }
+
+func TestDetectCloneRootFrom(t *testing.T) {
+	// GIT_CEILING_DIRECTORIES keeps git from finding a repo above the temp dir.
+	t.Setenv("GIT_CEILING_DIRECTORIES", os.TempDir())
+
+	t.Run("git repository", func(t *testing.T) {
+		if _, err := exec.LookPath("git"); err != nil {
+			t.Skip("git not installed")
+		}
+		root := t.TempDir()
+		if out, err := exec.Command("git", "init", root).CombinedOutput(); err != nil {
+			t.Fatalf("git init: %v\n%s", err, out)
+		}
+		sub := filepath.Join(root, "internal", "pkg")
+		if err := os.MkdirAll(sub, 0755); err != nil {
+			t.Fatal(err)
+		}
+
+		got, err := detectCloneRootFrom(sub)
+		if err != nil {
+			t.Fatalf("detectCloneRootFrom: %v", err)
+		}
+		want, _ := filepath.EvalSymlinks(root)
+		if got, _ = filepath.EvalSymlinks(got); got != want {
+			t.Errorf("clone root = %q, want %q", got, want)
+		}
+	})
+
+	t.Run("marker directory fallback", func(t *testing.T) {
+		root := t.TempDir()
+		if err := os.MkdirAll(filepath.Join(root, ".beads-wisp"), 0755); err != nil {
+			t.Fatal(err)
+		}
+		sub := filepath.Join(root, "a", "b")
+		if err := os.MkdirAll(sub, 0755); err != nil {
+			t.Fatal(err)
+		}
+
+		got, err := detectCloneRootFrom(sub)
+		if err != nil {
+			t.Fatalf("detectCloneRootFrom: %v", err)
+		}
+		if got != root {
+			t.Errorf("clone root = %q, want %q", got, root)
+		}
+	})
+
+	t.Run("no git and no marker", func(t *testing.T) {
+		if _, err := detectCloneRootFrom(t.TempDir()); err == nil {
+			t.Error("expected error outside any git repository or marker directory")
+		}
+	})
+}
+
+func TestValidateSlingPriority(t *testing.T) {
+	for _, p := range []int{-1, 0, 2, 4} {
+		if err := validateSlingPriority(p); err != nil {
+			t.Errorf("validateSlingPriority(%d) = %v, want nil", p, err)
+		}
+	}
+	for _, p := range []int{-2, 5, 99} {
+		if err := validateSlingPriority(p); err == nil {
+			t.Errorf("validateSlingPriority(%d) = nil, want error", p)
+		}
+	}
+}
+
+func TestBumpBeadPriority(t *testing.T) {
+	binDir := t.TempDir()
+	logPath := filepath.Join(t.TempDir(), "bd.log")
+	writeBDStub(t, binDir,
+		"#!/bin/sh\necho \"$*\" >> \"${BD_LOG}\"\n",
+		"@echo off\r\necho %*>>\"%BD_LOG%\"\r\n")
+	t.Setenv("BD_LOG", logPath)
+	t.Setenv("PATH", binDir+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	if err := bumpBeadPriority("gt-abc123", 0, t.TempDir()); err != nil {
+		t.Fatalf("bumpBeadPriority: %v", err)
+	}
+
+	data, err := os.ReadFile(logPath)
+	if err != nil {
+		t.Fatalf("read bd log: %v", err)
+	}
+	if got := strings.TrimSpace(string(data)); got != "update gt-abc123 --priority 0" {
+		t.Errorf("bd args = %q, want %q", got, "update gt-abc123 --priority 0")
+	}
+}
@@ -0,0 +1,147 @@
+package cmd
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/wisp"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+var (
+	slingHistoryLimit int
+	slingHistoryAgent string
+)
+
+var slingHistoryCmd = &cobra.Command{
+	Use:   "history",
+	Short: "Show what an agent has slung recently",
+	Long: `Show the most recent beads slung by an agent, oldest first.
+
+Every successful sling appends the bead, subject, and target to the slinging
+agent's history under the town's .beads-wisp directory (local, never synced).
+Use it to see how much work you've been re-slinging across restarts.
+
+Examples:
+  gt sling history                          # Your last 20 slings
+  gt sling history -n 5                     # Your last 5 slings
+  gt sling history --agent gastown/crew/max # Another agent's history`,
+	Args: cobra.NoArgs,
+	RunE: runSlingHistory,
+}
+
+func init() {
+	slingHistoryCmd.Flags().IntVarP(&slingHistoryLimit, "limit", "n", 20, "Number of entries to show (0 = all)")
+	slingHistoryCmd.Flags().StringVar(&slingHistoryAgent, "agent", "", "Agent whose history to show (default: you)")
+	slingCmd.AddCommand(slingHistoryCmd)
+}
+
+// slingHistoryEntry is one line of an agent's sling history.
+type slingHistoryEntry struct {
+	Timestamp time.Time `json:"timestamp"`
+	Bead      string    `json:"bead"`
+	Subject   string    `json:"subject,omitempty"`
+	Target    string    `json:"target"`
+}
+
+// slingHistoryPath returns the history file for agent (e.g. gastown/crew/max
+// → <town>/.beads-wisp/gastown/crew/max.history.jsonl).
+func slingHistoryPath(townRoot, agent string) string {
+	return filepath.Join(townRoot, wisp.WispConfigDir, filepath.FromSlash(strings.Trim(agent, "/"))+".history.jsonl")
+}
+
+// recordSlingHistory appends a sling of beadID to target to agent's history.
+// Best-effort: failures are ignored so history never blocks a sling.
+func recordSlingHistory(townRoot, agent, beadID, subject, target string) {
+	if townRoot == "" || strings.Trim(agent, "/") == "" {
+		return
+	}
+
+	line, err := json.Marshal(slingHistoryEntry{
+		Timestamp: time.Now().UTC(),
+		Bead:      beadID,
+		Subject:   subject,
+		Target:    target,
+	})
+	if err != nil {
+		return
+	}
+
+	path := slingHistoryPath(townRoot, agent)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return
+	}
+	defer f.Close()
+	_, _ = f.Write(append(line, '\n'))
+}
+
+// readSlingHistory returns the last limit entries of agent's history, oldest
+// first (limit <= 0 returns all). Malformed lines are skipped.
+func readSlingHistory(townRoot, agent string, limit int) ([]slingHistoryEntry, error) {
+	f, err := os.Open(slingHistoryPath(townRoot, agent))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var entries []slingHistoryEntry
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var e slingHistoryEntry
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			continue
+		}
+		entries = append(entries, e)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if limit > 0 && len(entries) > limit {
+		entries = entries[len(entries)-limit:]
+	}
+	return entries, nil
+}
+
+func runSlingHistory(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return err
+	}
+	agent := slingHistoryAgent
+	if agent == "" {
+		agent = detectActor()
+	}
+
+	entries, err := readSlingHistory(townRoot, agent, slingHistoryLimit)
+	if err != nil {
+		return fmt.Errorf("reading sling history: %w", err)
+	}
+	if len(entries) == 0 {
+		fmt.Printf("No sling history for %s\n", agent)
+		return nil
+	}
+
+	fmt.Printf("%s %s (last %d)\n", style.Bold.Render("Sling history:"), agent, len(entries))
+	for _, e := range entries {
+		subject := ""
+		if e.Subject != "" {
+			subject = " " + style.Dim.Render(e.Subject)
+		}
+		fmt.Printf("  %s  %s → %s%s\n", e.Timestamp.Local().Format("2006-01-02 15:04"), e.Bead, e.Target, subject)
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+// TestSlingRecordsHistory verifies that each sling appends to the slinging
+// agent's history, in order.
+func TestSlingRecordsHistory(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("shell stubs not supported on windows")
+	}
+
+	townRoot := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(townRoot, "mayor", "rig"), 0755); err != nil {
+		t.Fatalf("mkdir mayor/rig: %v", err)
+	}
+
+	binDir := filepath.Join(townRoot, "bin")
+	if err := os.MkdirAll(binDir, 0755); err != nil {
+		t.Fatalf("mkdir binDir: %v", err)
+	}
+	bdScript := `#!/bin/sh
+case "$1" in
+  show)
+    echo '[{"title":"Test issue","status":"open","assignee":"","description":""}]'
+    ;;
+esac
+exit 0
+`
+	_ = writeBDStub(t, binDir, bdScript, "")
+
+	t.Setenv("GT_TEST_ATTACHED_MOLECULE_LOG", filepath.Join(townRoot, "mol.log"))
+	t.Setenv("PATH", binDir+string(os.PathListSeparator)+os.Getenv("PATH"))
+	t.Setenv(EnvGTRole, "mayor")
+	t.Setenv("GT_CREW", "")
+	t.Setenv("GT_POLECAT", "")
+	t.Setenv("TMUX_PANE", "")
+	t.Setenv("GT_TEST_NO_NUDGE", "1")
+	t.Setenv("GT_TEST_SKIP_HOOK_VERIFY", "1")
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("getwd: %v", err)
+	}
+	t.Cleanup(func() { _ = os.Chdir(cwd) })
+	if err := os.Chdir(filepath.Join(townRoot, "mayor", "rig")); err != nil {
+		t.Fatalf("chdir: %v", err)
+	}
+
+	prevDryRun, prevNoConvoy, prevSubject := slingDryRun, slingNoConvoy, slingSubject
+	t.Cleanup(func() { slingDryRun, slingNoConvoy, slingSubject = prevDryRun, prevNoConvoy, prevSubject })
+	slingDryRun = false
+	slingNoConvoy = true
+
+	for _, beadID := range []string{"gt-first", "gt-second"} {
+		slingSubject = "work on " + beadID
+		if err := runSling(nil, []string{beadID}); err != nil {
+			t.Fatalf("runSling(%s): %v", beadID, err)
+		}
+	}
+
+	agent := detectActor()
+	data, err := os.ReadFile(slingHistoryPath(townRoot, agent))
+	if err != nil {
+		t.Fatalf("reading history for %s: %v", agent, err)
+	}
+	if lines := strings.Split(strings.TrimSpace(string(data)), "\n"); len(lines) != 2 {
+		t.Fatalf("history has %d lines, want 2:\n%s", len(lines), data)
+	}
+
+	entries, err := readSlingHistory(townRoot, agent, 0)
+	if err != nil {
+		t.Fatalf("readSlingHistory: %v", err)
+	}
+	if len(entries) != 2 || entries[0].Bead != "gt-first" || entries[1].Bead != "gt-second" {
+		t.Fatalf("history = %+v, want gt-first then gt-second", entries)
+	}
+	if entries[1].Subject != "work on gt-second" {
+		t.Errorf("subject = %q, want %q", entries[1].Subject, "work on gt-second")
+	}
+
+	last, err := readSlingHistory(townRoot, agent, 1)
+	if err != nil || len(last) != 1 || last[0].Bead != "gt-second" {
+		t.Errorf("readSlingHistory(limit 1) = %+v, %v; want just gt-second", last, err)
+	}
+}
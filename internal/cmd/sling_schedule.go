@@ -5,13 +5,11 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
-	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/steveyegge/gastown/internal/beads"
 	"github.com/steveyegge/gastown/internal/config"
-	"github.com/steveyegge/gastown/internal/events"
-	"github.com/steveyegge/gastown/internal/scheduler/capacity"
+	"github.com/steveyegge/gastown/internal/queue"
 	"github.com/steveyegge/gastown/internal/style"
 	"github.com/steveyegge/gastown/internal/workspace"
 )
@@ -44,156 +42,39 @@ func shouldDeferDispatch() (bool, error) {
 }
 
 // ScheduleOptions holds options for scheduling a bead.
-type ScheduleOptions struct {
-	Formula     string   // Formula to apply at dispatch time (e.g., "mol-polecat-work")
-	Args        string   // Natural language args for executor
-	Vars        []string // Formula variables (key=value)
-	Merge       string   // Merge strategy: direct/mr/local
-	BaseBranch  string   // Override base branch for polecat worktree
-	NoConvoy    bool     // Skip auto-convoy creation
-	Owned       bool     // Mark auto-convoy as caller-managed lifecycle
-	DryRun      bool     // Show what would be done without acting
-	Force       bool     // Force schedule even if bead is hooked/in_progress
-	NoMerge     bool     // Skip merge queue on completion
-	Account     string   // Claude Code account handle
-	Agent       string   // Agent override (e.g., "gemini", "codex")
-	HookRawBead bool     // Hook raw bead without default formula
-	Ralph       bool     // Ralph Wiggum loop mode
+type ScheduleOptions = queue.Options
+
+// newEnqueuer returns a queue.Enqueuer wired to the command layer's bead,
+// rig, formula and convoy lookups.
+func newEnqueuer() *queue.Enqueuer {
+	e := queue.New("",
+		func(beadID string) (*queue.BeadInfo, error) {
+			info, err := getBeadInfo(beadID)
+			if err != nil {
+				return nil, err
+			}
+			return &queue.BeadInfo{Title: info.Title, Status: info.Status, Assignee: info.Assignee}, nil
+		},
+		func(name string) bool {
+			_, isRig := IsRigName(name)
+			return isRig
+		},
+	)
+	e.CrossRigGuard = func(beadID, rig, townRoot string) error {
+		return checkCrossRigGuard(beadID, rig+"/polecats/_", townRoot)
+	}
+	e.VerifyFormula = verifyFormulaExists
+	e.CookFormula = CookFormula
+	e.TrackingConvoy = isTrackedByConvoy
+	e.CreateConvoy = createAutoConvoy
+	e.Actor = detectActor
+	return e
 }
 
 // scheduleBead schedules a bead for deferred dispatch via the capacity scheduler.
 // Creates a sling context bead to hold scheduling state. The work bead is never modified.
 func scheduleBead(beadID, rigName string, opts ScheduleOptions) error {
-	townRoot, err := workspace.FindFromCwdOrError()
-	if err != nil {
-		return err
-	}
-
-	if err := verifyBeadExists(beadID); err != nil {
-		return fmt.Errorf("bead '%s' not found", beadID)
-	}
-
-	if _, isRig := IsRigName(rigName); !isRig {
-		return fmt.Errorf("'%s' is not a known rig", rigName)
-	}
-
-	if !opts.Force {
-		if err := checkCrossRigGuard(beadID, rigName+"/polecats/_", townRoot); err != nil {
-			return err
-		}
-	}
-
-	info, err := getBeadInfo(beadID)
-	if err != nil {
-		return fmt.Errorf("checking bead status: %w", err)
-	}
-
-	// Idempotency: check for existing open sling context for this work bead.
-	// Fail fast on errors to avoid creating duplicate contexts on transient DB failures.
-	townBeads := beads.NewWithBeadsDir(townRoot, filepath.Join(townRoot, ".beads"))
-	existingCtx, _, findErr := townBeads.FindOpenSlingContext(beadID)
-	if findErr != nil {
-		return fmt.Errorf("checking for existing sling context: %w", findErr)
-	}
-	if existingCtx != nil {
-		fmt.Printf("%s Bead %s is already scheduled (context: %s), no-op\n",
-			style.Dim.Render("○"), beadID, existingCtx.ID)
-		return nil
-	}
-
-	if (info.Status == "pinned" || info.Status == "hooked" || info.Status == "in_progress") && !opts.Force {
-		return fmt.Errorf("bead %s is already %s to %s\nUse --force to override", beadID, info.Status, info.Assignee)
-	}
-
-	if opts.Formula != "" {
-		if err := verifyFormulaExists(opts.Formula); err != nil {
-			return fmt.Errorf("formula %q not found: %w", opts.Formula, err)
-		}
-	}
-
-	if opts.DryRun {
-		fmt.Printf("Would schedule %s → %s\n", beadID, rigName)
-		fmt.Printf("  Would create sling context bead\n")
-		if !opts.NoConvoy {
-			fmt.Printf("  Would create auto-convoy\n")
-		}
-		return nil
-	}
-
-	// Cook formula after dry-run check to avoid side effects
-	if opts.Formula != "" {
-		workDir := beads.ResolveHookDir(townRoot, beadID, "")
-		if err := CookFormula(opts.Formula, workDir, townRoot); err != nil {
-			return fmt.Errorf("formula %q failed to cook: %w", opts.Formula, err)
-		}
-	}
-
-	// Build sling context fields
-	fields := &capacity.SlingContextFields{
-		Version:    1,
-		WorkBeadID: beadID,
-		TargetRig:  rigName,
-		EnqueuedAt: time.Now().UTC().Format(time.RFC3339),
-	}
-	if opts.Formula != "" {
-		fields.Formula = opts.Formula
-	}
-	if opts.Args != "" {
-		fields.Args = opts.Args
-	}
-	if len(opts.Vars) > 0 {
-		fields.Vars = strings.Join(opts.Vars, "\n")
-	}
-	if opts.Merge != "" {
-		fields.Merge = opts.Merge
-	}
-	if opts.BaseBranch != "" {
-		fields.BaseBranch = opts.BaseBranch
-	}
-	fields.NoMerge = opts.NoMerge
-	if opts.Account != "" {
-		fields.Account = opts.Account
-	}
-	if opts.Agent != "" {
-		fields.Agent = opts.Agent
-	}
-	fields.HookRawBead = opts.HookRawBead
-	if opts.Ralph {
-		fields.Mode = "ralph"
-	}
-	fields.Owned = opts.Owned
-
-	// Create sling context bead — single atomic operation. No two-step write.
-	ctxBead, err := townBeads.CreateSlingContext(info.Title, beadID, fields)
-	if err != nil {
-		return fmt.Errorf("creating sling context: %w", err)
-	}
-
-	// Auto-convoy (unless --no-convoy)
-	if !opts.NoConvoy {
-		existingConvoy := isTrackedByConvoy(beadID)
-		if existingConvoy == "" {
-			convoyID, err := createAutoConvoy(beadID, info.Title, opts.Owned, opts.Merge)
-			if err != nil {
-				fmt.Printf("%s Could not create auto-convoy: %v\n", style.Dim.Render("Warning:"), err)
-			} else {
-				fmt.Printf("%s Created convoy %s\n", style.Bold.Render("→"), convoyID)
-				// Update the context bead fields with convoy ID
-				fields.Convoy = convoyID
-				if updateErr := townBeads.UpdateSlingContextFields(ctxBead.ID, fields); updateErr != nil {
-					fmt.Printf("%s Could not update context with convoy: %v\n", style.Dim.Render("Warning:"), updateErr)
-				}
-			}
-		} else {
-			fmt.Printf("%s Already tracked by convoy %s\n", style.Dim.Render("○"), existingConvoy)
-		}
-	}
-
-	actor := detectActor()
-	_ = events.LogFeed(events.TypeSchedulerEnqueue, actor, events.SchedulerEnqueuePayload(beadID, rigName))
-
-	fmt.Printf("%s Scheduled %s → %s (context: %s)\n", style.Bold.Render("✓"), beadID, rigName, ctxBead.ID)
-	return nil
+	return newEnqueuer().Enqueue(beadID, rigName, opts)
 }
 
 // runBatchSchedule schedules multiple beads for deferred dispatch.
@@ -227,6 +108,7 @@ func runBatchSchedule(beadIDs []string, rigName string) error {
 			Agent:       slingAgent,
 			HookRawBead: slingHookRawBead,
 			Ralph:       slingRalph,
+			TTL:         slingTTL,
 		})
 		if err != nil {
 			fmt.Printf("  %s %s: %v\n", style.Dim.Render("✗"), beadID, err)
@@ -242,13 +124,62 @@ func runBatchSchedule(beadIDs []string, rigName string) error {
 	return nil
 }
 
-// resolveRigForBead determines the rig that owns a bead from its ID prefix.
+// resolveRigForBead determines the rig that owns a bead from its ID prefix,
+// preferring the longest prefix that routes to a rig.
 func resolveRigForBead(townRoot, beadID string) string {
-	prefix := beads.ExtractPrefix(beadID)
-	if prefix == "" {
-		return ""
+	return rigForLongestPrefix(beadID, func(prefix string) string {
+		return beads.GetRigNameForPrefix(townRoot, prefix)
+	})
+}
+
+// rigForLongestPrefix tries beadID's prefixes longest first ("hq-cv-" before
+// "hq-") and returns the first rig that lookup knows, so beads with compound
+// prefixes resolve to their own route rather than the shorter one's.
+func rigForLongestPrefix(beadID string, lookup func(prefix string) string) string {
+	for n := strings.Count(beadID, "-"); n >= 1; n-- {
+		prefix := beads.ExtractPrefixN(beadID, n)
+		if prefix == "" {
+			continue
+		}
+		if rigName := lookup(prefix); rigName != "" {
+			return rigName
+		}
+	}
+	return ""
+}
+
+// rigResolver maps bead IDs to rig names for a single dispatch run, caching
+// each prefix so a large convoy doesn't re-scan the town's rig mappings for
+// every issue. Create one per invocation; it never invalidates.
+type rigResolver struct {
+	townRoot string
+	lookup   func(townRoot, prefix string) string
+	cache    map[string]string
+	forced   string // if set, every bead resolves to this rig
+}
+
+// newRigResolver returns a rigResolver backed by beads.GetRigNameForPrefix.
+func newRigResolver(townRoot string) *rigResolver {
+	return &rigResolver{
+		townRoot: townRoot,
+		lookup:   beads.GetRigNameForPrefix,
+		cache:    make(map[string]string),
 	}
-	return beads.GetRigNameForPrefix(townRoot, prefix)
+}
+
+// resolve returns the rig that owns beadID, or "" if its prefix is unknown.
+func (r *rigResolver) resolve(beadID string) string {
+	if r.forced != "" {
+		return r.forced
+	}
+	return rigForLongestPrefix(beadID, func(prefix string) string {
+		if rigName, ok := r.cache[prefix]; ok {
+			return rigName
+		}
+		rigName := r.lookup(r.townRoot, prefix)
+		r.cache[prefix] = rigName
+		return rigName
+	})
 }
 
 // resolveFormula determines the formula name from user flags.
@@ -356,7 +287,7 @@ func detectSchedulerIDType(id string) (string, error) {
 // not convoy or epic mode.
 var schedulerTaskOnlyFlagNames = []string{
 	"account", "agent", "ralph", "args", "var",
-	"merge", "base-branch", "no-convoy", "owned", "no-merge",
+	"merge", "base-branch", "no-convoy", "owned", "no-merge", "priority",
 }
 
 // validateNoTaskOnlySchedulerFlags checks that no task-only flags were set.
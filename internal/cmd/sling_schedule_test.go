@@ -1,8 +1,13 @@
 package cmd
 
 import (
+	"fmt"
 	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+
+	"github.com/spf13/cobra"
 )
 
 // TestAreScheduledFailClosed verifies that areScheduled fails closed when
@@ -40,3 +45,92 @@ func TestAreScheduledEmptyInput(t *testing.T) {
 		t.Errorf("areScheduled([]) should return empty map, got %d entries", len(result))
 	}
 }
+
+func TestRigResolverCachesPrefix(t *testing.T) {
+	calls := 0
+	r := &rigResolver{
+		townRoot: "/town",
+		lookup: func(townRoot, prefix string) string {
+			calls++
+			if prefix == "gt-" {
+				return "gastown"
+			}
+			return ""
+		},
+		cache: make(map[string]string),
+	}
+
+	for i := 0; i < 10; i++ {
+		if got := r.resolve(fmt.Sprintf("gt-%d", i)); got != "gastown" {
+			t.Fatalf("resolve(gt-%d) = %q, want gastown", i, got)
+		}
+	}
+	if calls != 1 {
+		t.Errorf("lookup called %d times for one prefix, want 1", calls)
+	}
+
+	// Unknown prefixes are cached too.
+	r.resolve("zz-1")
+	r.resolve("zz-2")
+	if calls != 2 {
+		t.Errorf("lookup called %d times after unknown prefix, want 2", calls)
+	}
+}
+
+func TestRigResolverPrefersLongestPrefix(t *testing.T) {
+	r := &rigResolver{
+		townRoot: "/town",
+		lookup: func(townRoot, prefix string) string {
+			return map[string]string{"hq-": "town", "hq-cv-": "convoys"}[prefix]
+		},
+		cache: make(map[string]string),
+	}
+
+	tests := map[string]string{
+		"hq-abc":     "town",    // single segment
+		"hq-cv-abc":  "convoys", // two segments
+		"hq-mol-abc": "town",    // unknown longer prefix falls back to the shorter one
+		"zz-cv-abc":  "",
+	}
+	for beadID, want := range tests {
+		if got := r.resolve(beadID); got != want {
+			t.Errorf("resolve(%q) = %q, want %q", beadID, got, want)
+		}
+	}
+}
+
+func TestResolveRigForBead_LongestPrefixWins(t *testing.T) {
+	townRoot := t.TempDir()
+	beadsDir := filepath.Join(townRoot, ".beads")
+	if err := os.MkdirAll(beadsDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	routes := `{"prefix":"gt-","path":"gastown/mayor/rig"}
+{"prefix":"gt-cv-","path":"convoys/mayor/rig"}
+`
+	if err := os.WriteFile(filepath.Join(beadsDir, "routes.jsonl"), []byte(routes), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := resolveRigForBead(townRoot, "gt-cv-abc"); got != "convoys" {
+		t.Errorf("resolveRigForBead(gt-cv-abc) = %q, want convoys", got)
+	}
+	if got := resolveRigForBead(townRoot, "gt-abc"); got != "gastown" {
+		t.Errorf("resolveRigForBead(gt-abc) = %q, want gastown", got)
+	}
+}
+
+func TestValidateNoTaskOnlySchedulerFlags_RejectsPriority(t *testing.T) {
+	cmd := &cobra.Command{Use: "sling"}
+	cmd.Flags().Int("priority", -1, "")
+	if err := cmd.Flags().Set("priority", "1"); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, mode := range []string{"convoy", "epic"} {
+		err := validateNoTaskOnlySchedulerFlags(cmd, mode)
+		if err == nil || !strings.Contains(err.Error(), "--priority") {
+			t.Errorf("%s mode: err = %v, want --priority rejected", mode, err)
+		}
+	}
+}
@@ -53,33 +53,77 @@ func sessionToAgentID(sessionName string) string {
 	return identity.Address()
 }
 
-// resolveSelfTarget determines agent identity, pane, and hook root for slinging to self.
-func resolveSelfTarget() (agentID string, pane string, hookRoot string, err error) {
-	roleInfo, err := GetRole()
-	if err != nil {
-		return "", "", "", fmt.Errorf("detecting role: %w", err)
-	}
-
-	// Build agent identity from role
-	// Town-level agents use trailing slash to match addressToIdentity() normalization
+// agentIdentityFromRole converts detected role info into a parsed agent
+// identity. The boot watchdog is a deacon named "boot", matching
+// session.ParseSessionName.
+func agentIdentityFromRole(roleInfo RoleInfo) (*session.AgentIdentity, error) {
 	switch roleInfo.Role {
 	case RoleMayor:
-		agentID = "mayor/"
+		return &session.AgentIdentity{Role: session.RoleMayor}, nil
 	case RoleDeacon:
-		agentID = "deacon/"
+		return &session.AgentIdentity{Role: session.RoleDeacon}, nil
 	case RoleBoot:
-		agentID = "deacon/boot"
+		return &session.AgentIdentity{Role: session.RoleDeacon, Name: "boot"}, nil
 	case RoleWitness:
-		agentID = fmt.Sprintf("%s/witness", roleInfo.Rig)
+		return &session.AgentIdentity{Role: session.RoleWitness, Rig: roleInfo.Rig}, nil
 	case RoleRefinery:
-		agentID = fmt.Sprintf("%s/refinery", roleInfo.Rig)
+		return &session.AgentIdentity{Role: session.RoleRefinery, Rig: roleInfo.Rig}, nil
 	case RolePolecat:
-		agentID = fmt.Sprintf("%s/polecats/%s", roleInfo.Rig, roleInfo.Polecat)
+		return &session.AgentIdentity{Role: session.RolePolecat, Rig: roleInfo.Rig, Name: roleInfo.Polecat}, nil
 	case RoleCrew:
-		agentID = fmt.Sprintf("%s/crew/%s", roleInfo.Rig, roleInfo.Polecat)
+		return &session.AgentIdentity{Role: session.RoleCrew, Rig: roleInfo.Rig, Name: roleInfo.Polecat}, nil
 	default:
-		return "", "", "", fmt.Errorf("cannot determine agent identity (role: %s)", roleInfo.Role)
+		return nil, fmt.Errorf("cannot determine agent identity (role: %s)", roleInfo.Role)
+	}
+}
+
+// selfAgentID formats an identity as the agent ID used for hook assignees.
+// Town-level agents use trailing slash to match addressToIdentity() normalization.
+func selfAgentID(identity *session.AgentIdentity) string {
+	switch identity.Role {
+	case session.RoleMayor:
+		return "mayor/"
+	case session.RoleDeacon:
+		if identity.Name != "" {
+			return "deacon/" + identity.Name
+		}
+		return "deacon/"
+	default:
+		return identity.Address()
+	}
+}
+
+// parseAgentID parses an agent ID such as a sling target or hook assignee.
+// Unlike session.ParseAddress it knows the town-level agents, so "deacon/boot"
+// is the boot watchdog (as in agentIdentityFromRole) rather than a polecat
+// named boot in a rig called deacon.
+func parseAgentID(agentID string) (*session.AgentIdentity, error) {
+	trimmed := strings.TrimSuffix(strings.TrimSpace(agentID), "/")
+	switch {
+	case trimmed == "mayor":
+		return &session.AgentIdentity{Role: session.RoleMayor}, nil
+	case trimmed == "deacon":
+		return &session.AgentIdentity{Role: session.RoleDeacon}, nil
+	case trimmed == "deacon/boot":
+		return &session.AgentIdentity{Role: session.RoleDeacon, Name: "boot"}, nil
+	case strings.HasPrefix(trimmed, "mayor/"), strings.HasPrefix(trimmed, "deacon/"):
+		return nil, fmt.Errorf("unknown town-level agent %q", agentID)
+	}
+	return session.ParseAddress(agentID)
+}
+
+// resolveSelfTarget determines agent identity, pane, and hook root for slinging to self.
+func resolveSelfTarget() (agentID string, pane string, hookRoot string, err error) {
+	roleInfo, err := GetRole()
+	if err != nil {
+		return "", "", "", fmt.Errorf("detecting role: %w", err)
+	}
+
+	identity, err := agentIdentityFromRole(roleInfo)
+	if err != nil {
+		return "", "", "", err
 	}
+	agentID = selfAgentID(identity)
 
 	pane = os.Getenv("TMUX_PANE")
 	hookRoot = roleInfo.Home
@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"bytes"
+	"errors"
 	"os"
 	"path/filepath"
 	"runtime"
@@ -11,6 +12,7 @@ import (
 
 	"github.com/steveyegge/gastown/internal/beads"
 	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/session"
 )
 
 func writeBDStub(t *testing.T, binDir string, unixScript string, windowsScript string) string {
@@ -1426,6 +1428,45 @@ func TestIsHookedAgentDead_NoTmuxSession(t *testing.T) {
 	_ = result
 }
 
+func TestCheckHookOwner(t *testing.T) {
+	alive := func(string) bool { return false }
+	dead := func(string) bool { return true }
+
+	tests := []struct {
+		name     string
+		info     *beadInfo
+		isDead   func(string) bool
+		conflict bool
+	}{
+		{"same agent overwrite", &beadInfo{Status: "hooked", Assignee: "gastown/polecats/nux"}, alive, false},
+		{"same agent trailing slash", &beadInfo{Status: "hooked", Assignee: "gastown/polecats/nux/"}, alive, false},
+		{"different live agent", &beadInfo{Status: "hooked", Assignee: "gastown/crew/max"}, alive, true},
+		{"different live agent in progress", &beadInfo{Status: "in_progress", Assignee: "gastown/crew/max"}, alive, true},
+		{"different dead agent", &beadInfo{Status: "hooked", Assignee: "gastown/crew/max"}, dead, false},
+		{"open bead", &beadInfo{Status: "open", Assignee: "gastown/crew/max"}, alive, false},
+		{"unassigned", &beadInfo{Status: "hooked"}, alive, false},
+		{"no info", nil, alive, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkHookOwner("gt-abc", "gastown/polecats/nux", tt.info, tt.isDead)
+			if !tt.conflict {
+				if err != nil {
+					t.Fatalf("checkHookOwner() = %v, want nil", err)
+				}
+				return
+			}
+			var conflict *hookConflictError
+			if !errors.As(err, &conflict) {
+				t.Fatalf("checkHookOwner() = %v, want hookConflictError", err)
+			}
+			if conflict.Owner != "gastown/crew/max" || !strings.Contains(err.Error(), "gastown/crew/max") {
+				t.Errorf("conflict error should name owner gastown/crew/max: %v", err)
+			}
+		})
+	}
+}
+
 func TestSlingSetsDoltAutoCommitOff(t *testing.T) {
 	townRoot := t.TempDir()
 
@@ -2265,6 +2306,7 @@ exit /b 0
 		})
 	}
 }
+
 // TestSlingRejectsDeferredBead verifies that gt sling refuses to sling beads
 // with deferred status or deferral keywords in their description (gt-1326mw).
 // This prevents wasting polecat slots on low-priority deferred work.
@@ -2365,3 +2407,104 @@ func TestSlingRejectsDeferredBead(t *testing.T) {
 		})
 	}
 }
+
+func TestAgentIdentityFromRole(t *testing.T) {
+	tests := []struct {
+		name    string
+		info    RoleInfo
+		role    session.Role
+		rig     string
+		agent   string
+		agentID string
+	}{
+		{"crew", RoleInfo{Role: RoleCrew, Rig: "gastown", Polecat: "max"}, session.RoleCrew, "gastown", "max", "gastown/crew/max"},
+		{"polecat", RoleInfo{Role: RolePolecat, Rig: "gastown", Polecat: "Toast"}, session.RolePolecat, "gastown", "Toast", "gastown/polecats/Toast"},
+		{"witness", RoleInfo{Role: RoleWitness, Rig: "gastown"}, session.RoleWitness, "gastown", "", "gastown/witness"},
+		{"refinery", RoleInfo{Role: RoleRefinery, Rig: "gastown"}, session.RoleRefinery, "gastown", "", "gastown/refinery"},
+		{"mayor", RoleInfo{Role: RoleMayor}, session.RoleMayor, "", "", "mayor/"},
+		{"deacon", RoleInfo{Role: RoleDeacon}, session.RoleDeacon, "", "", "deacon/"},
+		{"boot", RoleInfo{Role: RoleBoot}, session.RoleDeacon, "", "boot", "deacon/boot"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := agentIdentityFromRole(tt.info)
+			if err != nil {
+				t.Fatalf("agentIdentityFromRole: %v", err)
+			}
+			if got.Role != tt.role || got.Rig != tt.rig || got.Name != tt.agent {
+				t.Errorf("identity = %+v, want role=%s rig=%q name=%q", got, tt.role, tt.rig, tt.agent)
+			}
+			if id := selfAgentID(got); id != tt.agentID {
+				t.Errorf("selfAgentID = %q, want %q", id, tt.agentID)
+			}
+		})
+	}
+
+	if _, err := agentIdentityFromRole(RoleInfo{Role: RoleUnknown}); err == nil {
+		t.Error("expected error for unknown role")
+	}
+}
+
+func TestReadSlingMessage(t *testing.T) {
+	orig := slingMessage
+	t.Cleanup(func() { slingMessage = orig })
+
+	body := "Fix the flaky merge test.\n\n  - repro: go test -count=50 ./internal/refinery\n\t- keep the retry\n"
+	slingMessage = ""
+	if err := readSlingMessage(strings.NewReader(body)); err != nil {
+		t.Fatalf("readSlingMessage() = %v", err)
+	}
+	if want := strings.TrimRight(body, "\n"); slingMessage != want {
+		t.Errorf("slingMessage = %q, want %q", slingMessage, want)
+	}
+
+	slingMessage = "from -m"
+	err := readSlingMessage(strings.NewReader(body))
+	if err == nil || !strings.Contains(err.Error(), "--message") {
+		t.Errorf("readSlingMessage() with -m set = %v, want conflict error", err)
+	}
+	if slingMessage != "from -m" {
+		t.Errorf("slingMessage = %q, want -m value left alone", slingMessage)
+	}
+}
+
+func TestParseAgentID(t *testing.T) {
+	tests := []struct {
+		agentID string
+		role    session.Role
+		rig     string
+		name    string
+	}{
+		{"mayor", session.RoleMayor, "", ""},
+		{"mayor/", session.RoleMayor, "", ""},
+		{"deacon/", session.RoleDeacon, "", ""},
+		{"deacon/boot", session.RoleDeacon, "", "boot"},
+		{"gastown/witness", session.RoleWitness, "gastown", ""},
+		{"gastown/crew/max", session.RoleCrew, "gastown", "max"},
+		{"gastown/polecats/Toast", session.RolePolecat, "gastown", "Toast"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.agentID, func(t *testing.T) {
+			got, err := parseAgentID(tt.agentID)
+			if err != nil {
+				t.Fatalf("parseAgentID(%q): %v", tt.agentID, err)
+			}
+			if got.Role != tt.role || got.Rig != tt.rig || got.Name != tt.name {
+				t.Errorf("parseAgentID(%q) = %+v, want role=%s rig=%q name=%q", tt.agentID, got, tt.role, tt.rig, tt.name)
+			}
+		})
+	}
+
+	if _, err := parseAgentID("deacon/dogs/alpha"); err == nil {
+		t.Error("expected error for unknown town-level agent")
+	}
+}
+
+func TestCheckCrossRigGuard_TownLevelTargets(t *testing.T) {
+	townRoot := t.TempDir()
+	for _, target := range []string{"mayor/", "deacon/boot"} {
+		if err := checkCrossRigGuard("gt-abc", target, townRoot); err != nil {
+			t.Errorf("checkCrossRigGuard(%q) = %v, want nil for a town-level target", target, err)
+		}
+	}
+}
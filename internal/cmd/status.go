@@ -44,6 +44,7 @@ var statusCmd = &cobra.Command{
 Shows town name, registered rigs, polecats, and witness status.
 
 Use --fast to skip mail lookups for faster execution.
+Use --sessions to list every session as alive or dead, with its hooked bead.
 Use --watch to continuously refresh status at regular intervals.`,
 	RunE: runStatus,
 }
@@ -408,6 +409,9 @@ func buildInfoFromConfig(rc *config.RuntimeConfig) string {
 }
 
 func runStatus(cmd *cobra.Command, args []string) error {
+	if statusSessions {
+		return runStatusSessions()
+	}
 	if statusWatch {
 		return runStatusWatch(cmd, args)
 	}
@@ -0,0 +1,119 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"text/tabwriter"
+
+	"github.com/steveyegge/gastown/internal/session"
+	"github.com/steveyegge/gastown/internal/tmux"
+)
+
+// statusSessions switches gt status to a per-session table.
+var statusSessions bool
+
+func init() {
+	statusCmd.Flags().BoolVar(&statusSessions, "sessions", false, "List every Gas Town session with its role, whether it is alive, and its hooked bead")
+}
+
+// sessionStatusRow is one line of gt status --sessions.
+type sessionStatusRow struct {
+	Session  string `json:"session"`
+	Role     string `json:"role"`
+	Alive    bool   `json:"alive"`
+	HookBead string `json:"hook_bead,omitempty"`
+}
+
+// buildSessionStatusRows merges the live Gas Town sessions with the agents
+// the town expects. Every agent gets a row, marked dead if its session is not
+// in live; live sessions with no agent (boot, dogs) get a row with the role
+// parsed from the session name. Rows are sorted by session name.
+func buildSessionStatusRows(live []string, agents []AgentRuntime) []sessionStatusRow {
+	alive := make(map[string]bool, len(live))
+	for _, sess := range live {
+		alive[sess] = true
+	}
+
+	seen := make(map[string]bool)
+	var rows []sessionStatusRow
+	for _, a := range agents {
+		if a.Session == "" || seen[a.Session] {
+			continue
+		}
+		seen[a.Session] = true
+		rows = append(rows, sessionStatusRow{
+			Session:  a.Session,
+			Role:     a.Role,
+			Alive:    alive[a.Session],
+			HookBead: a.HookBead,
+		})
+	}
+	for _, sess := range live {
+		if seen[sess] {
+			continue
+		}
+		seen[sess] = true
+		role := "unknown"
+		if identity, err := session.ParseSessionName(sess); err == nil {
+			role = string(identity.Role)
+		}
+		rows = append(rows, sessionStatusRow{Session: sess, Role: role, Alive: true})
+	}
+
+	sort.Slice(rows, func(i, j int) bool { return rows[i].Session < rows[j].Session })
+	return rows
+}
+
+// runStatusSessions prints the session table. It only reads tmux and beads,
+// so it works from outside tmux.
+func runStatusSessions() error {
+	// Mail counts aren't shown, so don't pay for them.
+	statusFast = true
+	status, err := gatherStatus()
+	if err != nil {
+		return err
+	}
+	live, err := tmux.NewTmux().ListGastownSessions(session.IsKnownSession)
+	if err != nil {
+		return fmt.Errorf("listing sessions: %w", err)
+	}
+
+	agents := append([]AgentRuntime{}, status.Agents...)
+	for _, r := range status.Rigs {
+		agents = append(agents, r.Agents...)
+	}
+	rows := buildSessionStatusRows(live, agents)
+
+	if statusJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(rows)
+	}
+	return printSessionStatusRows(os.Stdout, rows)
+}
+
+// printSessionStatusRows writes rows as an aligned table.
+func printSessionStatusRows(out io.Writer, rows []sessionStatusRow) error {
+	if len(rows) == 0 {
+		fmt.Fprintln(out, "No Gas Town sessions found")
+		return nil
+	}
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "SESSION\tROLE\tSTATE\tHOOK")
+	for _, r := range rows {
+		// Plain text: styling escapes would throw off tabwriter's alignment.
+		state := "alive"
+		if !r.Alive {
+			state = "dead"
+		}
+		hook := r.HookBead
+		if hook == "" {
+			hook = "-"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", r.Session, r.Role, state, hook)
+	}
+	return w.Flush()
+}
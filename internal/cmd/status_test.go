@@ -526,3 +526,33 @@ func TestExtractBaseName(t *testing.T) {
 		})
 	}
 }
+
+func TestBuildSessionStatusRows(t *testing.T) {
+	setupHandoffTestRegistry(t)
+
+	live := []string{"hq-mayor", "gt-witness", "hq-boot"}
+	agents := []AgentRuntime{
+		{Session: "hq-mayor", Role: "coordinator", HookBead: "hq-abc"},
+		{Session: "hq-deacon", Role: "health-check"},
+		{Session: "gt-witness", Role: "witness"},
+		{Session: "gt-Toast", Role: "polecat", HookBead: "gt-123"},
+		{Session: "", Role: "polecat"},
+	}
+
+	rows := buildSessionStatusRows(live, agents)
+	want := []sessionStatusRow{
+		{Session: "gt-Toast", Role: "polecat", Alive: false, HookBead: "gt-123"},
+		{Session: "gt-witness", Role: "witness", Alive: true},
+		{Session: "hq-boot", Role: "deacon", Alive: true},
+		{Session: "hq-deacon", Role: "health-check", Alive: false},
+		{Session: "hq-mayor", Role: "coordinator", Alive: true, HookBead: "hq-abc"},
+	}
+	if len(rows) != len(want) {
+		t.Fatalf("got %d rows, want %d: %+v", len(rows), len(want), rows)
+	}
+	for i := range want {
+		if rows[i] != want[i] {
+			t.Errorf("row %d = %+v, want %+v", i, rows[i], want[i])
+		}
+	}
+}
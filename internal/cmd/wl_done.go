@@ -60,6 +60,7 @@ func runWlDone(cmd *cobra.Command, args []string) error {
 	}
 
 	store := doltserver.NewWLCommons(townRoot)
+	defer store.Close()
 	completionID := generateCompletionID(wantedID, rigHandle)
 
 	if err := submitDone(store, wantedID, rigHandle, wlDoneEvidence, completionID); err != nil {
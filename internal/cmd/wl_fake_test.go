@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"sync"
 
@@ -16,6 +17,7 @@ type fakeWLCommonsStore struct {
 	dbOK  bool
 
 	// Error injection fields
+	PingErr             error
 	EnsureDBErr         error
 	InsertWantedErr     error
 	ClaimWantedErr      error
@@ -30,6 +32,10 @@ func newFakeWLCommonsStore() *fakeWLCommonsStore {
 	}
 }
 
+func (f *fakeWLCommonsStore) Ping(context.Context) error {
+	return f.PingErr
+}
+
 func (f *fakeWLCommonsStore) EnsureDB() error {
 	if f.EnsureDBErr != nil {
 		return f.EnsureDBErr
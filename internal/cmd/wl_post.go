@@ -74,6 +74,7 @@ func runWlPost(cmd *cobra.Command, args []string) error {
 	}
 
 	store := doltserver.NewWLCommons(townRoot)
+	defer store.Close()
 
 	wlCfg, err := wasteland.LoadConfig(townRoot)
 	if err != nil {
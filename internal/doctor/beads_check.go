@@ -328,6 +328,7 @@ func NewRoleLabelCheck() *RoleLabelCheck {
 				CheckName:        "role-bead-labels",
 				CheckDescription: "Check that role beads have gt:role label",
 				CheckCategory:    CategoryConfig,
+				CheckDependsOn:   []string{"dolt-server-reachable"},
 			},
 		},
 		labelAdder: &realLabelAdder{},
@@ -668,7 +668,7 @@ func (c *ClaudeSettingsCheck) Fix(ctx *CheckContext) error {
 			errors = append(errors, fmt.Sprintf("failed to delete %s: %v", sf.path, err))
 			continue
 		}
-		fmt.Printf("  Deleted stale: %s\n", sf.path)
+		fmt.Fprintf(ctx.Output(), "  Deleted stale: %s\n", sf.path)
 		needsRestart = true
 
 		claudeDir := filepath.Dir(sf.path)
@@ -698,8 +698,8 @@ func (c *ClaudeSettingsCheck) Fix(ctx *CheckContext) error {
 			// Town-root files were inherited by ALL agents via directory traversal.
 			// Warn user to restart agents - don't auto-kill sessions as that's too disruptive,
 			// especially since deacon runs gt doctor automatically which would create a loop.
-			fmt.Printf("\n  %s Town-root settings were moved. Restart agents to pick up new config:\n", style.Warning.Render("⚠"))
-			fmt.Printf("      gt up --restore\n\n")
+			fmt.Fprintf(ctx.Output(), "\n  %s Town-root settings were moved. Restart agents to pick up new config:\n", style.Warning.Render("⚠"))
+			fmt.Fprintf(ctx.Output(), "      gt up --restore\n\n")
 			continue
 		}
 
@@ -745,15 +745,15 @@ func (c *ClaudeSettingsCheck) Fix(ctx *CheckContext) error {
 	// Report skipped files as warnings, not errors
 	if len(skipped) > 0 {
 		for _, s := range skipped {
-			fmt.Printf("  Warning: %s\n", s)
+			fmt.Fprintf(ctx.Output(), "  Warning: %s\n", s)
 		}
 	}
 
 	// Tell user to restart agents so they create correct settings
 	if needsRestart && !ctx.RestartSessions {
-		fmt.Printf("\n  %s Restart agents to create new settings:\n", style.Warning.Render("⚠"))
-		fmt.Printf("      gt up --restore\n")
-		fmt.Printf("\n  If you had custom Claude settings edits, re-apply them via 'gt hooks override <role>'.\n\n")
+		fmt.Fprintf(ctx.Output(), "\n  %s Restart agents to create new settings:\n", style.Warning.Render("⚠"))
+		fmt.Fprintf(ctx.Output(), "      gt up --restore\n")
+		fmt.Fprintf(ctx.Output(), "\n  If you had custom Claude settings edits, re-apply them via 'gt hooks override <role>'.\n\n")
 	}
 
 	if len(errors) > 0 {
@@ -2,6 +2,7 @@ package doctor
 
 import (
 	"encoding/json"
+	"io"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -1314,3 +1315,35 @@ func TestClaudeSettingsCheck_FixDoesNotDeleteMissingFiles(t *testing.T) {
 		t.Error("expected witness directory to still exist after fix")
 	}
 }
+
+func TestClaudeSettingsCheck_FixWritesToContextOutput(t *testing.T) {
+	check := NewClaudeSettingsCheck()
+	check.staleSettings = []staleSettingsInfo{{
+		path:          "/repo/.claude/settings.json",
+		agentType:     "witness",
+		wrongLocation: true,
+		gitStatus:     gitStatusTrackedClean,
+	}}
+
+	var out strings.Builder
+	stdout := os.Stdout
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	os.Stdout = w
+	fixErr := check.Fix(&CheckContext{TownRoot: t.TempDir(), Out: &out})
+	os.Stdout = stdout
+	w.Close()
+	leaked, _ := io.ReadAll(r)
+
+	if fixErr != nil {
+		t.Fatalf("Fix: %v", fixErr)
+	}
+	if !strings.Contains(out.String(), "tracked in customer repo, skipping") {
+		t.Errorf("ctx.Out = %q, want the skipped-file warning", out.String())
+	}
+	if len(leaked) > 0 {
+		t.Errorf("Fix wrote to stdout: %q", leaked)
+	}
+}
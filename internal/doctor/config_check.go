@@ -616,6 +616,7 @@ func NewCustomTypesCheck() *CustomTypesCheck {
 				CheckName:        "beads-custom-types",
 				CheckDescription: "Check that Gas Town custom types are registered with beads",
 				CheckCategory:    CategoryConfig,
+				CheckDependsOn:   []string{"dolt-server-reachable"},
 			},
 		},
 	}
@@ -4,6 +4,8 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/steveyegge/gastown/internal/ui"
@@ -11,7 +13,8 @@ import (
 
 // Doctor manages and executes health checks.
 type Doctor struct {
-	checks []Check
+	checks  []Check
+	workers int // Max checks run concurrently by Run; <= 1 runs them in order
 }
 
 // NewDoctor creates a new Doctor with no registered checks.
@@ -36,6 +39,61 @@ func (d *Doctor) Checks() []Check {
 	return d.checks
 }
 
+// Filter narrows the registered checks by name. If only is non-empty, just
+// the named checks are kept, and naming a check that isn't registered is an
+// error. Otherwise checks named in skip are dropped. Registration order is
+// preserved.
+func (d *Doctor) Filter(only, skip []string) error {
+	if len(only) > 0 && len(skip) > 0 {
+		return fmt.Errorf("only and skip are mutually exclusive")
+	}
+
+	if len(only) > 0 {
+		want := make(map[string]bool, len(only))
+		for _, name := range only {
+			want[name] = true
+		}
+		var kept []Check
+		for _, check := range d.checks {
+			if want[check.Name()] {
+				kept = append(kept, check)
+				delete(want, check.Name())
+			}
+		}
+		if len(want) > 0 {
+			var unknown []string
+			for _, name := range only {
+				if want[name] {
+					unknown = append(unknown, name)
+				}
+			}
+			return fmt.Errorf("unknown check(s): %s", strings.Join(unknown, ", "))
+		}
+		d.checks = kept
+		return nil
+	}
+
+	drop := make(map[string]bool, len(skip))
+	for _, name := range skip {
+		drop[name] = true
+	}
+	kept := d.checks[:0]
+	for _, check := range d.checks {
+		if !drop[check.Name()] {
+			kept = append(kept, check)
+		}
+	}
+	d.checks = kept
+	return nil
+}
+
+// SetWorkers sets how many checks Run and RunStreaming may execute at once.
+// Results are still reported in registration order. Fix always runs checks
+// one at a time, since fixes mutate the workspace.
+func (d *Doctor) SetWorkers(n int) {
+	d.workers = n
+}
+
 // categoryGetter interface for checks that provide a category
 type categoryGetter interface {
 	Category() string
@@ -46,10 +104,28 @@ func (d *Doctor) Run(ctx *CheckContext) *Report {
 	return d.RunStreaming(ctx, nil, 0)
 }
 
+// serialCheck is implemented by checks that must not run concurrently with
+// any other check, e.g. because Run queries the tmux or Dolt server. BaseCheck
+// implements it (see BaseCheck.CheckSerial).
+type serialCheck interface {
+	Serial() bool
+}
+
+func isSerial(check Check) bool {
+	sc, ok := check.(serialCheck)
+	return ok && sc.Serial()
+}
+
 // RunStreaming executes all registered checks with optional real-time output.
 // If w is non-nil, prints each check name as it starts and result when done.
 // If slowThreshold > 0, shows hourglass icon for slow checks.
+// With more than one worker (see SetWorkers), checks run concurrently and
+// each result is printed once it and every check before it have finished.
 func (d *Doctor) RunStreaming(ctx *CheckContext, w io.Writer, slowThreshold time.Duration) *Report {
+	if d.workers > 1 {
+		return d.runParallel(ctx, w, slowThreshold)
+	}
+
 	report := NewReport()
 
 	for _, check := range d.checks {
@@ -58,51 +134,99 @@ func (d *Doctor) RunStreaming(ctx *CheckContext, w io.Writer, slowThreshold time
 			fmt.Fprintf(w, "  %s  %s...", ui.RenderMuted("○"), check.Name())
 		}
 
-		start := time.Now()
-		result := check.Run(ctx)
-		result.Elapsed = time.Since(start)
-
-		// Ensure check name is populated
-		if result.Name == "" {
-			result.Name = check.Name()
-		}
-		// Set category from check if available
-		if cg, ok := check.(categoryGetter); ok && result.Category == "" {
-			result.Category = cg.Category()
+		result := runCheck(check, ctx)
+		if w != nil {
+			printRunResult(w, report, result, slowThreshold)
 		}
+		report.Add(result)
+	}
 
-		// Stream: overwrite line with result
-		if w != nil {
-			var statusIcon string
-			switch result.Status {
-			case StatusOK:
-				statusIcon = ui.RenderPassIcon()
-			case StatusWarning:
-				statusIcon = ui.RenderWarnIcon()
-			case StatusError:
-				statusIcon = ui.RenderFailIcon()
-			}
-			// Check if slow (hourglass replaces spaces to maintain alignment)
-			isSlow := slowThreshold > 0 && result.Elapsed >= slowThreshold
-			slowIndicator := "  "
-			if isSlow {
-				report.Summary.Slow++
-				slowIndicator = "⏳"
-			}
-			fmt.Fprintf(w, "\r  %s%s%s", statusIcon, slowIndicator, result.Name)
-			if result.Message != "" {
-				fmt.Fprintf(w, "%s", ui.RenderMuted(" "+result.Message))
-			}
-			if isSlow {
-				fmt.Fprintf(w, "%s", ui.RenderMuted(" ("+formatDuration(result.Elapsed)+")"))
+	return report
+}
+
+// runParallel runs checks on up to d.workers goroutines. Serial checks take
+// an exclusive lock, so they never overlap with any other check.
+func (d *Doctor) runParallel(ctx *CheckContext, w io.Writer, slowThreshold time.Duration) *Report {
+	results := make([]*CheckResult, len(d.checks))
+	done := make([]chan struct{}, len(d.checks))
+	slots := make(chan struct{}, d.workers)
+	var exclusive sync.RWMutex
+
+	for i, check := range d.checks {
+		done[i] = make(chan struct{})
+		go func(i int, check Check) {
+			defer close(done[i])
+			slots <- struct{}{}
+			defer func() { <-slots }()
+
+			if isSerial(check) {
+				exclusive.Lock()
+				defer exclusive.Unlock()
+			} else {
+				exclusive.RLock()
+				defer exclusive.RUnlock()
 			}
-			fmt.Fprintln(w)
+			results[i] = runCheck(check, ctx)
+		}(i, check)
+	}
+
+	report := NewReport()
+	for i := range d.checks {
+		<-done[i]
+		if w != nil {
+			printRunResult(w, report, results[i], slowThreshold)
 		}
+		report.Add(results[i])
+	}
+	return report
+}
 
-		report.Add(result)
+// runCheck runs a single check and fills in its name, category, and elapsed
+// time.
+func runCheck(check Check, ctx *CheckContext) *CheckResult {
+	start := time.Now()
+	result := check.Run(ctx)
+	result.Elapsed = time.Since(start)
+
+	// Ensure check name is populated
+	if result.Name == "" {
+		result.Name = check.Name()
+	}
+	// Set category from check if available
+	if cg, ok := check.(categoryGetter); ok && result.Category == "" {
+		result.Category = cg.Category()
 	}
+	result.Fixable = check.CanFix()
+	return result
+}
 
-	return report
+// printRunResult writes a check's result line, overwriting any "checking"
+// line already on the terminal, and counts it as slow in report if needed.
+func printRunResult(w io.Writer, report *Report, result *CheckResult, slowThreshold time.Duration) {
+	var statusIcon string
+	switch result.Status {
+	case StatusOK:
+		statusIcon = ui.RenderPassIcon()
+	case StatusWarning:
+		statusIcon = ui.RenderWarnIcon()
+	case StatusError:
+		statusIcon = ui.RenderFailIcon()
+	}
+	// Check if slow (hourglass replaces spaces to maintain alignment)
+	isSlow := slowThreshold > 0 && result.Elapsed >= slowThreshold
+	slowIndicator := "  "
+	if isSlow {
+		report.Summary.Slow++
+		slowIndicator = "⏳"
+	}
+	fmt.Fprintf(w, "\r  %s%s%s", statusIcon, slowIndicator, result.Name)
+	if result.Message != "" {
+		fmt.Fprintf(w, "%s", ui.RenderMuted(" "+result.Message))
+	}
+	if isSlow {
+		fmt.Fprintf(w, "%s", ui.RenderMuted(" ("+formatDuration(result.Elapsed)+")"))
+	}
+	fmt.Fprintln(w)
 }
 
 // Fix runs all checks with auto-fix enabled where possible.
@@ -123,27 +247,116 @@ func safeFixCheck(check Check, ctx *CheckContext) (retErr error) {
 	return check.Fix(ctx)
 }
 
+// dryRunFixer is implemented by checks whose Fix honors CheckContext.DryRun.
+// Fixes of other checks are skipped entirely in a dry run.
+type dryRunFixer interface {
+	SupportsDryRun() bool
+}
+
+// fixCheck applies a check's fix, or skips it when a dry run is requested
+// and the check can't preview its changes.
+func fixCheck(check Check, ctx *CheckContext) error {
+	if ctx.DryRun {
+		if f, ok := check.(dryRunFixer); !ok || !f.SupportsDryRun() {
+			return ErrSkippedDryRun
+		}
+	}
+	return safeFixCheck(check, ctx)
+}
+
+// dependentCheck is implemented by checks whose fix requires other checks'
+// fixes to have been applied first. BaseCheck implements it.
+type dependentCheck interface {
+	DependsOn() []string
+}
+
+// fixOrder returns the checks sorted so every check comes after the checks it
+// depends on, keeping registration order otherwise. Dependencies on checks
+// that aren't registered are ignored. Checks caught in a dependency cycle, or
+// depending on one, are appended in registration order and returned in
+// cyclic.
+func fixOrder(checks []Check) (ordered []Check, cyclic map[string]bool) {
+	registered := make(map[string]bool, len(checks))
+	for _, check := range checks {
+		registered[check.Name()] = true
+	}
+
+	placed := make(map[string]bool, len(checks))
+	for len(ordered) < len(checks) {
+		progressed := false
+		for _, check := range checks {
+			if placed[check.Name()] {
+				continue
+			}
+			ready := true
+			if dc, ok := check.(dependentCheck); ok {
+				for _, dep := range dc.DependsOn() {
+					if registered[dep] && !placed[dep] {
+						ready = false
+						break
+					}
+				}
+			}
+			if ready {
+				ordered = append(ordered, check)
+				placed[check.Name()] = true
+				progressed = true
+				break // Restart so earlier-registered checks keep priority
+			}
+		}
+		if !progressed {
+			break
+		}
+	}
+
+	for _, check := range checks {
+		if !placed[check.Name()] {
+			if cyclic == nil {
+				cyclic = make(map[string]bool)
+			}
+			cyclic[check.Name()] = true
+			ordered = append(ordered, check)
+		}
+	}
+	return ordered, cyclic
+}
+
+// unmetDependency returns the first dependency of check whose fix failed, or
+// "" if none did.
+func unmetDependency(check Check, failed map[string]bool) string {
+	dc, ok := check.(dependentCheck)
+	if !ok {
+		return ""
+	}
+	for _, dep := range dc.DependsOn() {
+		if failed[dep] {
+			return dep
+		}
+	}
+	return ""
+}
+
 // FixStreaming runs all checks with auto-fix and optional real-time output.
 // If w is non-nil, prints each check name as it starts and result when done.
 // If slowThreshold > 0, shows hourglass icon for slow checks.
+//
+// Checks run in dependency order (see BaseCheck.CheckDependsOn). A fix is
+// skipped when a check it depends on is still unhealthy after its own fix
+// attempt, or when its check is part of a dependency cycle.
 func (d *Doctor) FixStreaming(ctx *CheckContext, w io.Writer, slowThreshold time.Duration) *Report {
 	report := NewReport()
 
-	for _, check := range d.checks {
+	checks, cyclic := fixOrder(d.checks)
+	failed := make(map[string]bool) // Checks still unhealthy after fixing
+
+	for _, check := range checks {
 		// Stream: print check name before running
 		if w != nil {
 			fmt.Fprintf(w, "  %s  %s...", ui.RenderMuted("○"), check.Name())
 		}
 
 		start := time.Now()
-		result := check.Run(ctx)
-		if result.Name == "" {
-			result.Name = check.Name()
-		}
-		// Set category from check if available
-		if cg, ok := check.(categoryGetter); ok && result.Category == "" {
-			result.Category = cg.Category()
-		}
+		result := runCheck(check, ctx)
 
 		// Attempt fix if check failed and is fixable
 		if result.Status != StatusOK && check.CanFix() {
@@ -163,17 +376,17 @@ func (d *Doctor) FixStreaming(ctx *CheckContext, w io.Writer, slowThreshold time
 				fmt.Fprintf(w, "%s", ui.RenderMuted(" (fixing)..."))
 			}
 
-			err := safeFixCheck(check, ctx)
+			var err error
+			if cyclic[check.Name()] {
+				err = ErrDependencyCycle
+			} else if dep := unmetDependency(check, failed); dep != "" {
+				err = fmt.Errorf("%w: %s", ErrDependencyFailed, dep)
+			} else {
+				err = fixCheck(check, ctx)
+			}
 			if err == nil {
 				// Re-run check to verify fix worked
-				result = check.Run(ctx)
-				if result.Name == "" {
-					result.Name = check.Name()
-				}
-				// Set category again after re-run
-				if cg, ok := check.(categoryGetter); ok && result.Category == "" {
-					result.Category = cg.Category()
-				}
+				result = runCheck(check, ctx)
 				// Update message to indicate fix was applied
 				if result.Status == StatusOK {
 					result.Message = result.Message + " (fixed)"
@@ -182,12 +395,34 @@ func (d *Doctor) FixStreaming(ctx *CheckContext, w io.Writer, slowThreshold time
 			} else if errors.Is(err, ErrSkippedNoStart) {
 				// Fix skipped due to --no-start flag
 				result.Details = append(result.Details, "Skipped: --no-start suppresses startup")
+				result.Skipped = ErrSkippedNoStart.Error()
+			} else if err == ErrSkippedDryRun {
+				// Fix skipped due to --dry-run flag
+				result.Details = append(result.Details, "Skipped: --dry-run")
+				result.Skipped = ErrSkippedDryRun.Error()
+			} else if errors.Is(err, ErrSkippedDryRun) {
+				// Fix previewed by a dry-run-aware check
+				result.Details = append(result.Details, "Dry run: "+strings.TrimPrefix(err.Error(), ErrSkippedDryRun.Error()+": "))
+				result.Skipped = ErrSkippedDryRun.Error()
+			} else if errors.Is(err, ErrDependencyCycle) || errors.Is(err, ErrDependencyFailed) {
+				// Fix not attempted because of its dependencies
+				result.Details = append(result.Details, "Skipped: "+err.Error())
+				result.Skipped = err.Error()
+			} else if errors.Is(err, ErrCannotFix) {
+				// Check advertised a fix it doesn't have
+				result.Fixable = false
 			} else {
 				// Fix failed, add error to details
 				result.Details = append(result.Details, "Fix failed: "+err.Error())
 			}
 		}
 
+		// A check left unhealthy blocks its dependents' fixes, unless its
+		// own fix was only previewed
+		if result.Status != StatusOK && result.Skipped != ErrSkippedDryRun.Error() {
+			failed[check.Name()] = true
+		}
+
 		// Record total elapsed time including any fix attempts
 		result.Elapsed = time.Since(start)
 
@@ -238,7 +473,21 @@ func (d *Doctor) FixStreaming(ctx *CheckContext, w io.Writer, slowThreshold time
 type BaseCheck struct {
 	CheckName        string
 	CheckDescription string
-	CheckCategory    string // Category for grouping (e.g., CategoryCore)
+	CheckCategory    string   // Category for grouping (e.g., CategoryCore)
+	CheckDependsOn   []string // Names of checks whose fixes must be applied first
+	CheckSerial      bool     // Run alone, never alongside other checks
+}
+
+// Serial reports whether the check must run alone when checks run
+// concurrently.
+func (b *BaseCheck) Serial() bool {
+	return b.CheckSerial
+}
+
+// DependsOn returns the names of checks whose fixes must be applied before
+// this check's fix.
+func (b *BaseCheck) DependsOn() []string {
+	return b.CheckDependsOn
 }
 
 // Category returns the check's category for grouping in output.
@@ -2,9 +2,14 @@ package doctor
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
 	"strings"
+	"sync"
 	"testing"
+	"time"
 )
 
 // mockCheck is a test check that can be configured to return any status.
@@ -423,3 +428,322 @@ func TestFixableCheck(t *testing.T) {
 		t.Error("FixableCheck.CanFix() should return true")
 	}
 }
+
+// concurrencyProbe tracks how many probe checks are running at once.
+type concurrencyProbe struct {
+	mu            sync.Mutex
+	active        int
+	maxActive     int
+	serialOverlap bool
+}
+
+// probeCheck is a check that sleeps while registered as active in a probe.
+type probeCheck struct {
+	BaseCheck
+	probe  *concurrencyProbe
+	serial bool
+}
+
+func (p *probeCheck) Serial() bool { return p.serial }
+
+func (p *probeCheck) Run(ctx *CheckContext) *CheckResult {
+	p.probe.mu.Lock()
+	p.probe.active++
+	if p.probe.active > p.probe.maxActive {
+		p.probe.maxActive = p.probe.active
+	}
+	if p.serial && p.probe.active > 1 {
+		p.probe.serialOverlap = true
+	}
+	p.probe.mu.Unlock()
+
+	time.Sleep(20 * time.Millisecond)
+
+	p.probe.mu.Lock()
+	if p.serial && p.probe.active > 1 {
+		p.probe.serialOverlap = true
+	}
+	p.probe.active--
+	p.probe.mu.Unlock()
+	return &CheckResult{Status: StatusOK}
+}
+
+func TestDoctor_RunParallel_SerialChecksNeverOverlap(t *testing.T) {
+	probe := &concurrencyProbe{}
+	d := NewDoctor()
+	d.SetWorkers(4)
+
+	var want []string
+	for i := 0; i < 12; i++ {
+		name := fmt.Sprintf("check-%02d", i)
+		want = append(want, name)
+		d.Register(&probeCheck{
+			BaseCheck: BaseCheck{CheckName: name},
+			probe:     probe,
+			serial:    i%4 == 0,
+		})
+	}
+
+	var buf bytes.Buffer
+	report := d.RunStreaming(&CheckContext{TownRoot: "/test"}, &buf, 0)
+
+	if probe.serialOverlap {
+		t.Error("a serial check ran concurrently with another check")
+	}
+	if probe.maxActive < 2 {
+		t.Errorf("max concurrent checks = %d, want parallel checks to overlap", probe.maxActive)
+	}
+	if probe.maxActive > 4 {
+		t.Errorf("max concurrent checks = %d, want at most 4 workers", probe.maxActive)
+	}
+
+	if len(report.Checks) != len(want) {
+		t.Fatalf("report has %d checks, want %d", len(report.Checks), len(want))
+	}
+	for i, r := range report.Checks {
+		if r.Name != want[i] {
+			t.Errorf("report.Checks[%d] = %s, want %s (registration order)", i, r.Name, want[i])
+		}
+	}
+	if idx := strings.Index(buf.String(), "check-00"); idx < 0 || idx > strings.Index(buf.String(), "check-11") {
+		t.Errorf("streamed output not in registration order:\n%s", buf.String())
+	}
+}
+
+func checkNames(d *Doctor) []string {
+	var names []string
+	for _, check := range d.Checks() {
+		names = append(names, check.Name())
+	}
+	return names
+}
+
+func newFilterDoctor() *Doctor {
+	d := NewDoctor()
+	for _, name := range []string{"alpha", "beta", "gamma", "delta"} {
+		d.Register(newMockCheck(name, StatusOK))
+	}
+	return d
+}
+
+func TestDoctor_Filter_Only(t *testing.T) {
+	d := newFilterDoctor()
+	if err := d.Filter([]string{"gamma", "alpha"}, nil); err != nil {
+		t.Fatalf("Filter: %v", err)
+	}
+	if got := strings.Join(checkNames(d), ","); got != "alpha,gamma" {
+		t.Errorf("checks = %s, want alpha,gamma in registration order", got)
+	}
+	report := d.Run(&CheckContext{TownRoot: "/test"})
+	if report.Summary.Total != 2 {
+		t.Errorf("ran %d checks, want 2", report.Summary.Total)
+	}
+}
+
+func TestDoctor_Filter_OnlyUnknown(t *testing.T) {
+	d := newFilterDoctor()
+	err := d.Filter([]string{"alpha", "nope"}, nil)
+	if err == nil || !strings.Contains(err.Error(), "nope") {
+		t.Fatalf("Filter error = %v, want unknown check nope", err)
+	}
+	if len(d.Checks()) != 4 {
+		t.Errorf("failed Filter changed the checks: %v", checkNames(d))
+	}
+}
+
+func TestDoctor_Filter_Skip(t *testing.T) {
+	d := newFilterDoctor()
+	if err := d.Filter(nil, []string{"beta", "delta"}); err != nil {
+		t.Fatalf("Filter: %v", err)
+	}
+	if got := strings.Join(checkNames(d), ","); got != "alpha,gamma" {
+		t.Errorf("checks = %s, want alpha,gamma", got)
+	}
+}
+
+func TestDoctor_Filter_MutuallyExclusive(t *testing.T) {
+	d := newFilterDoctor()
+	if err := d.Filter([]string{"alpha"}, []string{"beta"}); err == nil {
+		t.Fatal("expected error when both only and skip are set")
+	}
+}
+
+func TestDoctor_Fix_SkipsDependentWhenPrerequisiteFails(t *testing.T) {
+	dependent := newMockCheck("beads-table", StatusError)
+	dependent.fixable = true
+	dependent.CheckDependsOn = []string{"dolt-server"}
+	prerequisite := newMockCheck("dolt-server", StatusError)
+	prerequisite.fixable = true
+	prerequisite.fixError = fmt.Errorf("dolt failed to start")
+
+	// Register the dependent first so the fix order must be corrected.
+	d := NewDoctor()
+	d.Register(dependent)
+	d.Register(prerequisite)
+
+	report := d.Fix(&CheckContext{TownRoot: "/test"})
+
+	if prerequisite.fixCount != 1 {
+		t.Errorf("prerequisite fixCount = %d, want 1", prerequisite.fixCount)
+	}
+	if dependent.fixCount != 0 {
+		t.Errorf("dependent fixCount = %d, want 0 (prerequisite fix failed)", dependent.fixCount)
+	}
+	if report.Checks[0].Name != "dolt-server" || report.Checks[1].Name != "beads-table" {
+		t.Errorf("fix order = %s, %s; want dolt-server first", report.Checks[0].Name, report.Checks[1].Name)
+	}
+	if !strings.Contains(report.Checks[1].Skipped, "dolt-server") {
+		t.Errorf("dependent Skipped = %q, want it to name dolt-server", report.Checks[1].Skipped)
+	}
+}
+
+func TestDoctor_Fix_RunsDependentAfterPrerequisiteFixed(t *testing.T) {
+	dependent := newMockCheck("beads-table", StatusError)
+	dependent.fixable = true
+	dependent.CheckDependsOn = []string{"dolt-server"}
+	prerequisite := newMockCheck("dolt-server", StatusError)
+	prerequisite.fixable = true
+
+	d := NewDoctor()
+	d.RegisterAll(dependent, prerequisite)
+	report := d.Fix(&CheckContext{TownRoot: "/test"})
+
+	if dependent.fixCount != 1 || report.Summary.Fixed != 2 {
+		t.Errorf("dependent fixCount = %d, Fixed = %d; want 1 and 2", dependent.fixCount, report.Summary.Fixed)
+	}
+}
+
+// dirFixCheck wants a directory to exist and creates it in Fix, standing in
+// for fixes that only touch the filesystem.
+type dirFixCheck struct {
+	FixableCheck
+	dir string
+}
+
+func (c *dirFixCheck) Run(ctx *CheckContext) *CheckResult {
+	if _, err := os.Stat(c.dir); err != nil {
+		return &CheckResult{Name: c.Name(), Status: StatusWarning, Message: "missing " + c.dir}
+	}
+	return &CheckResult{Name: c.Name(), Status: StatusOK, Message: "present"}
+}
+
+func (c *dirFixCheck) Fix(ctx *CheckContext) error {
+	return os.MkdirAll(c.dir, 0755)
+}
+
+func TestDaemonCheck_Fix_NoStart(t *testing.T) {
+	if err := NewDaemonCheck().Fix(&CheckContext{TownRoot: t.TempDir(), NoStart: true}); !errors.Is(err, ErrSkippedNoStart) {
+		t.Errorf("Fix() with NoStart = %v, want ErrSkippedNoStart", err)
+	}
+}
+
+func TestDoctor_Fix_NoStartSkipsOnlyStartupFixes(t *testing.T) {
+	townRoot := t.TempDir()
+	dir := &dirFixCheck{
+		FixableCheck: FixableCheck{BaseCheck: BaseCheck{CheckName: "runtime-dir"}},
+		dir:          filepath.Join(townRoot, ".runtime"),
+	}
+
+	d := NewDoctor()
+	d.RegisterAll(NewDaemonCheck(), dir)
+	report := d.Fix(&CheckContext{TownRoot: townRoot, NoStart: true})
+
+	daemonResult, dirResult := report.Checks[0], report.Checks[1]
+	if daemonResult.Skipped != ErrSkippedNoStart.Error() || daemonResult.Fixed {
+		t.Errorf("daemon result = %+v, want skipped by --no-start", daemonResult)
+	}
+	if !dirResult.Fixed || dirResult.Status != StatusOK {
+		t.Errorf("runtime-dir result = %+v, want fixed", dirResult)
+	}
+	if _, err := os.Stat(dir.dir); err != nil {
+		t.Errorf("filesystem fix not applied: %v", err)
+	}
+	if report.Summary.NotStarted != 1 || report.Summary.Fixed != 1 {
+		t.Errorf("NotStarted = %d, Fixed = %d; want 1 and 1", report.Summary.NotStarted, report.Summary.Fixed)
+	}
+
+	var buf bytes.Buffer
+	report.Print(&buf, false, 0)
+	if !strings.Contains(buf.String(), "1 not started (--no-start)") {
+		t.Errorf("summary should report the skipped startup fix distinctly:\n%s", buf.String())
+	}
+}
+
+func TestDoctor_Fix_ReportsDependencyCycle(t *testing.T) {
+	a := newMockCheck("a", StatusError)
+	a.fixable = true
+	a.CheckDependsOn = []string{"b"}
+	b := newMockCheck("b", StatusError)
+	b.fixable = true
+	b.CheckDependsOn = []string{"a"}
+	c := newMockCheck("c", StatusError)
+	c.fixable = true
+
+	d := NewDoctor()
+	d.RegisterAll(a, b, c)
+	report := d.Fix(&CheckContext{TownRoot: "/test"})
+
+	if a.fixCount != 0 || b.fixCount != 0 {
+		t.Errorf("cyclic checks were fixed: a=%d b=%d", a.fixCount, b.fixCount)
+	}
+	if c.fixCount != 1 {
+		t.Errorf("independent check fixCount = %d, want 1", c.fixCount)
+	}
+	if report.Summary.Total != 3 {
+		t.Fatalf("report has %d checks, want 3", report.Summary.Total)
+	}
+	for _, r := range report.Checks {
+		if r.Name != "c" && r.Skipped != ErrDependencyCycle.Error() {
+			t.Errorf("%s Skipped = %q, want %q", r.Name, r.Skipped, ErrDependencyCycle.Error())
+		}
+	}
+}
+
+func TestFixOrder_BeadsChecksAfterDoltServer(t *testing.T) {
+	// Registered in the same relative order as gt doctor: the beads checks
+	// come before the Dolt server check, which they depend on.
+	checks := []Check{
+		NewCustomTypesCheck(),
+		NewRoleLabelCheck(),
+		NewAgentBeadsCheck(),
+		NewRigBeadsCheck(),
+		NewDoltServerReachableCheck(),
+		NewNullAssigneeCheck(),
+	}
+
+	ordered, cyclic := fixOrder(checks)
+	if len(cyclic) != 0 {
+		t.Fatalf("unexpected dependency cycle: %v", cyclic)
+	}
+	pos := make(map[string]int, len(ordered))
+	for i, check := range ordered {
+		pos[check.Name()] = i
+	}
+	for _, name := range []string{"beads-custom-types", "role-bead-labels", "agent-beads-exist", "rig-beads-exist", "null-assignee-steps"} {
+		if pos[name] < pos["dolt-server-reachable"] {
+			t.Errorf("%s fixed before dolt-server-reachable", name)
+		}
+	}
+	for _, name := range []string{"agent-beads-exist", "rig-beads-exist"} {
+		if pos[name] < pos["beads-custom-types"] {
+			t.Errorf("%s fixed before beads-custom-types", name)
+		}
+	}
+}
+
+func TestSerialChecks_TmuxAndDoltServer(t *testing.T) {
+	for _, check := range []Check{
+		NewZombieSessionCheck(),
+		NewOrphanSessionCheck(),
+		NewLinkedPaneCheck(),
+		NewDoltServerReachableCheck(),
+		NewNullAssigneeCheck(),
+	} {
+		if !isSerial(check) {
+			t.Errorf("%s should run serially", check.Name())
+		}
+	}
+	if isSerial(NewBeadsBinaryCheck()) {
+		t.Error("beads-binary should not run serially")
+	}
+}
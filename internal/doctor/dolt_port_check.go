@@ -0,0 +1,156 @@
+package doctor
+
+import (
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/doltserver"
+)
+
+// DoltPortCheck verifies that the dolt_server_port in each server-mode
+// metadata.json matches the port this town's Dolt server is on. A stale port
+// makes bd silently talk to a different server (or another town's) while gt
+// uses the right one.
+type DoltPortCheck struct {
+	FixableCheck
+	wantPort       int      // Cached during Run for use in Fix
+	mismatchedRigs []string // Cached during Run for use in Fix
+}
+
+// NewDoltPortCheck creates a new Dolt server port consistency check.
+func NewDoltPortCheck() *DoltPortCheck {
+	return &DoltPortCheck{
+		FixableCheck: FixableCheck{
+			BaseCheck: BaseCheck{
+				CheckName:        "dolt-server-port",
+				CheckDescription: "Check that beads metadata points at the Dolt server's port",
+				CheckCategory:    CategoryInfrastructure,
+				CheckSerial:      true,
+			},
+		},
+	}
+}
+
+// Run compares each metadata port with the running server's port (or the
+// configured port when no server is running).
+func (c *DoltPortCheck) Run(ctx *CheckContext) *CheckResult {
+	c.wantPort = 0
+	c.mismatchedRigs = nil
+
+	config := doltserver.DefaultConfig(ctx.TownRoot)
+	if config.IsRemote() {
+		return &CheckResult{
+			Name:     c.Name(),
+			Status:   StatusOK,
+			Message:  fmt.Sprintf("Remote Dolt server %s (port managed externally)", config.HostPort()),
+			Category: c.CheckCategory,
+		}
+	}
+
+	rigsByAddr := (&DoltServerReachableCheck{}).findServerModeRigsByAddr(ctx.TownRoot)
+	if len(rigsByAddr) == 0 {
+		return &CheckResult{
+			Name:     c.Name(),
+			Status:   StatusOK,
+			Message:  "No rigs configured for Dolt server mode",
+			Category: c.CheckCategory,
+		}
+	}
+
+	serverPort := runningDoltPort(ctx.TownRoot, config.Port)
+	want := serverPort
+	if want == 0 {
+		want = config.Port
+	}
+
+	var details []string
+	for addr, rigs := range rigsByAddr {
+		host, portStr, err := net.SplitHostPort(addr)
+		if err != nil || (host != "127.0.0.1" && host != "localhost" && host != "::1") {
+			continue // Rigs on other hosts aren't served by this town's server
+		}
+		if port, _ := strconv.Atoi(portStr); port != want {
+			sort.Strings(rigs)
+			c.mismatchedRigs = append(c.mismatchedRigs, rigs...)
+			details = append(details, fmt.Sprintf("metadata port %d (rigs: %s)", port, strings.Join(rigs, ", ")))
+		}
+	}
+	if serverPort != 0 && config.Port != serverPort {
+		details = append(details, fmt.Sprintf("gt config port %d (GT_DOLT_PORT?) also differs", config.Port))
+	}
+
+	if len(c.mismatchedRigs) > 0 {
+		sort.Strings(c.mismatchedRigs)
+		sort.Strings(details)
+		c.wantPort = want
+		source := "running server"
+		if serverPort == 0 {
+			source = "configured server"
+		}
+		return &CheckResult{
+			Name:     c.Name(),
+			Status:   StatusError,
+			Message:  fmt.Sprintf("%d rig(s) point at a different port than the %s (%d)", len(c.mismatchedRigs), source, want),
+			Details:  append(details, "bd commands for these rigs connect to the wrong server or fail"),
+			FixHint:  fmt.Sprintf("Run 'gt doctor --fix' to set dolt_server_port to %d", want),
+			Category: c.CheckCategory,
+		}
+	}
+
+	if serverPort == 0 {
+		return &CheckResult{
+			Name:     c.Name(),
+			Status:   StatusWarning,
+			Message:  fmt.Sprintf("Metadata agrees on port %d, but no Dolt server is listening there", want),
+			FixHint:  "Run 'gt dolt start'",
+			Category: c.CheckCategory,
+		}
+	}
+
+	return &CheckResult{
+		Name:     c.Name(),
+		Status:   StatusOK,
+		Message:  fmt.Sprintf("Metadata and Dolt server agree on port %d", want),
+		Category: c.CheckCategory,
+	}
+}
+
+// Fix rewrites dolt_server_port for the mismatched rigs.
+func (c *DoltPortCheck) Fix(ctx *CheckContext) error {
+	if c.wantPort == 0 {
+		return nil
+	}
+	for _, rigName := range c.mismatchedRigs {
+		if err := doltserver.SetMetadataPort(ctx.TownRoot, rigName, c.wantPort); err != nil {
+			return fmt.Errorf("fixing %s: %w", rigName, err)
+		}
+	}
+	return nil
+}
+
+// runningDoltPort returns the port the town's Dolt server is listening on:
+// the port recorded in its state file if the server is up there, otherwise
+// configPort if something is listening on it, otherwise 0.
+func runningDoltPort(townRoot string, configPort int) int {
+	if state, err := doltserver.LoadState(townRoot); err == nil && state.Running && state.Port > 0 && localPortListening(state.Port) {
+		return state.Port
+	}
+	if localPortListening(configPort) {
+		return configPort
+	}
+	return 0
+}
+
+// localPortListening reports whether something accepts connections on port.
+func localPortListening(port int) bool {
+	conn, err := net.DialTimeout("tcp", net.JoinHostPort("127.0.0.1", strconv.Itoa(port)), time.Second)
+	if err != nil {
+		return false
+	}
+	_ = conn.Close()
+	return true
+}
@@ -0,0 +1,112 @@
+package doctor
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// writeTownServerMetadata writes a server-mode town metadata.json recording port.
+func writeTownServerMetadata(t *testing.T, townRoot string, port int) {
+	t.Helper()
+	beadsDir := filepath.Join(townRoot, ".beads")
+	if err := os.MkdirAll(beadsDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	meta := fmt.Sprintf(`{"backend":"dolt","dolt_mode":"server","dolt_database":"hq","dolt_server_port":%d}`, port)
+	if err := os.WriteFile(filepath.Join(beadsDir, "metadata.json"), []byte(meta), 0600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// listen occupies a free local port for the duration of the test.
+func listen(t *testing.T) int {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	t.Cleanup(func() { ln.Close() })
+	return ln.Addr().(*net.TCPAddr).Port
+}
+
+// freePort returns a local port with nothing listening on it.
+func freePort(t *testing.T) int {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	port := ln.Addr().(*net.TCPAddr).Port
+	ln.Close()
+	return port
+}
+
+func clearDoltEnv(t *testing.T) {
+	t.Setenv("GT_DOLT_HOST", "")
+	t.Setenv("GT_DOLT_PORT", "")
+}
+
+func TestDoltPortCheck_Matching(t *testing.T) {
+	clearDoltEnv(t)
+	townRoot := t.TempDir()
+	port := listen(t)
+	writeTownServerMetadata(t, townRoot, port)
+
+	result := NewDoltPortCheck().Run(&CheckContext{TownRoot: townRoot})
+	if result.Status != StatusOK {
+		t.Fatalf("Status = %v (%s), want OK", result.Status, result.Message)
+	}
+}
+
+func TestDoltPortCheck_MetadataMismatch(t *testing.T) {
+	clearDoltEnv(t)
+	townRoot := t.TempDir()
+	serverPort := listen(t)
+	t.Setenv("GT_DOLT_PORT", fmt.Sprint(serverPort))
+	stale := freePort(t)
+	writeTownServerMetadata(t, townRoot, stale)
+
+	check := NewDoltPortCheck()
+	ctx := &CheckContext{TownRoot: townRoot}
+	result := check.Run(ctx)
+	if result.Status != StatusError {
+		t.Fatalf("Status = %v (%s), want Error", result.Status, result.Message)
+	}
+	if !check.CanFix() {
+		t.Fatal("CanFix() = false, want true")
+	}
+
+	if err := check.Fix(ctx); err != nil {
+		t.Fatalf("Fix() error: %v", err)
+	}
+	data, err := os.ReadFile(filepath.Join(townRoot, ".beads", "metadata.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var meta map[string]interface{}
+	if err := json.Unmarshal(data, &meta); err != nil {
+		t.Fatal(err)
+	}
+	if meta["dolt_server_port"] != float64(serverPort) || meta["dolt_database"] != "hq" {
+		t.Errorf("metadata after Fix = %v, want dolt_server_port %d with other fields kept", meta, serverPort)
+	}
+
+	if result := check.Run(ctx); result.Status != StatusOK {
+		t.Errorf("after Fix: Status = %v (%s), want OK", result.Status, result.Message)
+	}
+}
+
+func TestDoltPortCheck_NothingListening(t *testing.T) {
+	clearDoltEnv(t)
+	townRoot := t.TempDir()
+	writeTownServerMetadata(t, townRoot, freePort(t))
+
+	result := NewDoltPortCheck().Run(&CheckContext{TownRoot: townRoot})
+	if result.Status != StatusWarning {
+		t.Fatalf("Status = %v (%s), want Warning", result.Status, result.Message)
+	}
+}
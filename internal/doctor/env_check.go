@@ -40,6 +40,7 @@ func NewEnvVarsCheck() *EnvVarsCheck {
 			CheckName:        "env-vars",
 			CheckDescription: "Verify tmux session environment variables match expected values",
 			CheckCategory:    CategoryConfig,
+			CheckSerial:      true,
 		},
 	}
 }
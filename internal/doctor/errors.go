@@ -9,4 +9,17 @@ var (
 
 	// ErrSkippedNoStart is returned when a fix is skipped due to --no-start.
 	ErrSkippedNoStart = errors.New("skipped: --no-start suppresses daemon/agent startup")
+
+	// ErrSkippedDryRun is returned when a fix is not applied due to --dry-run.
+	// Checks that support dry runs wrap it with a description of what they
+	// would have done.
+	ErrSkippedDryRun = errors.New("skipped: --dry-run")
+
+	// ErrDependencyFailed is returned when a fix is skipped because a check
+	// it depends on is still failing. It is wrapped with that check's name.
+	ErrDependencyFailed = errors.New("prerequisite check still failing")
+
+	// ErrDependencyCycle is returned when a fix is skipped because its check
+	// is part of (or depends on) a cycle of check dependencies.
+	ErrDependencyCycle = errors.New("check dependencies form a cycle")
 )
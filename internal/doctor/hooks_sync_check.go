@@ -129,6 +129,11 @@ func (c *HooksSyncCheck) Fix(ctx *CheckContext) error {
 		}
 		data = append(data, '\n')
 
+		if _, err := hooks.BackupSettings(target.Path); err != nil {
+			errs = append(errs, fmt.Sprintf("%s: %v", target.DisplayKey(), err))
+			continue
+		}
+
 		if err := os.WriteFile(target.Path, data, 0644); err != nil {
 			errs = append(errs, fmt.Sprintf("%s: write: %v", target.DisplayKey(), err))
 			continue
@@ -20,6 +20,7 @@ func NewIdentityCollisionCheck() *IdentityCollisionCheck {
 			CheckName:        "identity-collision",
 			CheckDescription: "Check for agent identity collisions and stale locks",
 			CheckCategory:    CategoryInfrastructure,
+			CheckSerial:      true,
 		},
 	}
 }
@@ -160,7 +161,7 @@ func (c *IdentityCollisionCheck) Fix(ctx *CheckContext) error {
 	}
 
 	if cleaned > 0 {
-		fmt.Printf("  Cleaned %d stale lock(s)\n", cleaned)
+		fmt.Fprintf(ctx.Output(), "  Cleaned %d stale lock(s)\n", cleaned)
 	}
 
 	return nil
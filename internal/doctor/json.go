@@ -0,0 +1,68 @@
+package doctor
+
+import (
+	"encoding/json"
+	"io"
+)
+
+// JSON status values for CheckResultJSON.Status.
+const (
+	JSONStatusOK      = "ok"
+	JSONStatusWarn    = "warn"
+	JSONStatusFail    = "fail"
+	JSONStatusSkipped = "skipped"
+)
+
+// CheckResultJSON is the machine-readable form of a CheckResult, emitted by
+// gt doctor --json.
+type CheckResultJSON struct {
+	Name         string   `json:"name"`
+	Category     string   `json:"category,omitempty"`
+	Status       string   `json:"status"` // ok, warn, fail, or skipped
+	Message      string   `json:"message"`
+	Details      []string `json:"details,omitempty"`
+	FixHint      string   `json:"fix_hint,omitempty"`
+	FixAvailable bool     `json:"fix_available"`         // false when the check can't auto-fix (ErrCannotFix)
+	Fixed        bool     `json:"fixed"`                 // true when --fix repaired the issue
+	SkipReason   string   `json:"skip_reason,omitempty"` // set when a fix was skipped, e.g. ErrSkippedNoStart
+	ElapsedMs    int64    `json:"elapsed_ms"`
+}
+
+// NewCheckResultJSON converts a CheckResult for JSON output. A result whose
+// fix was skipped (--no-start or --dry-run) reports status "skipped".
+func NewCheckResultJSON(r *CheckResult) CheckResultJSON {
+	out := CheckResultJSON{
+		Name:         r.Name,
+		Category:     r.Category,
+		Message:      r.Message,
+		Details:      r.Details,
+		FixHint:      r.FixHint,
+		FixAvailable: r.Fixable,
+		Fixed:        r.Fixed,
+		SkipReason:   r.Skipped,
+		ElapsedMs:    r.Elapsed.Milliseconds(),
+	}
+	switch {
+	case r.Skipped != "":
+		out.Status = JSONStatusSkipped
+	case r.Status == StatusOK:
+		out.Status = JSONStatusOK
+	case r.Status == StatusWarning:
+		out.Status = JSONStatusWarn
+	default:
+		out.Status = JSONStatusFail
+	}
+	return out
+}
+
+// WriteJSON writes the report's check results to w as an indented JSON array,
+// in the order the checks ran.
+func (r *Report) WriteJSON(w io.Writer) error {
+	results := make([]CheckResultJSON, len(r.Checks))
+	for i, check := range r.Checks {
+		results[i] = NewCheckResultJSON(check)
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(results)
+}
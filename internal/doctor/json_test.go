@@ -0,0 +1,71 @@
+package doctor
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+)
+
+func TestReport_WriteJSON(t *testing.T) {
+	d := NewDoctor()
+	d.Register(newMockCheck("passing", StatusOK))
+	failing := newMockCheck("failing", StatusError)
+	failing.fixable = true
+	d.Register(failing)
+
+	report := d.Run(&CheckContext{TownRoot: "/test"})
+
+	var buf bytes.Buffer
+	if err := report.WriteJSON(&buf); err != nil {
+		t.Fatalf("WriteJSON: %v", err)
+	}
+	var got []CheckResultJSON
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("unmarshaling %s: %v", buf.String(), err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("got %d results, want 2", len(got))
+	}
+
+	want := []CheckResultJSON{
+		{Name: "passing", Status: JSONStatusOK, Message: "mock result"},
+		{Name: "failing", Status: JSONStatusFail, Message: "mock result", FixAvailable: true},
+	}
+	for i := range want {
+		if got[i].Name != want[i].Name || got[i].Status != want[i].Status ||
+			got[i].Message != want[i].Message || got[i].FixAvailable != want[i].FixAvailable ||
+			got[i].Fixed || got[i].SkipReason != "" {
+			t.Errorf("result[%d] = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestReport_WriteJSON_FixOutcomes(t *testing.T) {
+	d := NewDoctor()
+	noStart := newMockCheck("no-start", StatusWarning)
+	noStart.fixable = true
+	noStart.fixError = ErrSkippedNoStart
+	d.Register(noStart)
+	cannotFix := newMockCheck("cannot-fix", StatusWarning)
+	cannotFix.fixable = true
+	cannotFix.fixError = ErrCannotFix
+	d.Register(cannotFix)
+
+	report := d.Fix(&CheckContext{TownRoot: "/test", NoStart: true})
+
+	var buf bytes.Buffer
+	if err := report.WriteJSON(&buf); err != nil {
+		t.Fatalf("WriteJSON: %v", err)
+	}
+	var got []CheckResultJSON
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("unmarshaling %s: %v", buf.String(), err)
+	}
+
+	if got[0].Status != JSONStatusSkipped || got[0].SkipReason != ErrSkippedNoStart.Error() {
+		t.Errorf("no-start result = %+v, want skipped with reason %q", got[0], ErrSkippedNoStart.Error())
+	}
+	if got[1].Status != JSONStatusWarn || got[1].FixAvailable {
+		t.Errorf("cannot-fix result = %+v, want warn with fix_available=false", got[1])
+	}
+}
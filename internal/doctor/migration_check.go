@@ -238,6 +238,7 @@ func NewDoltServerReachableCheck() *DoltServerReachableCheck {
 			CheckName:        "dolt-server-reachable",
 			CheckDescription: "Check that Dolt server is reachable when server mode is configured",
 			CheckCategory:    CategoryInfrastructure,
+			CheckSerial:      true,
 		},
 	}
 }
@@ -370,6 +371,7 @@ func NewDoltOrphanedDatabaseCheck() *DoltOrphanedDatabaseCheck {
 				CheckName:        "dolt-orphaned-databases",
 				CheckDescription: "Detect orphaned databases in .dolt-data/",
 				CheckCategory:    CategoryCleanup,
+				CheckSerial:      true,
 			},
 		},
 	}
@@ -41,6 +41,7 @@ func NewOrphanSessionCheck() *OrphanSessionCheck {
 				CheckName:        "orphan-sessions",
 				CheckDescription: "Detect orphaned tmux sessions",
 				CheckCategory:    CategoryCleanup,
+				CheckSerial:      true,
 			},
 		},
 	}
@@ -290,6 +291,7 @@ func NewOrphanProcessCheck() *OrphanProcessCheck {
 			CheckName:        "orphan-processes",
 			CheckDescription: "Detect runtime processes outside tmux",
 			CheckCategory:    CategoryCleanup,
+			CheckSerial:      true,
 		},
 	}
 }
@@ -0,0 +1,157 @@
+package doctor
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/steveyegge/gastown/internal/beads"
+)
+
+// slingWispStore is the subset of beads operations the orphaned sling wisp
+// check needs. *beads.Beads satisfies it.
+type slingWispStore interface {
+	ListOpenSlingContexts() ([]*beads.Issue, error)
+	Show(id string) (*beads.Issue, error)
+	CloseSlingContext(contextID, reason string) error
+}
+
+// OrphanedSlingWispCheck detects open sling-context wisps whose work bead is
+// closed or no longer exists. These are left behind when an agent crashes
+// after finishing its work; the scheduler keeps them in its queue and would
+// dispatch the completed work again.
+type OrphanedSlingWispCheck struct {
+	FixableCheck
+	newStore func(townRoot string) slingWispStore
+	orphans  []orphanedSlingWisp
+}
+
+type orphanedSlingWisp struct {
+	contextID  string
+	workBeadID string
+	reason     string // "closed" or "not_found"
+}
+
+// NewOrphanedSlingWispCheck creates a new orphaned sling wisp check.
+func NewOrphanedSlingWispCheck() *OrphanedSlingWispCheck {
+	return &OrphanedSlingWispCheck{
+		FixableCheck: FixableCheck{
+			BaseCheck: BaseCheck{
+				CheckName:        "orphaned-sling-wisps",
+				CheckDescription: "Detect sling wisps whose work bead is closed or missing",
+				CheckCategory:    CategoryHooks,
+			},
+		},
+		newStore: func(townRoot string) slingWispStore {
+			return beads.NewWithBeadsDir(townRoot, filepath.Join(townRoot, ".beads"))
+		},
+	}
+}
+
+// SupportsDryRun reports that Fix honors CheckContext.DryRun.
+func (c *OrphanedSlingWispCheck) SupportsDryRun() bool { return true }
+
+// Run lists open sling contexts and flags those whose work bead is done.
+func (c *OrphanedSlingWispCheck) Run(ctx *CheckContext) *CheckResult {
+	c.orphans = nil
+
+	if _, err := os.Stat(filepath.Join(ctx.TownRoot, ".beads")); err != nil {
+		return &CheckResult{
+			Name:    c.Name(),
+			Status:  StatusOK,
+			Message: "No town beads directory",
+		}
+	}
+
+	store := c.newStore(ctx.TownRoot)
+	contexts, err := store.ListOpenSlingContexts()
+	if err != nil {
+		return &CheckResult{
+			Name:    c.Name(),
+			Status:  StatusWarning,
+			Message: "Could not list sling wisps",
+			Details: []string{err.Error()},
+		}
+	}
+
+	var details []string
+	for _, sc := range contexts {
+		fields := beads.ParseSlingContextFields(sc.Description)
+		if fields == nil || fields.WorkBeadID == "" {
+			continue
+		}
+
+		reason := ""
+		work, err := store.Show(fields.WorkBeadID)
+		switch {
+		case errors.Is(err, beads.ErrNotFound):
+			reason = "not_found"
+		case err != nil:
+			continue // Can't tell — leave it alone
+		case work.Status == "closed":
+			reason = "closed"
+		default:
+			continue
+		}
+
+		c.orphans = append(c.orphans, orphanedSlingWisp{
+			contextID:  sc.ID,
+			workBeadID: fields.WorkBeadID,
+			reason:     reason,
+		})
+		details = append(details, c.formatOrphan(c.orphans[len(c.orphans)-1]))
+	}
+
+	if len(c.orphans) == 0 {
+		return &CheckResult{
+			Name:    c.Name(),
+			Status:  StatusOK,
+			Message: "No orphaned sling wisps",
+		}
+	}
+
+	return &CheckResult{
+		Name:    c.Name(),
+		Status:  StatusWarning,
+		Message: fmt.Sprintf("Found %d sling wisp(s) for finished work", len(c.orphans)),
+		Details: details,
+		FixHint: "Run 'gt doctor --fix' to close them (add --dry-run to preview)",
+	}
+}
+
+// Fix closes the orphaned sling wisps found by Run. In a dry run it closes
+// nothing and reports which wisps it would close.
+func (c *OrphanedSlingWispCheck) Fix(ctx *CheckContext) error {
+	if len(c.orphans) == 0 {
+		return nil
+	}
+
+	if ctx.DryRun {
+		ids := make([]string, len(c.orphans))
+		for i, o := range c.orphans {
+			ids[i] = o.contextID
+		}
+		return fmt.Errorf("%w: would close %s", ErrSkippedDryRun, strings.Join(ids, ", "))
+	}
+
+	store := c.newStore(ctx.TownRoot)
+	var errs []string
+	for _, o := range c.orphans {
+		if err := store.CloseSlingContext(o.contextID, "orphaned: work bead "+o.workBeadID+" "+o.reason); err != nil {
+			errs = append(errs, fmt.Sprintf("%s: %v", o.contextID, err))
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("failed to close %d sling wisp(s): %s", len(errs), strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+func (c *OrphanedSlingWispCheck) formatOrphan(o orphanedSlingWisp) string {
+	if o.reason == "not_found" {
+		return fmt.Sprintf("%s: work bead %s not found", o.contextID, o.workBeadID)
+	}
+	return fmt.Sprintf("%s: work bead %s is closed", o.contextID, o.workBeadID)
+}
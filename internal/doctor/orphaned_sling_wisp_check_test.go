@@ -0,0 +1,130 @@
+package doctor
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/scheduler/capacity"
+)
+
+type fakeSlingWispStore struct {
+	contexts []*beads.Issue
+	work     map[string]*beads.Issue
+	closed   []string
+}
+
+func (f *fakeSlingWispStore) ListOpenSlingContexts() ([]*beads.Issue, error) {
+	return f.contexts, nil
+}
+
+func (f *fakeSlingWispStore) Show(id string) (*beads.Issue, error) {
+	if issue, ok := f.work[id]; ok {
+		return issue, nil
+	}
+	return nil, beads.ErrNotFound
+}
+
+func (f *fakeSlingWispStore) CloseSlingContext(contextID, _ string) error {
+	f.closed = append(f.closed, contextID)
+	return nil
+}
+
+func slingContext(id, workBeadID string) *beads.Issue {
+	return &beads.Issue{
+		ID:          id,
+		Description: beads.FormatSlingContextDescription(&capacity.SlingContextFields{WorkBeadID: workBeadID}),
+	}
+}
+
+func newOrphanedSlingWispTest(t *testing.T) (*OrphanedSlingWispCheck, *fakeSlingWispStore, *CheckContext) {
+	t.Helper()
+	townRoot := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(townRoot, ".beads"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	store := &fakeSlingWispStore{
+		contexts: []*beads.Issue{
+			slingContext("hq-ctx-done", "gt-done"),
+			slingContext("hq-ctx-open", "gt-open"),
+		},
+		work: map[string]*beads.Issue{
+			"gt-done": {ID: "gt-done", Status: "closed"},
+			"gt-open": {ID: "gt-open", Status: "open"},
+		},
+	}
+	check := NewOrphanedSlingWispCheck()
+	check.newStore = func(string) slingWispStore { return store }
+	return check, store, &CheckContext{TownRoot: townRoot}
+}
+
+func TestOrphanedSlingWispCheck_FlagsClosedWorkBead(t *testing.T) {
+	check, store, ctx := newOrphanedSlingWispTest(t)
+
+	result := check.Run(ctx)
+	if result.Status != StatusWarning {
+		t.Fatalf("Status = %v, want Warning", result.Status)
+	}
+	if len(result.Details) != 1 || !strings.Contains(result.Details[0], "hq-ctx-done") {
+		t.Errorf("Details = %v, want only hq-ctx-done flagged", result.Details)
+	}
+
+	if err := check.Fix(ctx); err != nil {
+		t.Fatalf("Fix() error: %v", err)
+	}
+	if len(store.closed) != 1 || store.closed[0] != "hq-ctx-done" {
+		t.Errorf("closed = %v, want [hq-ctx-done] (open work bead's wisp left alone)", store.closed)
+	}
+}
+
+func TestOrphanedSlingWispCheck_FlagsMissingWorkBead(t *testing.T) {
+	check, store, ctx := newOrphanedSlingWispTest(t)
+	store.contexts = append(store.contexts, slingContext("hq-ctx-gone", "gt-gone"))
+
+	result := check.Run(ctx)
+	if len(check.orphans) != 2 {
+		t.Fatalf("orphans = %+v, want closed and missing work beads", check.orphans)
+	}
+	if !strings.Contains(strings.Join(result.Details, "\n"), "gt-gone not found") {
+		t.Errorf("Details = %v, want missing work bead reported", result.Details)
+	}
+}
+
+func TestOrphanedSlingWispCheck_OpenWorkBeadLeftAlone(t *testing.T) {
+	check, store, ctx := newOrphanedSlingWispTest(t)
+	store.contexts = store.contexts[1:] // only the open work bead's wisp
+
+	if result := check.Run(ctx); result.Status != StatusOK {
+		t.Errorf("Status = %v, want OK for a wisp on open work", result.Status)
+	}
+	if err := check.Fix(ctx); err != nil {
+		t.Fatalf("Fix() error: %v", err)
+	}
+	if len(store.closed) != 0 {
+		t.Errorf("closed = %v, want nothing", store.closed)
+	}
+}
+
+func TestOrphanedSlingWispCheck_DryRun(t *testing.T) {
+	check, store, ctx := newOrphanedSlingWispTest(t)
+	ctx.DryRun = true
+
+	check.Run(ctx)
+	err := check.Fix(ctx)
+	if !errors.Is(err, ErrSkippedDryRun) || !strings.Contains(err.Error(), "hq-ctx-done") {
+		t.Errorf("Fix() in dry run = %v, want ErrSkippedDryRun naming hq-ctx-done", err)
+	}
+	if len(store.closed) != 0 {
+		t.Errorf("dry run closed %v, want nothing", store.closed)
+	}
+}
+
+func TestFixCheck_DryRunSkipsUnawareChecks(t *testing.T) {
+	ctx := &CheckContext{DryRun: true}
+	if err := fixCheck(NewStaleTaskDispatchCheck(), ctx); err != ErrSkippedDryRun {
+		t.Errorf("fixCheck() on dry-run-unaware check = %v, want ErrSkippedDryRun", err)
+	}
+}
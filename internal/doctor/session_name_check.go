@@ -53,6 +53,7 @@ func NewMalformedSessionNameCheck() *MalformedSessionNameCheck {
 				CheckName:        "session-name-format",
 				CheckDescription: "Detect sessions with outdated Gas Town naming format",
 				CheckCategory:    CategoryCleanup,
+				CheckSerial:      true,
 			},
 		},
 	}
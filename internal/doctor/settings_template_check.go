@@ -0,0 +1,140 @@
+package doctor
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/steveyegge/gastown/internal/claude"
+	"github.com/steveyegge/gastown/internal/hooks"
+)
+
+// SettingsTemplateCheck detects settings.json files that have drifted from
+// the current template for their role, such as agents still running hook
+// configs from an older gt release. Fix merges the template back in with
+// claude.MergeSettings, so user-added hooks and keys survive.
+type SettingsTemplateCheck struct {
+	FixableCheck
+	drifted []hooks.Target
+}
+
+// NewSettingsTemplateCheck creates a new settings template drift check.
+func NewSettingsTemplateCheck() *SettingsTemplateCheck {
+	return &SettingsTemplateCheck{
+		FixableCheck: FixableCheck{
+			BaseCheck: BaseCheck{
+				CheckName:        "settings-template-drift",
+				CheckDescription: "Verify settings.json files carry the current role template",
+				CheckCategory:    CategoryConfig,
+			},
+		},
+	}
+}
+
+// SupportsDryRun reports that Fix honors CheckContext.DryRun.
+func (c *SettingsTemplateCheck) SupportsDryRun() bool { return true }
+
+// Run compares each role's settings file against its template.
+func (c *SettingsTemplateCheck) Run(ctx *CheckContext) *CheckResult {
+	c.drifted = nil
+
+	targets, err := hooks.DiscoverTargets(ctx.TownRoot)
+	if err != nil {
+		return &CheckResult{
+			Name:    c.Name(),
+			Status:  StatusWarning,
+			Message: fmt.Sprintf("Failed to discover settings files: %v", err),
+		}
+	}
+
+	var details []string
+	checked := 0
+	for _, target := range targets {
+		if _, err := os.Stat(target.Path); err != nil {
+			continue // Missing files are reported by claude-settings
+		}
+		checked++
+
+		drift, err := claude.SettingsDrift(filepath.Dir(target.Path), target.Role, "", filepath.Base(target.Path))
+		if err != nil {
+			details = append(details, fmt.Sprintf("%s: %v", target.DisplayKey(), err))
+			continue
+		}
+		if len(drift) == 0 {
+			continue
+		}
+
+		c.drifted = append(c.drifted, target)
+		details = append(details, fmt.Sprintf("%s: %s", target.DisplayKey(), strings.Join(driftSections(drift), ", ")))
+	}
+
+	if len(c.drifted) == 0 && len(details) == 0 {
+		return &CheckResult{
+			Name:    c.Name(),
+			Status:  StatusOK,
+			Message: fmt.Sprintf("All %d settings file(s) match their templates", checked),
+		}
+	}
+	if len(c.drifted) == 0 {
+		return &CheckResult{
+			Name:    c.Name(),
+			Status:  StatusWarning,
+			Message: "Could not compare some settings files to their templates",
+			Details: details,
+		}
+	}
+
+	return &CheckResult{
+		Name:    c.Name(),
+		Status:  StatusWarning,
+		Message: fmt.Sprintf("%d settings file(s) drifted from their templates", len(c.drifted)),
+		Details: details,
+		FixHint: "Run 'gt doctor --fix' to merge in the current templates (customizations are kept)",
+	}
+}
+
+// Fix merges the current template into each drifted settings file. In a dry
+// run it changes nothing and reports which files it would merge.
+func (c *SettingsTemplateCheck) Fix(ctx *CheckContext) error {
+	if len(c.drifted) == 0 {
+		return nil
+	}
+
+	if ctx.DryRun {
+		paths := make([]string, len(c.drifted))
+		for i, target := range c.drifted {
+			paths[i] = target.Path
+		}
+		return fmt.Errorf("%w: would merge templates into %s", ErrSkippedDryRun, strings.Join(paths, ", "))
+	}
+
+	var errs []string
+	for _, target := range c.drifted {
+		if err := claude.MergeSettings(filepath.Dir(target.Path), target.Role, "", filepath.Base(target.Path)); err != nil {
+			errs = append(errs, fmt.Sprintf("%s: %v", target.DisplayKey(), err))
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("%s", strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// driftSections collapses flattened settings keys to the sections they belong
+// to, e.g. "hooks.Stop[0].hooks[0].command" to "hooks.Stop", so a stale hook
+// is reported once rather than once per field.
+func driftSections(keys []string) []string {
+	var sections []string
+	seen := make(map[string]bool)
+	for _, key := range keys {
+		if i := strings.Index(key, "["); i >= 0 {
+			key = key[:i]
+		}
+		if !seen[key] {
+			seen[key] = true
+			sections = append(sections, key)
+		}
+	}
+	return sections
+}
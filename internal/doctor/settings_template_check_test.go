@@ -0,0 +1,115 @@
+package doctor
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/gastown/internal/hooks"
+)
+
+// driftedMayorSettings is an old interactive settings file: no version
+// marker, a pre-"--hook" prime command, no Stop hook, plus a user hook and a
+// user key that must survive the fix.
+const driftedMayorSettings = `{
+  "model": "opus",
+  "hooks": {
+    "PreToolUse": [
+      {
+        "matcher": "Bash(rm -rf*)",
+        "hooks": [{"type": "command", "command": "echo no && exit 2"}]
+      }
+    ],
+    "SessionStart": [
+      {
+        "matcher": "",
+        "hooks": [{"type": "command", "command": "gt prime"}]
+      }
+    ]
+  }
+}
+`
+
+func writeDriftedMayorSettings(t *testing.T) (townRoot, path string) {
+	t.Helper()
+	townRoot = t.TempDir()
+	path = filepath.Join(townRoot, "mayor", ".claude", "settings.json")
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(driftedMayorSettings), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return townRoot, path
+}
+
+func TestSettingsTemplateCheck_DriftReportedAndFixed(t *testing.T) {
+	townRoot, path := writeDriftedMayorSettings(t)
+	check := NewSettingsTemplateCheck()
+	ctx := &CheckContext{TownRoot: townRoot}
+
+	result := check.Run(ctx)
+	if result.Status != StatusWarning {
+		t.Fatalf("Status = %v, want warning (%s)", result.Status, result.Message)
+	}
+	details := strings.Join(result.Details, "\n")
+	for _, want := range []string{"mayor:", "_gastown_settings_version", "hooks.SessionStart", "hooks.Stop"} {
+		if !strings.Contains(details, want) {
+			t.Errorf("details missing %q:\n%s", want, details)
+		}
+	}
+
+	if err := check.Fix(ctx); err != nil {
+		t.Fatalf("Fix: %v", err)
+	}
+	if result := check.Run(ctx); result.Status != StatusOK {
+		t.Fatalf("after fix: Status = %v, want OK (%v)", result.Status, result.Details)
+	}
+
+	settings, err := hooks.LoadSettings(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := settings.Extra["model"]; !ok {
+		t.Error("user key \"model\" was dropped by the fix")
+	}
+	if settings.Hooks.PreToolUse[0].Matcher != "Bash(rm -rf*)" {
+		t.Errorf("user PreToolUse hook was not kept first: %+v", settings.Hooks.PreToolUse)
+	}
+	if got := settings.Hooks.SessionStart[0].Hooks[0].Command; !strings.Contains(got, "gt prime --hook") {
+		t.Errorf("SessionStart command = %q, want the template's", got)
+	}
+	if len(settings.Hooks.Stop) == 0 {
+		t.Error("template Stop hook was not added")
+	}
+}
+
+func TestSettingsTemplateCheck_DryRunLeavesFile(t *testing.T) {
+	townRoot, path := writeDriftedMayorSettings(t)
+	check := NewSettingsTemplateCheck()
+	ctx := &CheckContext{TownRoot: townRoot, DryRun: true}
+
+	if result := check.Run(ctx); result.Status != StatusWarning {
+		t.Fatalf("Status = %v, want warning", result.Status)
+	}
+	err := check.Fix(ctx)
+	if !errors.Is(err, ErrSkippedDryRun) || !strings.Contains(err.Error(), path) {
+		t.Fatalf("Fix error = %v, want dry-run report naming %s", err, path)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != driftedMayorSettings {
+		t.Error("dry run modified the settings file")
+	}
+}
+
+func TestSettingsTemplateCheck_NoSettingsFiles(t *testing.T) {
+	check := NewSettingsTemplateCheck()
+	if result := check.Run(&CheckContext{TownRoot: t.TempDir()}); result.Status != StatusOK {
+		t.Errorf("Status = %v, want OK (%s)", result.Status, result.Message)
+	}
+}
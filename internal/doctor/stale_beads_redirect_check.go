@@ -365,7 +365,7 @@ func (c *StaleBeadsRedirectCheck) verifyRedirectTopology(ctx *CheckContext, rigD
 			// Other errors may indicate real problems - log them in verbose mode.
 			if ctx.Verbose && !strings.Contains(err.Error(), "no beads found") {
 				relPath, _ := filepath.Rel(townRoot, worktreePath)
-				fmt.Printf("  [verbose] skipping %s: %v\n", relPath, err)
+				fmt.Fprintf(ctx.Output(), "  [verbose] skipping %s: %v\n", relPath, err)
 			}
 			continue
 		}
@@ -22,6 +22,7 @@ func NewThemeCheck() *ThemeCheck {
 				CheckName:        "themes",
 				CheckDescription: "Check tmux session theme configuration",
 				CheckCategory:    CategoryConfig,
+				CheckSerial:      true,
 			},
 		},
 	}
@@ -0,0 +1,116 @@
+package doctor
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"regexp"
+	"strings"
+
+	"github.com/steveyegge/gastown/internal/deps"
+)
+
+// minTmuxVersion is the oldest tmux release Gas Town is tested against.
+const minTmuxVersion = "3.0"
+
+// errTmuxNotFound is returned by the tmux runner when tmux isn't in PATH.
+var errTmuxNotFound = errors.New("tmux not found in PATH")
+
+// tmuxVersionRe matches `tmux -V` output such as "tmux 3.3a" or
+// "tmux next-3.4".
+var tmuxVersionRe = regexp.MustCompile(`tmux (?:next-)?(\d+\.\d+)`)
+
+// TmuxBinaryCheck verifies that tmux is installed, meets the minimum version,
+// and that its server answers (or simply isn't running yet). Sessions for
+// every agent live in tmux, so sling and handoff fail without it.
+type TmuxBinaryCheck struct {
+	BaseCheck
+	run func(args ...string) (string, error) // Runs tmux; replaced in tests
+}
+
+// NewTmuxBinaryCheck creates a new tmux availability check.
+func NewTmuxBinaryCheck() *TmuxBinaryCheck {
+	return &TmuxBinaryCheck{
+		BaseCheck: BaseCheck{
+			CheckName:        "tmux-binary",
+			CheckDescription: "Check that tmux is installed and its server is reachable",
+			CheckCategory:    CategoryInfrastructure,
+		},
+		run: runTmux,
+	}
+}
+
+// runTmux runs tmux with args and returns its combined output.
+func runTmux(args ...string) (string, error) {
+	path, err := exec.LookPath("tmux")
+	if err != nil {
+		return "", errTmuxNotFound
+	}
+	out, err := exec.Command(path, args...).CombinedOutput()
+	return strings.TrimSpace(string(out)), err
+}
+
+// Run checks the tmux version and that `tmux list-sessions` succeeds.
+func (c *TmuxBinaryCheck) Run(ctx *CheckContext) *CheckResult {
+	out, err := c.run("-V")
+	if errors.Is(err, errTmuxNotFound) {
+		return &CheckResult{
+			Name:    c.Name(),
+			Status:  StatusError,
+			Message: "tmux not found in PATH",
+			Details: []string{"Every Gas Town agent runs in a tmux session"},
+			FixHint: "Install tmux 3.0+ (e.g. 'brew install tmux' or 'apt install tmux')",
+		}
+	}
+	if err != nil {
+		return &CheckResult{
+			Name:    c.Name(),
+			Status:  StatusError,
+			Message: "tmux found but 'tmux -V' failed",
+			Details: []string{fmt.Sprintf("%v: %s", err, out)},
+			FixHint: "Reinstall tmux 3.0+",
+		}
+	}
+
+	matches := tmuxVersionRe.FindStringSubmatch(out)
+	if matches == nil {
+		return &CheckResult{
+			Name:    c.Name(),
+			Status:  StatusWarning,
+			Message: fmt.Sprintf("Could not parse tmux version from %q", out),
+		}
+	}
+	version := matches[1]
+	if deps.CompareVersions(version, minTmuxVersion) < 0 {
+		return &CheckResult{
+			Name:    c.Name(),
+			Status:  StatusWarning,
+			Message: fmt.Sprintf("tmux %s is too old (minimum: %s)", version, minTmuxVersion),
+			Details: []string{"Older tmux releases lack options Gas Town sets on agent sessions"},
+			FixHint: fmt.Sprintf("Upgrade tmux to %s or newer", minTmuxVersion),
+		}
+	}
+
+	if out, err := c.run("list-sessions"); err != nil && !isTmuxNoServer(out) {
+		return &CheckResult{
+			Name:    c.Name(),
+			Status:  StatusError,
+			Message: fmt.Sprintf("tmux %s installed but the server is not reachable", version),
+			Details: []string{strings.TrimSpace(fmt.Sprintf("%v: %s", err, out))},
+			FixHint: "Check that $TMUX_TMPDIR (default /tmp) is writable; if the server crashed, remove its stale socket (/tmp/tmux-$(id -u)/default) and retry",
+		}
+	}
+
+	return &CheckResult{
+		Name:    c.Name(),
+		Status:  StatusOK,
+		Message: fmt.Sprintf("tmux %s", version),
+	}
+}
+
+// isTmuxNoServer reports whether tmux output means no server is running yet,
+// which is fine: the first agent session starts one.
+func isTmuxNoServer(out string) bool {
+	return strings.Contains(out, "no server running") ||
+		(strings.Contains(out, "error connecting to") && strings.Contains(out, "No such file or directory"))
+}
@@ -0,0 +1,95 @@
+package doctor
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+// fakeTmux returns canned output for each tmux subcommand.
+func fakeTmux(version string, versionErr error, listOut string, listErr error) func(args ...string) (string, error) {
+	return func(args ...string) (string, error) {
+		switch args[0] {
+		case "-V":
+			return version, versionErr
+		case "list-sessions":
+			return listOut, listErr
+		}
+		return "", errors.New("unexpected tmux call: " + strings.Join(args, " "))
+	}
+}
+
+func TestTmuxBinaryCheck(t *testing.T) {
+	exitErr := errors.New("exit status 1")
+	tests := []struct {
+		name       string
+		run        func(args ...string) (string, error)
+		wantStatus CheckStatus
+		wantMsg    string
+	}{
+		{
+			name:       "installed with sessions",
+			run:        fakeTmux("tmux 3.3a", nil, "gt-mayor: 1 windows", nil),
+			wantStatus: StatusOK,
+			wantMsg:    "tmux 3.3",
+		},
+		{
+			name:       "installed, no server running",
+			run:        fakeTmux("tmux 3.4", nil, "no server running on /tmp/tmux-1000/default", exitErr),
+			wantStatus: StatusOK,
+			wantMsg:    "tmux 3.4",
+		},
+		{
+			name:       "development build",
+			run:        fakeTmux("tmux next-3.5", nil, "", nil),
+			wantStatus: StatusOK,
+			wantMsg:    "tmux 3.5",
+		},
+		{
+			name:       "too old",
+			run:        fakeTmux("tmux 2.9a", nil, "", nil),
+			wantStatus: StatusWarning,
+			wantMsg:    "too old",
+		},
+		{
+			name:       "missing",
+			run:        fakeTmux("", errTmuxNotFound, "", nil),
+			wantStatus: StatusError,
+			wantMsg:    "not found",
+		},
+		{
+			name:       "server unreachable",
+			run:        fakeTmux("tmux 3.3a", nil, "server exited unexpectedly", exitErr),
+			wantStatus: StatusError,
+			wantMsg:    "not reachable",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			check := NewTmuxBinaryCheck()
+			check.run = tt.run
+
+			result := check.Run(&CheckContext{TownRoot: t.TempDir()})
+			if result.Status != tt.wantStatus {
+				t.Errorf("Status = %v, want %v (%s)", result.Status, tt.wantStatus, result.Message)
+			}
+			if !strings.Contains(result.Message, tt.wantMsg) {
+				t.Errorf("Message = %q, want it to contain %q", result.Message, tt.wantMsg)
+			}
+			if tt.wantStatus != StatusOK && result.FixHint == "" {
+				t.Error("failing result has no FixHint")
+			}
+		})
+	}
+}
+
+func TestTmuxBinaryCheck_NotFixable(t *testing.T) {
+	check := NewTmuxBinaryCheck()
+	if check.CanFix() {
+		t.Error("tmux-binary should not be auto-fixable")
+	}
+	if err := check.Fix(&CheckContext{}); !errors.Is(err, ErrCannotFix) {
+		t.Errorf("Fix() = %v, want ErrCannotFix", err)
+	}
+}
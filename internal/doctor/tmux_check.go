@@ -24,6 +24,7 @@ func NewLinkedPaneCheck() *LinkedPaneCheck {
 				CheckName:        "linked-panes",
 				CheckDescription: "Detect tmux sessions sharing panes (causes crosstalk)",
 				CheckCategory:    CategoryInfrastructure,
+				CheckSerial:      true,
 			},
 		},
 	}
@@ -33,7 +34,7 @@ func NewLinkedPaneCheck() *LinkedPaneCheck {
 func (c *LinkedPaneCheck) Run(ctx *CheckContext) *CheckResult {
 	t := tmux.NewTmux()
 
-	sessions, err := t.ListSessions()
+	gtSessions, err := t.ListGastownSessions(session.IsKnownSession)
 	if err != nil {
 		return &CheckResult{
 			Name:    c.Name(),
@@ -43,14 +44,6 @@ func (c *LinkedPaneCheck) Run(ctx *CheckContext) *CheckResult {
 		}
 	}
 
-	// Filter to Gas Town sessions only
-	var gtSessions []string
-	for _, s := range sessions {
-		if session.IsKnownSession(s) {
-			gtSessions = append(gtSessions, s)
-		}
-	}
-
 	if len(gtSessions) < 2 {
 		return &CheckResult{
 			Name:    c.Name(),
@@ -4,6 +4,7 @@ package doctor
 import (
 	"fmt"
 	"io"
+	"os"
 	"time"
 
 	"github.com/steveyegge/gastown/internal/ui"
@@ -64,6 +65,19 @@ type CheckContext struct {
 	Verbose         bool   // Enable verbose output
 	RestartSessions bool   // Restart patrol sessions when fixing (requires explicit --restart-sessions flag)
 	NoStart         bool   // Suppress starting daemon/agents during --fix
+	DryRun          bool   // Report what --fix would change without changing it
+
+	// Out receives progress notes printed by checks and fixes. Nil = os.Stdout.
+	// JSON output sets it to stderr so stdout stays valid JSON.
+	Out io.Writer
+}
+
+// Output returns the writer checks and fixes print progress notes to.
+func (ctx *CheckContext) Output() io.Writer {
+	if ctx.Out == nil {
+		return os.Stdout
+	}
+	return ctx.Out
 }
 
 // RigPath returns the full path to the rig directory.
@@ -88,6 +102,8 @@ type CheckResult struct {
 	Category string        // Category for grouping (e.g., CategoryCore)
 	Elapsed  time.Duration // How long the check took to run
 	Fixed    bool          // True if this check was auto-fixed
+	Fixable  bool          // True if the check supports auto-fix
+	Skipped  string        // Why a fix was skipped (e.g. ErrSkippedNoStart); empty if not skipped
 }
 
 // Check defines the interface for a health check.
@@ -116,6 +132,7 @@ type ReportSummary struct {
 	Warnings    int
 	Errors      int
 	Fixed       int           // Checks that were auto-fixed
+	NotStarted  int           // Fixes skipped because --no-start forbids starting the daemon/agents
 	Slow        int           // Checks that took longer than threshold (counted during Print)
 	SlowestName string        // Name of the slowest check
 	SlowestTime time.Duration // Duration of the slowest check
@@ -154,6 +171,9 @@ func (r *Report) Add(result *CheckResult) {
 	if result.Fixed {
 		r.Summary.Fixed++
 	}
+	if result.Skipped == ErrSkippedNoStart.Error() {
+		r.Summary.NotStarted++
+	}
 
 	// Track the slowest check
 	if result.Elapsed > r.Summary.SlowestTime {
@@ -342,6 +362,9 @@ func (r *Report) printSummary(w io.Writer, slowThreshold time.Duration) {
 	if r.Summary.Fixed > 0 {
 		summary += fmt.Sprintf("  🔧 %d fixed", r.Summary.Fixed)
 	}
+	if r.Summary.NotStarted > 0 {
+		summary += fmt.Sprintf("  ⏸ %d not started (--no-start)", r.Summary.NotStarted)
+	}
 	if slowThreshold > 0 && r.Summary.Slow > 0 {
 		summary += fmt.Sprintf("  ⏳ %d slow (slowest: %s %s)",
 			r.Summary.Slow,
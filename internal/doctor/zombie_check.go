@@ -24,6 +24,7 @@ func NewZombieSessionCheck() *ZombieSessionCheck {
 				CheckName:        "zombie-sessions",
 				CheckDescription: "Detect tmux sessions with dead Claude processes",
 				CheckCategory:    CategoryCleanup,
+				CheckSerial:      true,
 			},
 		},
 	}
@@ -0,0 +1,165 @@
+package doltserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// BackupManifestFile is the file written at the root of every backup
+// directory, listing the databases it contains.
+const BackupManifestFile = "manifest.json"
+
+// BackupManifest describes a backup produced by BackupDatabases.
+type BackupManifest struct {
+	CreatedAt time.Time `json:"created_at"`
+	Databases []string  `json:"databases"`
+}
+
+// BackupDatabases snapshots every database on the town's running Dolt server
+// into a new timestamped directory under destDir and returns its path.
+//
+// Each database's working set is committed first so the dump matches what
+// the server serves, then dumped with Export to <database>.sql. A manifest
+// listing the databases is written last, so a directory without one is an
+// incomplete backup.
+func BackupDatabases(townRoot, destDir string) (string, error) {
+	running, _, err := IsRunning(townRoot)
+	if err != nil {
+		return "", fmt.Errorf("checking Dolt server: %w", err)
+	}
+	if !running {
+		return "", fmt.Errorf("Dolt server is not running — start with 'gt dolt start'")
+	}
+
+	config := DefaultConfig(townRoot)
+	databases, err := ListDatabases(townRoot)
+	if err != nil {
+		return "", fmt.Errorf("listing databases: %w", err)
+	}
+	if len(databases) == 0 {
+		return "", fmt.Errorf("no databases to back up in %s", config.DataDir)
+	}
+
+	now := time.Now()
+	backupDir := filepath.Join(destDir, "dolt-backup-"+now.Format("20060102-150405"))
+	if _, err := os.Stat(backupDir); err == nil {
+		return "", fmt.Errorf("backup directory %s already exists", backupDir)
+	}
+	if err := os.MkdirAll(backupDir, 0755); err != nil {
+		return "", fmt.Errorf("creating backup directory: %w", err)
+	}
+
+	for _, db := range databases {
+		if err := validateDatabaseName(db); err != nil {
+			return "", err
+		}
+		commit := fmt.Sprintf("USE `%s`;\nCALL DOLT_ADD('-A');\nCALL DOLT_COMMIT('--allow-empty', '-m', 'gt dolt backup');\n", db)
+		if err := configExecScript(config, commit); err != nil {
+			return "", fmt.Errorf("committing %s: %w", db, err)
+		}
+		if err := Export(config, db, filepath.Join(backupDir, db+".sql")); err != nil {
+			return "", fmt.Errorf("dumping %s: %w", db, err)
+		}
+	}
+
+	manifest, err := json.MarshalIndent(BackupManifest{CreatedAt: now.UTC(), Databases: databases}, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("encoding manifest: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(backupDir, BackupManifestFile), manifest, 0644); err != nil {
+		return "", fmt.Errorf("writing manifest: %w", err)
+	}
+	return backupDir, nil
+}
+
+// RestoreOptions controls RestoreDatabases.
+type RestoreOptions struct {
+	// Force replaces databases that already have tables. Without it, restore
+	// refuses to start if any target database is non-empty.
+	Force bool
+}
+
+// ReadBackupManifest validates a backup directory produced by BackupDatabases
+// and returns its manifest. Every listed database must have a non-empty dump.
+func ReadBackupManifest(backupDir string) (*BackupManifest, error) {
+	data, err := os.ReadFile(filepath.Join(backupDir, BackupManifestFile))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("%s is not a complete backup (no %s)", backupDir, BackupManifestFile)
+		}
+		return nil, fmt.Errorf("reading manifest: %w", err)
+	}
+	var manifest BackupManifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("parsing manifest: %w", err)
+	}
+	if len(manifest.Databases) == 0 {
+		return nil, fmt.Errorf("backup %s lists no databases", backupDir)
+	}
+	for _, db := range manifest.Databases {
+		if err := validateDatabaseName(db); err != nil {
+			return nil, fmt.Errorf("backup manifest: %w", err)
+		}
+		info, err := os.Stat(filepath.Join(backupDir, db+".sql"))
+		if err != nil {
+			return nil, fmt.Errorf("backup is missing dump for %s: %w", db, err)
+		}
+		if info.Size() == 0 {
+			return nil, fmt.Errorf("backup dump for %s is empty", db)
+		}
+	}
+	return &manifest, nil
+}
+
+// RestoreDatabases loads every database in a backup directory back into the
+// town's running Dolt server. The backup is validated and all targets are
+// checked before anything is written, so a refused restore leaves the server
+// untouched.
+func RestoreDatabases(townRoot, backupDir string, opts RestoreOptions) error {
+	manifest, err := ReadBackupManifest(backupDir)
+	if err != nil {
+		return err
+	}
+
+	running, _, err := IsRunning(townRoot)
+	if err != nil {
+		return fmt.Errorf("checking Dolt server: %w", err)
+	}
+	if !running {
+		return fmt.Errorf("Dolt server is not running — start with 'gt dolt start'")
+	}
+
+	config := DefaultConfig(townRoot)
+	if !opts.Force {
+		existing, err := ListDatabases(townRoot)
+		if err != nil {
+			return fmt.Errorf("listing databases: %w", err)
+		}
+		present := make(map[string]bool, len(existing))
+		for _, db := range existing {
+			present[db] = true
+		}
+		for _, db := range manifest.Databases {
+			if !present[db] {
+				continue
+			}
+			tables, err := configListTables(config, db)
+			if err != nil {
+				return fmt.Errorf("inspecting database %s: %w", db, err)
+			}
+			if len(tables) > 0 {
+				return fmt.Errorf("database %q is not empty (%d tables) — use --force to overwrite", db, len(tables))
+			}
+		}
+	}
+
+	for _, db := range manifest.Databases {
+		if err := Import(config, db, filepath.Join(backupDir, db+".sql"), opts.Force); err != nil {
+			return fmt.Errorf("restoring %s: %w", db, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+package doltserver
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeTestBackup(t *testing.T, manifest string, dumps map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	if manifest != "" {
+		if err := os.WriteFile(filepath.Join(dir, BackupManifestFile), []byte(manifest), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for name, content := range dumps {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestReadBackupManifest(t *testing.T) {
+	dir := writeTestBackup(t, `{"databases":["hq","gastown"]}`, map[string]string{
+		"hq.sql":      "CREATE TABLE t (id INT);",
+		"gastown.sql": "CREATE TABLE t (id INT);",
+	})
+	manifest, err := ReadBackupManifest(dir)
+	if err != nil {
+		t.Fatalf("ReadBackupManifest() error: %v", err)
+	}
+	if strings.Join(manifest.Databases, ",") != "hq,gastown" {
+		t.Errorf("databases = %v", manifest.Databases)
+	}
+}
+
+func TestReadBackupManifest_Invalid(t *testing.T) {
+	tests := []struct {
+		name     string
+		manifest string
+		dumps    map[string]string
+		wantErr  string
+	}{
+		{"no manifest", "", map[string]string{"hq.sql": "x"}, "not a complete backup"},
+		{"bad json", "{", nil, "parsing manifest"},
+		{"no databases", `{"databases":[]}`, nil, "lists no databases"},
+		{"missing dump", `{"databases":["hq"]}`, nil, "missing dump for hq"},
+		{"empty dump", `{"databases":["hq"]}`, map[string]string{"hq.sql": ""}, "dump for hq is empty"},
+		{"unsafe name", `{"databases":["../etc"]}`, nil, "invalid database name"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := writeTestBackup(t, tt.manifest, tt.dumps)
+			_, err := ReadBackupManifest(dir)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("ReadBackupManifest() error = %v, want containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,51 @@
+package doltserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/steveyegge/gastown/internal/util"
+)
+
+// SetDataDir records dir as the town's Dolt data directory (dolt_data_dir in
+// the town's beads metadata). Every DefaultConfig call for townRoot then uses
+// it, so Start, IsRunning, and the SQL helpers agree on one directory without
+// depending on cwd or GT_DOLT_* variables. A relative dir is resolved against
+// townRoot; an empty dir restores the default <townRoot>/.dolt-data.
+func SetDataDir(townRoot, dir string) error {
+	if townRoot == "" {
+		return fmt.Errorf("town root is required")
+	}
+	if dir != "" && !filepath.IsAbs(dir) {
+		dir = filepath.Join(townRoot, dir)
+	}
+
+	metadataPath := filepath.Join(townRoot, ".beads", "metadata.json")
+	mu := getMetadataMu(metadataPath)
+	mu.Lock()
+	defer mu.Unlock()
+
+	existing := make(map[string]interface{})
+	if data, err := os.ReadFile(metadataPath); err == nil {
+		_ = json.Unmarshal(data, &existing) // best effort
+	}
+	if dir == "" {
+		delete(existing, "dolt_data_dir")
+	} else {
+		existing["dolt_data_dir"] = filepath.Clean(dir)
+	}
+
+	data, err := json.MarshalIndent(existing, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshaling metadata: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(metadataPath), 0755); err != nil {
+		return fmt.Errorf("creating beads dir: %w", err)
+	}
+	if err := util.AtomicWriteFile(metadataPath, append(data, '\n'), 0600); err != nil {
+		return fmt.Errorf("writing metadata.json: %w", err)
+	}
+	return nil
+}
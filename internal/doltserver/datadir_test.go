@@ -0,0 +1,76 @@
+package doltserver
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSetDataDir_UsedByDownstreamCommands(t *testing.T) {
+	t.Setenv("GT_DOLT_HOST", "")
+	t.Setenv("GT_DOLT_PORT", "13306") // env port must not disturb the configured dir
+	townRoot := t.TempDir()
+	custom := filepath.Join(t.TempDir(), "shared-dolt")
+
+	if err := SetDataDir(townRoot, custom); err != nil {
+		t.Fatalf("SetDataDir: %v", err)
+	}
+
+	config := DefaultConfig(townRoot)
+	if config.DataDir != custom {
+		t.Fatalf("DataDir = %q, want %q", config.DataDir, custom)
+	}
+	if config.Port != 13306 {
+		t.Errorf("Port = %d, want 13306 from GT_DOLT_PORT", config.Port)
+	}
+
+	// serverExecSQL and the other SQL helpers run dolt from the data dir.
+	cmd := buildDoltSQLCmd(context.Background(), config, "-q", "SELECT 1")
+	if cmd.Dir != custom {
+		t.Errorf("dolt sql Dir = %q, want %q", cmd.Dir, custom)
+	}
+	if got, want := RigDatabaseDir(townRoot, "gastown"), filepath.Join(custom, "gastown"); got != want {
+		t.Errorf("RigDatabaseDir = %q, want %q", got, want)
+	}
+}
+
+func TestSetDataDir_RelativeAndReset(t *testing.T) {
+	t.Setenv("GT_DOLT_PORT", "")
+	townRoot := t.TempDir()
+	metadataPath := filepath.Join(townRoot, ".beads", "metadata.json")
+	if err := os.MkdirAll(filepath.Dir(metadataPath), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(metadataPath, []byte(`{"database":"dolt","dolt_server_port":3400}`), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := SetDataDir(townRoot, "data/dolt"); err != nil {
+		t.Fatalf("SetDataDir: %v", err)
+	}
+	if got, want := DefaultConfig(townRoot).DataDir, filepath.Join(townRoot, "data", "dolt"); got != want {
+		t.Errorf("DataDir = %q, want %q", got, want)
+	}
+
+	// Other metadata fields survive.
+	data, err := os.ReadFile(metadataPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var metadata map[string]interface{}
+	if err := json.Unmarshal(data, &metadata); err != nil {
+		t.Fatal(err)
+	}
+	if metadata["database"] != "dolt" || metadata["dolt_server_port"] != float64(3400) {
+		t.Errorf("metadata fields lost: %v", metadata)
+	}
+
+	if err := SetDataDir(townRoot, ""); err != nil {
+		t.Fatalf("SetDataDir reset: %v", err)
+	}
+	if got, want := DefaultConfig(townRoot).DataDir, filepath.Join(townRoot, ".dolt-data"); got != want {
+		t.Errorf("DataDir after reset = %q, want %q", got, want)
+	}
+}
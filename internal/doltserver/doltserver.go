@@ -32,6 +32,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"net"
 	"os"
 	"os/exec"
@@ -124,8 +125,9 @@ func setDoltGlobalConfig(key, value string) error {
 // Default configuration
 const (
 	DefaultPort           = 3307
-	DefaultUser           = "root" // Default Dolt user (no password for local access)
-	DefaultMaxConnections = 200    // Support concurrent crews (10 conns/pool × ~15 bd processes + headroom)
+	DefaultUser           = "root"           // Default Dolt user (no password for local access)
+	DefaultMaxConnections = 200              // Support concurrent crews (10 conns/pool × ~15 bd processes + headroom)
+	DefaultQueryTimeout   = 30 * time.Second // Per-execution deadline so a wedged server can't hang agents
 )
 
 // metadataMu provides per-path mutexes for EnsureMetadata goroutine synchronization.
@@ -172,26 +174,50 @@ type Config struct {
 	// Set to 0 to use the Dolt default (1000). Gas Town defaults to 50 to prevent
 	// connection storms during mass polecat slings.
 	MaxConnections int
+
+	// QueryTimeout bounds each SQL execution (query or script) against the
+	// server. Executions that exceed it fail with an error wrapping
+	// ErrQueryTimeout.
+	QueryTimeout time.Duration
+
+	// AutoPort lets Start move to the next free port when Port is held by
+	// something other than this town's server. See SelectPort.
+	AutoPort bool
+
+	// SlowQueryThreshold, when positive, logs any query or script that takes
+	// longer than this to stderr. Zero disables slow-query logging.
+	SlowQueryThreshold time.Duration
 }
 
 // DefaultConfig returns the default Dolt server configuration.
+// A port previously chosen by SelectPort (recorded as dolt_server_port in the
+// town's beads metadata) replaces DefaultPort, and a data directory recorded
+// by SetDataDir replaces <townRoot>/.dolt-data.
 // Environment variables override defaults when set:
 //   - GT_DOLT_HOST → Host
 //   - GT_DOLT_PORT → Port
 //   - GT_DOLT_USER → User
 //   - GT_DOLT_PASSWORD → Password
+//   - GT_DOLT_QUERY_TIMEOUT → QueryTimeout (Go duration, e.g. "45s")
+//   - GT_DOLT_AUTO_PORT → AutoPort ("1" or "true")
+//   - GT_DOLT_SLOW_QUERY → SlowQueryThreshold (Go duration, e.g. "500ms")
 func DefaultConfig(townRoot string) *Config {
 	daemonDir := filepath.Join(townRoot, "daemon")
 	config := &Config{
 		TownRoot:       townRoot,
 		Port:           DefaultPort,
 		User:           DefaultUser,
-		DataDir:        filepath.Join(townRoot, ".dolt-data"),
 		LogFile:        filepath.Join(daemonDir, "dolt.log"),
 		PidFile:        filepath.Join(daemonDir, "dolt.pid"),
 		MaxConnections: DefaultMaxConnections,
+		QueryTimeout:   DefaultQueryTimeout,
 	}
 
+	if port := persistedServerPort(townRoot); port > 0 {
+		config.Port = port
+	}
+	config.DataDir = beads.DoltDataDir(townRoot)
+
 	if h := os.Getenv("GT_DOLT_HOST"); h != "" {
 		config.Host = h
 	}
@@ -206,10 +232,73 @@ func DefaultConfig(townRoot string) *Config {
 	if pw := os.Getenv("GT_DOLT_PASSWORD"); pw != "" {
 		config.Password = pw
 	}
+	if t := os.Getenv("GT_DOLT_QUERY_TIMEOUT"); t != "" {
+		if timeout, err := time.ParseDuration(t); err == nil && timeout > 0 {
+			config.QueryTimeout = timeout
+		}
+	}
+	if a := os.Getenv("GT_DOLT_AUTO_PORT"); a != "" {
+		config.AutoPort, _ = strconv.ParseBool(a)
+	}
+	if t := os.Getenv("GT_DOLT_SLOW_QUERY"); t != "" {
+		if threshold, err := time.ParseDuration(t); err == nil && threshold > 0 {
+			config.SlowQueryThreshold = threshold
+		}
+	}
 
 	return config
 }
 
+// ErrQueryTimeout is wrapped by errors from SQL executions that exceeded
+// Config.QueryTimeout, so callers can tell a wedged server from a bad query.
+var ErrQueryTimeout = errors.New("dolt query timed out")
+
+// IsQueryTimeout reports whether err came from an execution that exceeded
+// Config.QueryTimeout.
+func IsQueryTimeout(err error) bool {
+	return errors.Is(err, ErrQueryTimeout)
+}
+
+// queryContext returns a context bounded by the config's query timeout.
+func (c *Config) queryContext() (context.Context, context.CancelFunc) {
+	timeout := c.QueryTimeout
+	if timeout <= 0 {
+		timeout = DefaultQueryTimeout
+	}
+	return context.WithTimeout(context.Background(), timeout)
+}
+
+// maxLoggedQueryLen caps how much of a slow statement is logged.
+const maxLoggedQueryLen = 200
+
+// Slow-query logging hooks, replaced in tests.
+var (
+	queryClock                = time.Now
+	slowQueryOutput io.Writer = os.Stderr
+)
+
+// logSlowQuery logs query with its duration when elapsed exceeds the
+// config's SlowQueryThreshold.
+func (c *Config) logSlowQuery(query string, elapsed time.Duration) {
+	if c.SlowQueryThreshold <= 0 || elapsed <= c.SlowQueryThreshold {
+		return
+	}
+	stmt := strings.Join(strings.Fields(query), " ")
+	if len(stmt) > maxLoggedQueryLen {
+		stmt = stmt[:maxLoggedQueryLen] + "..."
+	}
+	fmt.Fprintf(slowQueryOutput, "%s took %v (threshold %v): %s\n",
+		style.Warning.Render("Slow Dolt query"), elapsed.Round(time.Millisecond), c.SlowQueryThreshold, stmt)
+}
+
+// timeoutError wraps err with ErrQueryTimeout when ctx's deadline expired.
+func (c *Config) timeoutError(ctx context.Context, err error) error {
+	if err != nil && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return fmt.Errorf("%w after %v: %v", ErrQueryTimeout, c.QueryTimeout, err)
+	}
+	return err
+}
+
 // IsRemote returns true when the config points to a non-local Dolt server.
 // Empty host, "127.0.0.1", "localhost", "::1", and "[::1]" are all considered local.
 func (c *Config) IsRemote() bool {
@@ -341,8 +430,11 @@ func SaveState(townRoot string, state *State) error {
 // Checks both PID file AND port to detect externally-started servers.
 // For remote servers, skips PID/port scan and just does TCP reachability.
 func IsRunning(townRoot string) (bool, int, error) {
-	config := DefaultConfig(townRoot)
+	return isRunning(DefaultConfig(townRoot))
+}
 
+// isRunning is IsRunning for an explicit config.
+func isRunning(config *Config) (bool, int, error) {
 	// Remote server: no local PID/process to check — just TCP reachability.
 	if config.IsRemote() {
 		conn, err := net.DialTimeout("tcp", config.HostPort(), 2*time.Second)
@@ -390,7 +482,11 @@ func IsRunning(townRoot string) (bool, int, error) {
 // or the PID file is stale and the port is not actually listening.
 // Returns nil if reachable, error describing the problem otherwise.
 func CheckServerReachable(townRoot string) error {
-	config := DefaultConfig(townRoot)
+	return checkServerReachable(DefaultConfig(townRoot))
+}
+
+// checkServerReachable is CheckServerReachable for an explicit config.
+func checkServerReachable(config *Config) error {
 	addr := config.HostPort()
 	conn, err := net.DialTimeout("tcp", addr, 2*time.Second)
 	if err != nil {
@@ -691,7 +787,12 @@ func KillImposters(townRoot string) error {
 
 // Start starts the Dolt SQL server.
 func Start(townRoot string) error {
-	config := DefaultConfig(townRoot)
+	return startServer(DefaultConfig(townRoot))
+}
+
+// startServer starts a Dolt SQL server on config's port and data dir.
+func startServer(config *Config) error {
+	townRoot := config.TownRoot
 
 	// Ensure daemon directory exists
 	daemonDir := filepath.Dir(config.LogFile)
@@ -712,7 +813,7 @@ func Start(townRoot string) error {
 	defer func() { _ = fileLock.Unlock() }()
 
 	// Check if already running (checks both PID file AND port)
-	running, pid, err := IsRunning(townRoot)
+	running, pid, err := isRunning(config)
 	if err != nil {
 		return fmt.Errorf("checking server status: %w", err)
 	}
@@ -775,13 +876,19 @@ func Start(townRoot string) error {
 		}
 	}
 
+	if config.AutoPort {
+		if _, err := SelectPort(config); err != nil {
+			return err
+		}
+	}
+
 	// Ensure data directory exists
 	if err := os.MkdirAll(config.DataDir, 0755); err != nil {
 		return fmt.Errorf("creating data directory: %w", err)
 	}
 
 	// Clean up stale Dolt LOCK files in all database directories
-	databases, _ := ListDatabases(townRoot)
+	databases, _ := listDatabases(config)
 	for _, db := range databases {
 		dbDir := filepath.Join(config.DataDir, db)
 		if err := cleanupStaleDoltLock(dbDir); err != nil {
@@ -853,7 +960,7 @@ func Start(townRoot string) error {
 	for attempt := 0; attempt < 10; attempt++ {
 		time.Sleep(500 * time.Millisecond)
 
-		running, _, err = IsRunning(townRoot)
+		running, _, err = isRunning(config)
 		if err != nil {
 			return fmt.Errorf("verifying server started: %w", err)
 		}
@@ -861,7 +968,7 @@ func Start(townRoot string) error {
 			return fmt.Errorf("Dolt server failed to start (check logs with 'gt dolt logs')")
 		}
 
-		if err := CheckServerReachable(townRoot); err == nil {
+		if err := checkServerReachable(config); err == nil {
 			return nil // Server is up and accepting connections
 		} else {
 			lastErr = err
@@ -871,6 +978,44 @@ func Start(townRoot string) error {
 	return fmt.Errorf("Dolt server process started (PID %d) but not accepting connections after 5s: %w\nCheck logs with: gt dolt logs", cmd.Process.Pid, lastErr)
 }
 
+// EnsureRunning starts the Dolt server described by config if it is down and
+// waits up to timeout for it to accept connections. A restarted server keeps
+// config's port and data dir. It is a no-op when the server is already up,
+// so supervisors can call it on every tick. Remote servers can't be started
+// from here; for them it only waits for reachability.
+func EnsureRunning(config *Config, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+
+	running, _, err := isRunning(config)
+	if err != nil {
+		return fmt.Errorf("checking server status: %w", err)
+	}
+	if running && checkServerReachable(config) == nil {
+		return nil
+	}
+
+	if !running && !config.IsRemote() {
+		if startErr := startServer(config); startErr != nil {
+			// startServer gives up on reachability after a fixed wait; keep
+			// waiting as long as the process came up and the deadline allows.
+			if up, _, _ := isRunning(config); !up {
+				return fmt.Errorf("restarting Dolt server: %w", startErr)
+			}
+		}
+	}
+
+	for {
+		lastErr := checkServerReachable(config)
+		if lastErr == nil {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("Dolt server not ready after %v: %w", timeout, lastErr)
+		}
+		time.Sleep(250 * time.Millisecond)
+	}
+}
+
 // cleanupStaleDoltLock removes a stale Dolt LOCK file if no process holds it.
 // Dolt's embedded mode uses a file lock at .dolt/noms/LOCK that can become stale
 // after crashes. This checks if any process holds the lock before removing.
@@ -958,6 +1103,56 @@ func Stop(townRoot string) error {
 	return nil
 }
 
+// Shutdown stops the server after committing any pending working-set changes
+// in every managed database, so a stop never strands uncommitted WLCommons
+// writes. Databases with nothing to commit are skipped. The process is then
+// stopped with Stop (SIGTERM, falling back to SIGKILL after a grace period).
+//
+// Commit failures don't prevent the stop; they are returned afterwards so the
+// caller can report which databases may need attention.
+func Shutdown(config *Config) error {
+	if config.IsRemote() {
+		return fmt.Errorf("Dolt server is remote (%s) — start/stop managed externally", config.HostPort())
+	}
+
+	running, _, err := IsRunning(config.TownRoot)
+	if err != nil {
+		return err
+	}
+	if !running {
+		return fmt.Errorf("Dolt server is not running")
+	}
+
+	var commitErrs []error
+	databases, err := ListDatabases(config.TownRoot)
+	if err != nil {
+		commitErrs = append(commitErrs, fmt.Errorf("listing databases: %w", err))
+	}
+	for _, db := range databases {
+		if err := commitPendingChanges(config, db); err != nil {
+			commitErrs = append(commitErrs, fmt.Errorf("committing %s: %w", db, err))
+		}
+	}
+
+	if err := Stop(config.TownRoot); err != nil {
+		return err
+	}
+	return errors.Join(commitErrs...)
+}
+
+// commitPendingChanges commits the working set of database, treating
+// "nothing to commit" as success.
+func commitPendingChanges(config *Config, database string) error {
+	if err := validateDatabaseName(database); err != nil {
+		return err
+	}
+	script := fmt.Sprintf("USE `%s`;\nCALL DOLT_ADD('-A');\nCALL DOLT_COMMIT('-m', 'gt dolt stop: commit pending changes');\n", database)
+	if err := configExecScript(config, script); err != nil && !isNothingToCommit(err) {
+		return err
+	}
+	return nil
+}
+
 // GetConnectionString returns the MySQL connection string for the server.
 // Use GetConnectionStringForRig for a specific database.
 func GetConnectionString(townRoot string) string {
@@ -983,8 +1178,11 @@ func (c *Config) displayDSN() string {
 // For local servers, scans the data directory on disk.
 // For remote servers, queries SHOW DATABASES via SQL.
 func ListDatabases(townRoot string) ([]string, error) {
-	config := DefaultConfig(townRoot)
+	return listDatabases(DefaultConfig(townRoot))
+}
 
+// listDatabases is ListDatabases for an explicit config.
+func listDatabases(config *Config) ([]string, error) {
 	if config.IsRemote() {
 		return listDatabasesRemote(config)
 	}
@@ -1014,7 +1212,7 @@ func ListDatabases(townRoot string) ([]string, error) {
 
 // listDatabasesRemote queries SHOW DATABASES on a remote Dolt server.
 func listDatabasesRemote(config *Config) ([]string, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	ctx, cancel := config.queryContext()
 	defer cancel()
 
 	cmd := buildDoltSQLCmd(ctx, config, "-r", "json", "-q", "SHOW DATABASES")
@@ -1023,7 +1221,7 @@ func listDatabasesRemote(config *Config) ([]string, error) {
 	cmd.Stderr = &stderrBuf
 	output, err := cmd.Output()
 	if err != nil {
-		return nil, fmt.Errorf("querying remote SHOW DATABASES: %w (stderr: %s)", err, strings.TrimSpace(stderrBuf.String()))
+		return nil, config.timeoutError(ctx, fmt.Errorf("querying remote SHOW DATABASES: %w (stderr: %s)", err, strings.TrimSpace(stderrBuf.String())))
 	}
 
 	var result struct {
@@ -1095,7 +1293,7 @@ func verifyDatabasesWithRetry(townRoot string, maxAttempts int) (served, missing
 			continue
 		}
 
-		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		ctx, cancel := config.queryContext()
 		cmd := buildDoltSQLCmd(ctx, config,
 			"-r", "json",
 			"-q", "SHOW DATABASES",
@@ -1115,7 +1313,7 @@ func verifyDatabasesWithRetry(townRoot string, maxAttempts int) (served, missing
 			if stderrMsg != "" {
 				errDetail = errDetail + " (stderr: " + stderrMsg + ")"
 			}
-			lastErr = fmt.Errorf("querying SHOW DATABASES: %w (output: %s)", queryErr, errDetail)
+			lastErr = config.timeoutError(ctx, fmt.Errorf("querying SHOW DATABASES: %w (output: %s)", queryErr, errDetail))
 			if attempt < maxAttempts {
 				backoff := baseBackoff
 				for i := 1; i < attempt; i++ {
@@ -1930,7 +2128,7 @@ func GetActiveConnectionCount(townRoot string) (int, error) {
 
 	// Use dolt sql-client to query the server with a timeout to prevent
 	// hanging indefinitely if the Dolt server is unresponsive.
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	ctx, cancel := config.queryContext()
 	defer cancel()
 
 	// Always connect as a TCP client to the running server, even for local servers.
@@ -1953,7 +2151,7 @@ func GetActiveConnectionCount(townRoot string) (int, error) {
 	cmd.Env = append(os.Environ(), "DOLT_CLI_PASSWORD="+config.Password)
 	output, err := cmd.CombinedOutput()
 	if err != nil {
-		return 0, fmt.Errorf("querying connection count: %w (output: %s)", err, strings.TrimSpace(string(output)))
+		return 0, config.timeoutError(ctx, fmt.Errorf("querying connection count: %w (output: %s)", err, strings.TrimSpace(string(output))))
 	}
 
 	// Parse CSV output: "cnt\n5\n"
@@ -2092,7 +2290,7 @@ func CheckReadOnly(townRoot string) (bool, error) {
 	}
 
 	db := databases[0]
-	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	ctx, cancel := config.queryContext()
 	defer cancel()
 
 	// Attempt a write operation: create a temp table, write a row, drop it.
@@ -2109,7 +2307,7 @@ func CheckReadOnly(townRoot string) (bool, error) {
 		if IsReadOnlyError(msg) {
 			return true, nil
 		}
-		return false, fmt.Errorf("write probe failed: %w (%s)", err, msg)
+		return false, config.timeoutError(ctx, fmt.Errorf("write probe failed: %w (%s)", err, msg))
 	}
 
 	return false, nil
@@ -2300,13 +2498,15 @@ func moveDir(src, dest string) error {
 // a specific database. Used for server-level commands like CREATE DATABASE.
 func serverExecSQL(townRoot, query string) error {
 	config := DefaultConfig(townRoot)
-	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	ctx, cancel := config.queryContext()
 	defer cancel()
 
+	start := queryClock()
 	cmd := buildDoltSQLCmd(ctx, config, "-q", query)
 	output, err := cmd.CombinedOutput()
+	config.logSlowQuery(query, queryClock().Sub(start))
 	if err != nil {
-		return fmt.Errorf("%w (output: %s)", err, strings.TrimSpace(string(output)))
+		return config.timeoutError(ctx, fmt.Errorf("%w (output: %s)", err, strings.TrimSpace(string(output))))
 	}
 	return nil
 }
@@ -2355,7 +2555,7 @@ func waitForCatalog(townRoot, dbName string) error {
 // The USE prefix selects the database since --use-db is not available on all dolt versions.
 func doltSQL(townRoot, rigDB, query string) error {
 	config := DefaultConfig(townRoot)
-	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	ctx, cancel := config.queryContext()
 	defer cancel()
 
 	// Prepend USE <db> to select the target database.
@@ -2363,7 +2563,7 @@ func doltSQL(townRoot, rigDB, query string) error {
 	cmd := buildDoltSQLCmd(ctx, config, "-q", fullQuery)
 	output, err := cmd.CombinedOutput()
 	if err != nil {
-		return fmt.Errorf("%w (output: %s)", err, strings.TrimSpace(string(output)))
+		return config.timeoutError(ctx, fmt.Errorf("%w (output: %s)", err, strings.TrimSpace(string(output))))
 	}
 	return nil
 }
@@ -2455,13 +2655,15 @@ func doltSQLScript(townRoot, script string) error {
 	}
 	tmpFile.Close()
 
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := config.queryContext()
 	defer cancel()
 
+	start := queryClock()
 	cmd := buildDoltSQLCmd(ctx, config, "--file", tmpFile.Name())
 	output, err := cmd.CombinedOutput()
+	config.logSlowQuery(script, queryClock().Sub(start))
 	if err != nil {
-		return fmt.Errorf("%w (output: %s)", err, strings.TrimSpace(string(output)))
+		return config.timeoutError(ctx, fmt.Errorf("%w (output: %s)", err, strings.TrimSpace(string(output))))
 	}
 	return nil
 }
@@ -2471,13 +2673,19 @@ func doltSQLScript(townRoot, script string) error {
 // before the retry. Uses the same retry classification as doltSQLWithRetry but with
 // fewer retries and shorter backoff since multi-statement scripts are more expensive.
 func doltSQLScriptWithRetry(townRoot, script string) error {
+	return retryDoltScript(func() error { return doltSQLScript(townRoot, script) })
+}
+
+// retryDoltScript runs a script executor with the retry policy of
+// doltSQLScriptWithRetry, regardless of how the script reaches the server.
+func retryDoltScript(run func() error) error {
 	const maxRetries = 3
 	const baseBackoff = 500 * time.Millisecond
 	const maxBackoff = 8 * time.Second
 
 	var lastErr error
 	for attempt := 1; attempt <= maxRetries; attempt++ {
-		if err := doltSQLScript(townRoot, script); err != nil {
+		if err := run(); err != nil {
 			lastErr = err
 			if !isDoltRetryableError(err) {
 				return err
@@ -3,6 +3,7 @@ package doltserver
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net"
@@ -13,6 +14,9 @@ import (
 	"sync"
 	"testing"
 	"time"
+
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/doltserver/doltservertest"
 )
 
 // =============================================================================
@@ -3211,6 +3215,14 @@ func TestHostPort(t *testing.T) {
 	}
 }
 
+func TestDefaultConfig_DataDirMatchesTestFixture(t *testing.T) {
+	townRoot := t.TempDir()
+	want := filepath.Join(townRoot, doltservertest.DataDirName)
+	if got := DefaultConfig(townRoot).DataDir; got != want {
+		t.Errorf("DataDir = %q, want %q (doltservertest.DataDirName is out of date)", got, want)
+	}
+}
+
 func TestDefaultConfig_EnvVarOverrides(t *testing.T) {
 	townRoot := t.TempDir()
 
@@ -3268,6 +3280,188 @@ func TestDefaultConfig_InvalidPortIgnored(t *testing.T) {
 	}
 }
 
+func TestDefaultConfig_QueryTimeout(t *testing.T) {
+	tests := []struct {
+		env  string
+		want time.Duration
+	}{
+		{"", DefaultQueryTimeout},
+		{"45s", 45 * time.Second},
+		{"2m", 2 * time.Minute},
+		{"not-a-duration", DefaultQueryTimeout},
+		{"-5s", DefaultQueryTimeout},
+	}
+	for _, tt := range tests {
+		t.Setenv("GT_DOLT_QUERY_TIMEOUT", tt.env)
+		config := DefaultConfig(t.TempDir())
+		if config.QueryTimeout != tt.want {
+			t.Errorf("GT_DOLT_QUERY_TIMEOUT=%q: QueryTimeout = %v, want %v", tt.env, config.QueryTimeout, tt.want)
+		}
+	}
+}
+
+func TestTimeoutError(t *testing.T) {
+	config := &Config{QueryTimeout: time.Millisecond}
+	base := errors.New("signal: killed")
+
+	ctx, cancel := config.queryContext()
+	defer cancel()
+	if err := config.timeoutError(ctx, base); IsQueryTimeout(err) {
+		t.Errorf("error before deadline reported as timeout: %v", err)
+	}
+
+	<-ctx.Done()
+	err := config.timeoutError(ctx, base)
+	if !IsQueryTimeout(err) {
+		t.Errorf("IsQueryTimeout(%v) = false after deadline", err)
+	}
+	if !strings.Contains(err.Error(), "signal: killed") {
+		t.Errorf("timeout error %q should keep the underlying error", err)
+	}
+	if config.timeoutError(ctx, nil) != nil {
+		t.Error("timeoutError(nil) should stay nil")
+	}
+}
+
+// TestQueryTimeout_ClassifiedTransient pins the ErrQueryTimeout text that
+// beads matches on, since beads can't import this package.
+func TestListDatabasesRemote_QueryTimeout(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("shell stubs not supported on windows")
+	}
+	// A wedged server: dolt sql never answers.
+	binDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(binDir, "dolt"), []byte("#!/bin/sh\nexec sleep 5\n"), 0755); err != nil {
+		t.Fatalf("write dolt stub: %v", err)
+	}
+	t.Setenv("PATH", binDir+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	config := &Config{Host: "dolt.example.com", Port: 3307, User: "root", QueryTimeout: 100 * time.Millisecond}
+	start := time.Now()
+	_, err := listDatabasesRemote(config)
+	if elapsed := time.Since(start); elapsed > 3*time.Second {
+		t.Errorf("listDatabasesRemote took %v, want the query timeout to cut it short", elapsed)
+	}
+	if !IsQueryTimeout(err) {
+		t.Errorf("listDatabasesRemote() = %v, want ErrQueryTimeout", err)
+	}
+}
+
+// TestEnsureRunning_StartsWithCallerConfig checks that a restart uses the
+// caller's port and data dir, not the town defaults.
+func TestEnsureRunning_StartsWithCallerConfig(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("shell stubs not supported on windows")
+	}
+	// A dolt that records its arguments and dies before listening.
+	binDir := t.TempDir()
+	argsFile := filepath.Join(binDir, "args")
+	stub := fmt.Sprintf("#!/bin/sh\necho \"$@\" > %q\nexit 1\n", argsFile)
+	if err := os.WriteFile(filepath.Join(binDir, "dolt"), []byte(stub), 0755); err != nil {
+		t.Fatalf("write dolt stub: %v", err)
+	}
+	t.Setenv("PATH", binDir+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	// A port nothing listens on.
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	port := ln.Addr().(*net.TCPAddr).Port
+	_ = ln.Close()
+
+	config := DefaultConfig(t.TempDir())
+	config.Port = port
+	config.DataDir = filepath.Join(t.TempDir(), "custom-data")
+
+	if err := EnsureRunning(config, time.Second); err == nil {
+		t.Fatal("EnsureRunning() = nil, want an error for a server that never came up")
+	}
+	data, err := os.ReadFile(argsFile)
+	if err != nil {
+		t.Fatalf("dolt was not started: %v", err)
+	}
+	got := strings.TrimSpace(string(data))
+	want := fmt.Sprintf("sql-server --port %d --data-dir %s", port, config.DataDir)
+	if !strings.HasPrefix(got, want) {
+		t.Errorf("dolt args = %q, want prefix %q", got, want)
+	}
+}
+
+func TestQueryTimeout_ClassifiedTransient(t *testing.T) {
+	err := fmt.Errorf("querying wanted item: %w", ErrQueryTimeout)
+	if got := beads.ClassifyError(err); got != beads.BeadErrorTransient {
+		t.Errorf("beads.ClassifyError(%v) = %v, want Transient", err, got)
+	}
+}
+
+// TestServerExecSQL_SlowQueryLog runs serverExecSQL against a stub dolt with
+// a fake clock and checks that only queries over the threshold are logged.
+func TestServerExecSQL_SlowQueryLog(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("shell stubs not supported on windows")
+	}
+
+	binDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(binDir, "dolt"), []byte("#!/bin/sh\nexit 0\n"), 0755); err != nil {
+		t.Fatalf("writing dolt stub: %v", err)
+	}
+	t.Setenv("PATH", binDir+string(os.PathListSeparator)+os.Getenv("PATH"))
+	t.Setenv("GT_DOLT_SLOW_QUERY", "1s")
+
+	townRoot := t.TempDir()
+	if err := os.MkdirAll(DefaultConfig(townRoot).DataDir, 0755); err != nil {
+		t.Fatalf("creating data dir: %v", err)
+	}
+
+	var logged bytes.Buffer
+	origClock, origOutput := queryClock, slowQueryOutput
+	t.Cleanup(func() { queryClock, slowQueryOutput = origClock, origOutput })
+	slowQueryOutput = &logged
+
+	// Each query takes exactly `step` on the fake clock.
+	var step time.Duration
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	queryClock = func() time.Time {
+		now = now.Add(step)
+		return now
+	}
+
+	step = 500 * time.Millisecond
+	if err := serverExecSQL(townRoot, "SELECT 'fast'"); err != nil {
+		t.Fatalf("serverExecSQL: %v", err)
+	}
+	if logged.Len() != 0 {
+		t.Errorf("query under threshold was logged: %q", logged.String())
+	}
+
+	step = 3 * time.Second
+	if err := serverExecSQL(townRoot, "SELECT 'slow'"); err != nil {
+		t.Fatalf("serverExecSQL: %v", err)
+	}
+	if !strings.Contains(logged.String(), "SELECT 'slow'") || !strings.Contains(logged.String(), "3s") {
+		t.Errorf("slow query log = %q, want statement and duration", logged.String())
+	}
+}
+
+func TestLogSlowQuery_TruncatesAndDisables(t *testing.T) {
+	var logged bytes.Buffer
+	origOutput := slowQueryOutput
+	t.Cleanup(func() { slowQueryOutput = origOutput })
+	slowQueryOutput = &logged
+
+	(&Config{}).logSlowQuery("SELECT 1", time.Hour)
+	if logged.Len() != 0 {
+		t.Errorf("zero threshold should disable logging, got %q", logged.String())
+	}
+
+	long := "SELECT '" + strings.Repeat("x", 500) + "'"
+	(&Config{SlowQueryThreshold: time.Second}).logSlowQuery(long, 2*time.Second)
+	if strings.Contains(logged.String(), long) || !strings.Contains(logged.String(), "...") {
+		t.Errorf("long statement should be truncated, got %q", logged.String())
+	}
+}
+
 func TestBuildDoltSQLCmd_Local(t *testing.T) {
 	config := &Config{
 		Host:    "",
@@ -0,0 +1,106 @@
+// Package doltservertest provides an ephemeral Dolt SQL server for
+// integration tests in any package.
+package doltservertest
+
+import (
+	"database/sql"
+	"fmt"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	_ "github.com/go-sql-driver/mysql"
+)
+
+// DataDirName is the data directory under the town root, matching the
+// layout doltserver.DefaultConfig expects.
+const DataDirName = ".dolt-data"
+
+// IsolatedServer holds the connection details of a test Dolt server.
+type IsolatedServer struct {
+	TownRoot string
+	DataDir  string
+	Port     int
+}
+
+// StartIsolated starts a Dolt SQL server on a dynamic port with an isolated
+// town root and data directory. It sets GT_DOLT_PORT so that
+// doltserver.DefaultConfig and everything built on it connect to this server
+// instead of the production server on port 3307. The test is skipped when
+// dolt is not installed, and the server is killed when the test completes.
+func StartIsolated(t testing.TB) *IsolatedServer {
+	t.Helper()
+
+	if _, err := exec.LookPath("dolt"); err != nil {
+		t.Skip("dolt not found in PATH — skipping integration test")
+	}
+
+	townRoot := t.TempDir()
+	dataDir := filepath.Join(townRoot, DataDirName)
+	if err := os.MkdirAll(dataDir, 0755); err != nil {
+		t.Fatalf("creating data dir: %v", err)
+	}
+
+	// Find a free port.
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("finding free port: %v", err)
+	}
+	port := ln.Addr().(*net.TCPAddr).Port
+	ln.Close()
+
+	// Override GT_DOLT_PORT so all DefaultConfig calls use our port.
+	// This is critical: without it, IsRunning/serverExecSQL would fall back
+	// to port 3307 and hit the production server.
+	t.Setenv("GT_DOLT_PORT", strconv.Itoa(port))
+
+	// Configure dolt identity in an isolated root.
+	doltEnv := append(os.Environ(), "DOLT_ROOT_PATH="+townRoot)
+	for _, args := range [][]string{
+		{"dolt", "config", "--global", "--add", "user.name", "integration-test"},
+		{"dolt", "config", "--global", "--add", "user.email", "test@integration.test"},
+	} {
+		cmd := exec.Command(args[0], args[1:]...)
+		cmd.Env = doltEnv
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("%s failed: %v\n%s", args[1], err, out)
+		}
+	}
+
+	// Start dolt sql-server on the dynamic port.
+	serverCmd := exec.Command("dolt", "sql-server",
+		"--port", fmt.Sprintf("%d", port),
+		"--data-dir", dataDir,
+	)
+	serverCmd.Env = doltEnv
+	serverCmd.Stdout = nil
+	serverCmd.Stderr = nil
+	if err := serverCmd.Start(); err != nil {
+		t.Fatalf("starting dolt sql-server: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = serverCmd.Process.Kill()
+		_ = serverCmd.Wait()
+	})
+
+	// Wait for server readiness via MySQL ping.
+	dsn := fmt.Sprintf("root@tcp(127.0.0.1:%d)/?timeout=1s", port)
+	deadline := time.Now().Add(15 * time.Second)
+	for time.Now().Before(deadline) {
+		db, err := sql.Open("mysql", dsn)
+		if err == nil {
+			if err := db.Ping(); err == nil {
+				db.Close()
+				return &IsolatedServer{TownRoot: townRoot, DataDir: dataDir, Port: port}
+			}
+			db.Close()
+		}
+		time.Sleep(200 * time.Millisecond)
+	}
+	t.Fatalf("dolt sql-server did not become ready on port %d within 15s", port)
+	return nil // unreachable
+}
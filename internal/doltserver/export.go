@@ -0,0 +1,189 @@
+package doltserver
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// exportTimeout bounds a single dolt dump or import run. Dumps of large
+// databases are slower than normal queries, so this is more generous than
+// the per-query timeouts used elsewhere.
+const exportTimeout = 5 * time.Minute
+
+// validateDatabaseName rejects names that cannot be safely interpolated into
+// a backtick-quoted SQL identifier or used as a data directory name.
+func validateDatabaseName(name string) error {
+	if name == "" {
+		return fmt.Errorf("database name cannot be empty")
+	}
+	for _, r := range name {
+		if !((r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') || r == '_' || r == '-') {
+			return fmt.Errorf("invalid database name %q: must contain only alphanumeric, underscore, or dash", name)
+		}
+	}
+	return nil
+}
+
+// Export dumps a database served by the configured Dolt server to a portable
+// SQL file at path. The dump contains schema and data and can be loaded on
+// another machine with Import.
+//
+// Export runs `dolt dump` from the database directory so that dolt routes the
+// read through the server configured for this town. Remote servers are not
+// supported because the dump needs local access to the database directory.
+func Export(config *Config, database, path string) error {
+	if err := validateDatabaseName(database); err != nil {
+		return err
+	}
+	if config.IsRemote() {
+		return fmt.Errorf("Dolt server is remote (%s) — export requires local server access", config.HostPort())
+	}
+
+	dbDir := filepath.Join(config.DataDir, database)
+	if _, err := os.Stat(filepath.Join(dbDir, ".dolt")); err != nil {
+		return fmt.Errorf("database %q not found in %s", database, config.DataDir)
+	}
+
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return fmt.Errorf("resolving export path: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(absPath), 0755); err != nil {
+		return fmt.Errorf("creating export directory: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), exportTimeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, "dolt", "dump", "-r", "sql", "-fn", absPath, "-f")
+	cmd.Dir = dbDir
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("dolt dump %s: %w (output: %s)", database, err, strings.TrimSpace(string(output)))
+	}
+
+	if info, err := os.Stat(absPath); err != nil {
+		return fmt.Errorf("dolt dump did not produce %s: %w", absPath, err)
+	} else if info.Size() == 0 {
+		return fmt.Errorf("dolt dump produced an empty file at %s", absPath)
+	}
+	return nil
+}
+
+// Import loads a SQL dump produced by Export into the named database on the
+// configured Dolt server, creating the database if needed, and commits the
+// result. Importing into a database that already has tables is refused unless
+// force is set, since the dump's CREATE TABLE statements would collide with or
+// silently shadow existing data.
+func Import(config *Config, database, path string, force bool) error {
+	if err := validateDatabaseName(database); err != nil {
+		return err
+	}
+
+	dump, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("reading dump: %w", err)
+	}
+	if len(strings.TrimSpace(string(dump))) == 0 {
+		return fmt.Errorf("dump file %s is empty", path)
+	}
+
+	if err := configExecSQL(config, fmt.Sprintf("CREATE DATABASE IF NOT EXISTS `%s`", database)); err != nil {
+		return fmt.Errorf("creating database %s: %w", database, err)
+	}
+
+	tables, err := configListTables(config, database)
+	if err != nil {
+		return fmt.Errorf("inspecting database %s: %w", database, err)
+	}
+	if len(tables) > 0 && !force {
+		return fmt.Errorf("database %q is not empty (%d tables) — use --force to import anyway", database, len(tables))
+	}
+
+	var script strings.Builder
+	fmt.Fprintf(&script, "USE `%s`;\n", database)
+	if force {
+		// Drop existing tables so the dump's CREATE TABLE statements apply cleanly.
+		for _, table := range tables {
+			fmt.Fprintf(&script, "DROP TABLE IF EXISTS `%s`;\n", table)
+		}
+	}
+	script.Write(dump)
+	script.WriteString("\nCALL DOLT_ADD('-A');\n")
+	script.WriteString("CALL DOLT_COMMIT('--allow-empty', '-m', 'gt dolt import');\n")
+
+	if err := configExecScript(config, script.String()); err != nil {
+		return fmt.Errorf("importing into %s: %w", database, err)
+	}
+	return nil
+}
+
+// configExecSQL executes a server-level SQL statement using an explicit Config
+// rather than the town's DefaultConfig.
+func configExecSQL(config *Config, query string) error {
+	ctx, cancel := config.queryContext()
+	defer cancel()
+
+	cmd := buildDoltSQLCmd(ctx, config, "-q", query)
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return config.timeoutError(ctx, fmt.Errorf("%w (output: %s)", err, strings.TrimSpace(string(output))))
+	}
+	return nil
+}
+
+// configListTables returns the user tables in database.
+func configListTables(config *Config, database string) ([]string, error) {
+	ctx, cancel := config.queryContext()
+	defer cancel()
+
+	query := fmt.Sprintf("SHOW TABLES FROM `%s`", database)
+	cmd := buildDoltSQLCmd(ctx, config, "-r", "csv", "-q", query)
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, config.timeoutError(ctx, fmt.Errorf("listing tables: %w", err))
+	}
+
+	var tables []string
+	lines := strings.Split(strings.TrimSpace(string(output)), "\n")
+	for i, line := range lines {
+		if i == 0 {
+			continue // CSV header
+		}
+		if name := strings.TrimSpace(line); name != "" {
+			tables = append(tables, name)
+		}
+	}
+	return tables, nil
+}
+
+// configExecScript executes a multi-statement SQL script via a temp file using
+// an explicit Config.
+func configExecScript(config *Config, script string) error {
+	tmpFile, err := os.CreateTemp("", "dolt-import-*.sql")
+	if err != nil {
+		return fmt.Errorf("creating temp SQL file: %w", err)
+	}
+	defer os.Remove(tmpFile.Name())
+
+	if _, err := tmpFile.WriteString(script); err != nil {
+		tmpFile.Close()
+		return fmt.Errorf("writing SQL script: %w", err)
+	}
+	tmpFile.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), exportTimeout)
+	defer cancel()
+
+	cmd := buildDoltSQLCmd(ctx, config, "--file", tmpFile.Name())
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("%w (output: %s)", err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+//go:build integration
+
+package doltserver
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/gastown/internal/doltserver/doltservertest"
+)
+
+// TestExportImport_RoundTrip exports a seeded database from one isolated
+// server and imports it into a fresh one, verifying the rows survive.
+func TestExportImport_RoundTrip(t *testing.T) {
+	src := doltservertest.StartIsolated(t)
+	srcConfig := DefaultConfig(src.TownRoot)
+
+	seed := `CREATE DATABASE IF NOT EXISTS exportdb;
+USE exportdb;
+CREATE TABLE items (id INT PRIMARY KEY, name VARCHAR(64));
+INSERT INTO items VALUES (1, 'alpha'), (2, 'beta'), (3, 'gamma');
+CALL DOLT_ADD('-A');
+CALL DOLT_COMMIT('-m', 'seed');
+`
+	if err := configExecScript(srcConfig, seed); err != nil {
+		t.Fatalf("seeding source: %v", err)
+	}
+
+	dumpPath := filepath.Join(t.TempDir(), "exportdb.sql")
+	if err := Export(srcConfig, "exportdb", dumpPath); err != nil {
+		t.Fatalf("Export() error: %v", err)
+	}
+	if info, err := os.Stat(dumpPath); err != nil || info.Size() == 0 {
+		t.Fatalf("dump file missing or empty: %v", err)
+	}
+
+	dst := doltservertest.StartIsolated(t)
+	dstConfig := DefaultConfig(dst.TownRoot)
+
+	if err := Import(dstConfig, "exportdb", dumpPath, false); err != nil {
+		t.Fatalf("Import() error: %v", err)
+	}
+
+	for _, cfg := range []*Config{srcConfig, dstConfig} {
+		if got := countRows(t, cfg, "exportdb", "items"); got != 3 {
+			t.Errorf("row count in %s = %d, want 3", cfg.DataDir, got)
+		}
+	}
+
+	// A second import into the now non-empty database requires force.
+	if err := Import(dstConfig, "exportdb", dumpPath, false); err == nil {
+		t.Error("Import() into non-empty database without force: want error, got nil")
+	}
+	if err := Import(dstConfig, "exportdb", dumpPath, true); err != nil {
+		t.Fatalf("Import() with force error: %v", err)
+	}
+	if got := countRows(t, dstConfig, "exportdb", "items"); got != 3 {
+		t.Errorf("row count after forced import = %d, want 3", got)
+	}
+}
+
+func countRows(t *testing.T, config *Config, database, table string) int {
+	t.Helper()
+	query := fmt.Sprintf("SELECT COUNT(*) AS n FROM `%s`.`%s`", database, table)
+	cmd := buildDoltSQLCmd(t.Context(), config, "-r", "csv", "-q", query)
+	out, err := cmd.Output()
+	if err != nil {
+		t.Fatalf("counting rows: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(out)), "\n")
+	var n int
+	if _, err := fmt.Sscanf(lines[len(lines)-1], "%d", &n); err != nil {
+		t.Fatalf("parsing row count %q: %v", out, err)
+	}
+	return n
+}
@@ -0,0 +1,163 @@
+//go:build integration
+
+package doltserver
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/doltserver/doltservertest"
+)
+
+// TestEnsureRunning_RestartsKilledServer kills the isolated server and
+// verifies EnsureRunning brings it back on the same port and data dir.
+func TestEnsureRunning_RestartsKilledServer(t *testing.T) {
+	srv := doltservertest.StartIsolated(t)
+	// Restarted servers need the isolated dolt identity too.
+	t.Setenv("DOLT_ROOT_PATH", srv.TownRoot)
+	t.Cleanup(func() { _ = Stop(srv.TownRoot) })
+	config := DefaultConfig(srv.TownRoot)
+
+	// Already up: no-op.
+	if err := EnsureRunning(config, 5*time.Second); err != nil {
+		t.Fatalf("EnsureRunning() on live server: %v", err)
+	}
+
+	_, pid, err := IsRunning(srv.TownRoot)
+	if err != nil || pid == 0 {
+		t.Fatalf("IsRunning() pid=%d err=%v", pid, err)
+	}
+	proc, err := os.FindProcess(pid)
+	if err != nil {
+		t.Fatalf("finding server process: %v", err)
+	}
+	if err := proc.Kill(); err != nil {
+		t.Fatalf("killing server: %v", err)
+	}
+	waitFor(t, 5*time.Second, func() bool {
+		running, _, _ := IsRunning(srv.TownRoot)
+		return !running
+	})
+
+	if err := EnsureRunning(config, 20*time.Second); err != nil {
+		t.Fatalf("EnsureRunning() after kill: %v", err)
+	}
+	if err := CheckServerReachable(srv.TownRoot); err != nil {
+		t.Errorf("server not reachable after EnsureRunning: %v", err)
+	}
+}
+
+// TestShutdown_CommitsPendingChanges leaves an uncommitted write in the
+// working set, shuts the server down, and verifies the write was committed
+// before the process exited.
+func TestShutdown_CommitsPendingChanges(t *testing.T) {
+	srv := doltservertest.StartIsolated(t)
+	config := DefaultConfig(srv.TownRoot)
+
+	seed := `CREATE DATABASE IF NOT EXISTS shutdowndb;
+USE shutdowndb;
+CREATE TABLE items (id INT PRIMARY KEY, name VARCHAR(64));
+INSERT INTO items VALUES (1, 'pending');
+`
+	if err := configExecScript(config, seed); err != nil {
+		t.Fatalf("seeding: %v", err)
+	}
+
+	if err := Shutdown(config); err != nil {
+		t.Fatalf("Shutdown() error: %v", err)
+	}
+	if running, _, _ := IsRunning(srv.TownRoot); running {
+		t.Fatal("server still running after Shutdown()")
+	}
+
+	// With the server down, dolt reads the database directory directly.
+	dbDir := filepath.Join(config.DataDir, "shutdowndb")
+	cmd := exec.Command("dolt", "status")
+	cmd.Dir = dbDir
+	status, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("dolt status: %v (%s)", err, status)
+	}
+	if !strings.Contains(string(status), "nothing to commit") {
+		t.Errorf("working set not committed by Shutdown():\n%s", status)
+	}
+
+	cmd = exec.Command("dolt", "log", "-n", "1", "--oneline")
+	cmd.Dir = dbDir
+	log, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("dolt log: %v (%s)", err, log)
+	}
+	if !strings.Contains(string(log), "commit pending changes") {
+		t.Errorf("latest commit = %q, want the shutdown commit", strings.TrimSpace(string(log)))
+	}
+}
+
+func TestShutdown_NotRunning(t *testing.T) {
+	t.Setenv("GT_DOLT_PORT", "1") // nothing listens here
+	if err := Shutdown(DefaultConfig(t.TempDir())); err == nil {
+		t.Fatal("Shutdown() with no server: want error, got nil")
+	}
+}
+
+// TestStatus_IsolatedServer verifies Status reports version, uptime, and
+// connections for a live server.
+func TestStatus_IsolatedServer(t *testing.T) {
+	srv := doltservertest.StartIsolated(t)
+
+	status, err := Status(DefaultConfig(srv.TownRoot))
+	if err != nil {
+		t.Fatalf("Status() error: %v", err)
+	}
+	if !status.Running {
+		t.Fatal("Status().Running = false for live server")
+	}
+	if status.Version == "" {
+		t.Error("Status().Version is empty")
+	}
+	if status.Uptime <= 0 {
+		t.Errorf("Status().Uptime = %v, want > 0", status.Uptime)
+	}
+	if status.Connections < 1 {
+		t.Errorf("Status().Connections = %d, want at least the status query's own", status.Connections)
+	}
+}
+
+// TestServerExecSQL_QueryTimeout runs a query slower than GT_DOLT_QUERY_TIMEOUT
+// and verifies it is cut off with an error IsQueryTimeout recognizes.
+func TestServerExecSQL_QueryTimeout(t *testing.T) {
+	srv := doltservertest.StartIsolated(t)
+	t.Setenv("GT_DOLT_QUERY_TIMEOUT", "1s")
+
+	start := time.Now()
+	err := serverExecSQL(srv.TownRoot, "SELECT SLEEP(10)")
+	if err == nil {
+		t.Fatal("serverExecSQL(SLEEP(10)) succeeded, want timeout")
+	}
+	if !IsQueryTimeout(err) {
+		t.Errorf("IsQueryTimeout(%v) = false", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("slow query took %v, want it cut off near the 1s timeout", elapsed)
+	}
+
+	// The server must still answer once the slow query is abandoned.
+	if err := serverExecSQL(srv.TownRoot, "SELECT 1"); err != nil {
+		t.Errorf("serverExecSQL(SELECT 1) after timeout: %v", err)
+	}
+}
+
+func waitFor(t *testing.T, timeout time.Duration, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(timeout)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("condition not met within %v", timeout)
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+}
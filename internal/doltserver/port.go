@@ -0,0 +1,134 @@
+package doltserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/steveyegge/gastown/internal/util"
+)
+
+// maxPortProbe bounds how far past the configured port SelectPort searches
+// for a free one.
+const maxPortProbe = 100
+
+// SelectPort makes sure config.Port can be used to start this town's server.
+// The port is kept if it is free or already held by this town's own server.
+// Otherwise the next free port is chosen, config.Port is updated, and the
+// choice is persisted as dolt_server_port in the beads metadata so bd and
+// later DefaultConfig calls find the server there.
+//
+// Remote servers are managed externally, so their port is never changed.
+func SelectPort(config *Config) (int, error) {
+	if config.IsRemote() || portAvailable(config.Port) || ownsPort(config, config.Port) {
+		return config.Port, nil
+	}
+
+	for port := config.Port + 1; port <= config.Port+maxPortProbe && port <= 65535; port++ {
+		if !portAvailable(port) {
+			continue
+		}
+		if err := persistServerPort(config.TownRoot, port); err != nil {
+			return 0, fmt.Errorf("recording Dolt port %d: %w", port, err)
+		}
+		fmt.Fprintf(os.Stderr, "Port %d is in use by another process — using port %d for the Dolt server\n", config.Port, port)
+		config.Port = port
+		return port, nil
+	}
+	return 0, fmt.Errorf("port %d is in use and no free port found in %d-%d", config.Port, config.Port+1, config.Port+maxPortProbe)
+}
+
+// portAvailable reports whether a local listener can bind port.
+func portAvailable(port int) bool {
+	ln, err := net.Listen("tcp", net.JoinHostPort("127.0.0.1", strconv.Itoa(port)))
+	if err != nil {
+		return false
+	}
+	_ = ln.Close()
+	return true
+}
+
+// ownsPort reports whether port is held by a dolt sql-server serving this
+// town's data directory.
+func ownsPort(config *Config, port int) bool {
+	pid := findDoltServerOnPort(port)
+	if pid == 0 {
+		return false
+	}
+	processDataDir := getProcessDataDir(pid)
+	if processDataDir == "" {
+		return false
+	}
+	expectedDir, _ := filepath.Abs(config.DataDir)
+	actualDir, _ := filepath.Abs(processDataDir)
+	return expectedDir == actualDir
+}
+
+// persistedServerPort returns the dolt_server_port recorded in the town's
+// beads metadata, or 0 if none is recorded.
+func persistedServerPort(townRoot string) int {
+	if townRoot == "" {
+		return 0
+	}
+	data, err := os.ReadFile(filepath.Join(townRoot, ".beads", "metadata.json"))
+	if err != nil {
+		return 0
+	}
+	var metadata struct {
+		DoltServerPort int `json:"dolt_server_port"`
+	}
+	if err := json.Unmarshal(data, &metadata); err != nil {
+		return 0
+	}
+	return metadata.DoltServerPort
+}
+
+// persistServerPort records port as dolt_server_port in the town's beads
+// metadata and in the metadata of every rig database on disk.
+func persistServerPort(townRoot string, port int) error {
+	rigs := []string{"hq"}
+	databases, _ := ListDatabases(townRoot)
+	for _, db := range databases {
+		if db != "hq" {
+			rigs = append(rigs, db)
+		}
+	}
+
+	for _, rigName := range rigs {
+		if err := EnsureMetadata(townRoot, rigName); err != nil {
+			return err
+		}
+		if err := SetMetadataPort(townRoot, rigName, port); err != nil {
+			return fmt.Errorf("%s: %w", rigName, err)
+		}
+	}
+	return nil
+}
+
+// SetMetadataPort sets dolt_server_port in a rig's metadata.json, preserving
+// all other fields.
+func SetMetadataPort(townRoot, rigName string, port int) error {
+	metadataPath := filepath.Join(FindRigBeadsDir(townRoot, rigName), "metadata.json")
+
+	mu := getMetadataMu(metadataPath)
+	mu.Lock()
+	defer mu.Unlock()
+
+	existing := make(map[string]interface{})
+	if data, err := os.ReadFile(metadataPath); err == nil {
+		_ = json.Unmarshal(data, &existing) // best effort
+	}
+	existing["dolt_server_port"] = port
+
+	data, err := json.MarshalIndent(existing, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshaling metadata: %w", err)
+	}
+	if err := util.AtomicWriteFile(metadataPath, append(data, '\n'), 0600); err != nil {
+		return fmt.Errorf("writing metadata.json: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,96 @@
+package doltserver
+
+import (
+	"net"
+	"testing"
+)
+
+func TestSelectPort_FallsForwardWhenOccupied(t *testing.T) {
+	t.Setenv("GT_DOLT_PORT", "")
+	townRoot := t.TempDir()
+
+	// Occupy the configured port with something that isn't our server.
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("binding port: %v", err)
+	}
+	defer ln.Close()
+	occupied := ln.Addr().(*net.TCPAddr).Port
+
+	config := DefaultConfig(townRoot)
+	config.Port = occupied
+
+	got, err := SelectPort(config)
+	if err != nil {
+		t.Fatalf("SelectPort() error: %v", err)
+	}
+	if got == occupied {
+		t.Fatalf("SelectPort() kept occupied port %d", occupied)
+	}
+	if config.Port != got {
+		t.Errorf("config.Port = %d, want %d", config.Port, got)
+	}
+	if !portAvailable(got) {
+		t.Errorf("SelectPort() chose port %d, which is not free", got)
+	}
+
+	// The choice is persisted so later configs (and bd) agree on it.
+	if port := DefaultConfig(townRoot).Port; port != got {
+		t.Errorf("DefaultConfig().Port after SelectPort = %d, want %d", port, got)
+	}
+}
+
+func TestSelectPort_KeepsFreePort(t *testing.T) {
+	t.Setenv("GT_DOLT_PORT", "")
+	townRoot := t.TempDir()
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("finding free port: %v", err)
+	}
+	free := ln.Addr().(*net.TCPAddr).Port
+	ln.Close()
+
+	config := DefaultConfig(townRoot)
+	config.Port = free
+	got, err := SelectPort(config)
+	if err != nil {
+		t.Fatalf("SelectPort() error: %v", err)
+	}
+	if got != free {
+		t.Errorf("SelectPort() = %d, want free port %d kept", got, free)
+	}
+	if port := persistedServerPort(townRoot); port != 0 {
+		t.Errorf("persisted port = %d, want nothing recorded for an unchanged port", port)
+	}
+}
+
+func TestSelectPort_RemoteUnchanged(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("binding port: %v", err)
+	}
+	defer ln.Close()
+	occupied := ln.Addr().(*net.TCPAddr).Port
+
+	config := &Config{TownRoot: t.TempDir(), Host: "10.0.0.5", Port: occupied}
+	if got, err := SelectPort(config); err != nil || got != occupied {
+		t.Errorf("SelectPort() on remote = %d, %v; want %d unchanged", got, err, occupied)
+	}
+}
+
+func TestDefaultConfig_EnvPortOverridesPersisted(t *testing.T) {
+	townRoot := t.TempDir()
+	if err := persistServerPort(townRoot, 13399); err != nil {
+		t.Fatalf("persistServerPort: %v", err)
+	}
+
+	t.Setenv("GT_DOLT_PORT", "")
+	if port := DefaultConfig(townRoot).Port; port != 13399 {
+		t.Errorf("DefaultConfig().Port = %d, want persisted 13399", port)
+	}
+	t.Setenv("GT_DOLT_PORT", "13500")
+	if port := DefaultConfig(townRoot).Port; port != 13500 {
+		t.Errorf("DefaultConfig().Port = %d, want GT_DOLT_PORT 13500", port)
+	}
+}
@@ -0,0 +1,116 @@
+package doltserver
+
+import (
+	"encoding/csv"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ServerStatus is a point-in-time view of the Dolt server, richer than the
+// boolean from IsRunning.
+type ServerStatus struct {
+	// Running is true when the server is up and answering queries.
+	Running bool `json:"running"`
+
+	// PID is the server process ID (local servers only).
+	PID int `json:"pid,omitempty"`
+
+	// Version is the Dolt version reported by dolt_version().
+	Version string `json:"version,omitempty"`
+
+	// Uptime is how long the server has been up.
+	Uptime time.Duration `json:"uptime,omitempty"`
+
+	// Connections is the number of open client connections, including the
+	// one used to collect this status.
+	Connections int `json:"connections"`
+}
+
+// Status reports whether the server is running and, if so, its version,
+// uptime, and connection count. A server that is down or unreachable yields
+// Running=false with a nil error; errors are returned only when a reachable
+// server fails to answer.
+func Status(config *Config) (ServerStatus, error) {
+	var status ServerStatus
+	if !config.IsRemote() {
+		running, pid, err := IsRunning(config.TownRoot)
+		if err != nil || !running {
+			return status, nil
+		}
+		status.PID = pid
+	}
+	if err := CheckServerReachable(config.TownRoot); err != nil {
+		return status, nil
+	}
+
+	rows, err := statusQuery(config, "SELECT dolt_version() AS version")
+	if err != nil {
+		return status, fmt.Errorf("querying server version: %w", err)
+	}
+	if len(rows) > 0 && len(rows[0]) > 0 {
+		status.Version = rows[0][0]
+	}
+	status.Running = true
+
+	rows, err = statusQuery(config, "SHOW STATUS")
+	if err != nil {
+		return status, fmt.Errorf("querying server status: %w", err)
+	}
+	vars := make(map[string]string, len(rows))
+	for _, row := range rows {
+		if len(row) >= 2 {
+			vars[row[0]] = row[1]
+		}
+	}
+	applyStatusVariables(&status, vars)
+
+	// Not every Dolt release reports these variables; fall back to what the
+	// state file and the process list can tell us.
+	if status.Uptime == 0 && !config.IsRemote() {
+		if state, err := LoadState(config.TownRoot); err == nil && !state.StartedAt.IsZero() {
+			status.Uptime = time.Since(state.StartedAt)
+		}
+	}
+	if status.Connections == 0 {
+		rows, err := statusQuery(config, "SELECT COUNT(*) AS cnt FROM information_schema.PROCESSLIST")
+		if err == nil && len(rows) > 0 && len(rows[0]) > 0 {
+			status.Connections, _ = strconv.Atoi(rows[0][0])
+		}
+	}
+	return status, nil
+}
+
+// applyStatusVariables fills uptime and connection count from SHOW STATUS
+// variables. Missing or malformed values are left unset.
+func applyStatusVariables(status *ServerStatus, vars map[string]string) {
+	if secs, err := strconv.ParseInt(vars["Uptime"], 10, 64); err == nil && secs > 0 {
+		status.Uptime = time.Duration(secs) * time.Second
+	}
+	if n, err := strconv.Atoi(vars["Threads_connected"]); err == nil && n > 0 {
+		status.Connections = n
+	}
+}
+
+// statusQuery runs query against the server and returns its CSV rows without
+// the header.
+func statusQuery(config *Config, query string) ([][]string, error) {
+	ctx, cancel := config.queryContext()
+	defer cancel()
+
+	cmd := buildDoltSQLCmd(ctx, config, "-r", "csv", "-q", query)
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return nil, config.timeoutError(ctx, fmt.Errorf("%w (output: %s)", err, strings.TrimSpace(string(output))))
+	}
+
+	records, err := csv.NewReader(strings.NewReader(string(output))).ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("parsing output of %q: %w", query, err)
+	}
+	if len(records) <= 1 {
+		return nil, nil
+	}
+	return records[1:], nil
+}
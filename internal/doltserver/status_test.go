@@ -0,0 +1,40 @@
+package doltserver
+
+import (
+	"testing"
+	"time"
+)
+
+func TestApplyStatusVariables(t *testing.T) {
+	var status ServerStatus
+	applyStatusVariables(&status, map[string]string{
+		"Uptime":            "3725",
+		"Threads_connected": "7",
+		"Questions":         "100",
+	})
+	if status.Uptime != 3725*time.Second {
+		t.Errorf("Uptime = %v, want %v", status.Uptime, 3725*time.Second)
+	}
+	if status.Connections != 7 {
+		t.Errorf("Connections = %d, want 7", status.Connections)
+	}
+}
+
+func TestApplyStatusVariables_MissingOrMalformed(t *testing.T) {
+	var status ServerStatus
+	applyStatusVariables(&status, map[string]string{"Uptime": "soon"})
+	if status.Uptime != 0 || status.Connections != 0 {
+		t.Errorf("status = %+v, want zero uptime and connections", status)
+	}
+}
+
+func TestStatus_ServerDown(t *testing.T) {
+	t.Setenv("GT_DOLT_PORT", "1") // nothing listens here
+	status, err := Status(DefaultConfig(t.TempDir()))
+	if err != nil {
+		t.Fatalf("Status() with no server: unexpected error %v", err)
+	}
+	if status.Running {
+		t.Errorf("Status().Running = true with no server")
+	}
+}
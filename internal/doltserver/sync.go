@@ -88,9 +88,8 @@ func CommitWorkingSet(dbDir string) error {
 	output, err := commitCmd.CombinedOutput()
 	if err != nil {
 		msg := strings.TrimSpace(string(output))
-		// "nothing to commit" or "no changes added" is success — no changes to push
-		lower := strings.ToLower(msg)
-		if strings.Contains(lower, "nothing to commit") || strings.Contains(lower, "no changes added") {
+		// Nothing to commit is success — no changes to push
+		if isNothingToCommitMessage(msg) {
 			return nil
 		}
 		return fmt.Errorf("dolt commit: %w (%s)", err, msg)
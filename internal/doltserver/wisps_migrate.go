@@ -141,16 +141,29 @@ func bdTableExists(workDir, tableName string) bool {
 	return err == nil
 }
 
-// ensureWispsTable creates the wisps table with the core columns needed for agent beads.
-func ensureWispsTable(workDir string) (bool, error) {
-	if bdTableExists(workDir, "wisps") {
-		return false, nil
+// VerifySchema reports which of the required tables are missing from the
+// beads database at workDir, in the order given. It first checks that bd can
+// reach the database at all, so an unreachable server is an error rather than
+// every table being reported missing. A missing "wisps" table means
+// MigrateWisps has not run.
+func VerifySchema(workDir string, required []string) ([]string, error) {
+	if err := bdSQL(workDir, "SELECT 1"); err != nil {
+		return nil, fmt.Errorf("checking schema: %w", err)
 	}
 
-	// Create the wisps table with all columns the bd tool expects.
-	// We use individual column definitions instead of CREATE TABLE LIKE because
-	// LIKE can cause Dolt server crashes with dolt_ignored tables.
-	err := bdSQL(workDir, `CREATE TABLE wisps (
+	var missing []string
+	for _, table := range required {
+		if !bdTableExists(workDir, table) {
+			missing = append(missing, table)
+		}
+	}
+	return missing, nil
+}
+
+// wispsTableDDL creates the wisps table with all columns the bd tool expects.
+// It uses individual column definitions instead of CREATE TABLE LIKE because
+// LIKE can cause Dolt server crashes with dolt_ignored tables.
+const wispsTableDDL = `CREATE TABLE wisps (
   id varchar(255) NOT NULL,
   content_hash varchar(64),
   title varchar(500) NOT NULL,
@@ -211,34 +224,25 @@ func ensureWispsTable(workDir string) (bool, error) {
   PRIMARY KEY (id),
   KEY idx_wisps_status (status),
   KEY idx_wisps_issue_type (issue_type)
-)`)
-	if err != nil {
-		return false, err
-	}
-
-	return true, nil
-}
-
-// ensureWispAuxTables creates auxiliary tables for wisps.
-func ensureWispAuxTables(workDir string) ([]string, error) {
-	var created []string
-
-	auxTables := []struct {
-		name string
-		ddl  string
-	}{
-		{
-			name: "wisp_labels",
-			ddl: `CREATE TABLE wisp_labels (
+)`
+
+// wispAuxTables lists the auxiliary wisp tables and their DDL.
+var wispAuxTables = []struct {
+	name string
+	ddl  string
+}{
+	{
+		name: "wisp_labels",
+		ddl: `CREATE TABLE wisp_labels (
   issue_id varchar(255) NOT NULL,
   label varchar(255) NOT NULL,
   PRIMARY KEY (issue_id, label),
   KEY idx_wisp_labels_label (label)
 )`,
-		},
-		{
-			name: "wisp_comments",
-			ddl: `CREATE TABLE wisp_comments (
+	},
+	{
+		name: "wisp_comments",
+		ddl: `CREATE TABLE wisp_comments (
   id bigint NOT NULL AUTO_INCREMENT,
   issue_id varchar(255) NOT NULL,
   author varchar(255) NOT NULL,
@@ -247,10 +251,10 @@ func ensureWispAuxTables(workDir string) ([]string, error) {
   PRIMARY KEY (id),
   KEY idx_wisp_comments_issue (issue_id)
 )`,
-		},
-		{
-			name: "wisp_events",
-			ddl: `CREATE TABLE wisp_events (
+	},
+	{
+		name: "wisp_events",
+		ddl: `CREATE TABLE wisp_events (
   id bigint NOT NULL AUTO_INCREMENT,
   issue_id varchar(255) NOT NULL,
   event_type varchar(32) NOT NULL,
@@ -262,10 +266,10 @@ func ensureWispAuxTables(workDir string) ([]string, error) {
   PRIMARY KEY (id),
   KEY idx_wisp_events_issue (issue_id)
 )`,
-		},
-		{
-			name: "wisp_dependencies",
-			ddl: `CREATE TABLE wisp_dependencies (
+	},
+	{
+		name: "wisp_dependencies",
+		ddl: `CREATE TABLE wisp_dependencies (
   issue_id varchar(255) NOT NULL,
   depends_on_id varchar(255) NOT NULL,
   type varchar(32) NOT NULL DEFAULT 'blocks',
@@ -276,10 +280,28 @@ func ensureWispAuxTables(workDir string) ([]string, error) {
   PRIMARY KEY (issue_id, depends_on_id),
   KEY idx_wisp_deps_depends_on (depends_on_id)
 )`,
-		},
+	},
+}
+
+// ensureWispsTable creates the wisps table with the core columns needed for agent beads.
+func ensureWispsTable(workDir string) (bool, error) {
+	if bdTableExists(workDir, "wisps") {
+		return false, nil
+	}
+
+	err := bdSQL(workDir, wispsTableDDL)
+	if err != nil {
+		return false, err
 	}
 
-	for _, t := range auxTables {
+	return true, nil
+}
+
+// ensureWispAuxTables creates auxiliary tables for wisps.
+func ensureWispAuxTables(workDir string) ([]string, error) {
+	var created []string
+
+	for _, t := range wispAuxTables {
 		if bdTableExists(workDir, t.name) {
 			continue
 		}
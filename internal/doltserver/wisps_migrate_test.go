@@ -11,13 +11,14 @@ import (
 	"testing"
 
 	_ "github.com/go-sql-driver/mysql"
+	"github.com/steveyegge/gastown/internal/doltserver/doltservertest"
 )
 
 // setupBdWorkDir creates a beads-compatible working directory pointing at an
 // isolated Dolt server. It creates a .beads/metadata.json with the server port
 // and initialises a minimal beads database with an issues table so that the
 // bd CLI can operate against it.
-func setupBdWorkDir(t *testing.T, srv *isolatedServer) string {
+func setupBdWorkDir(t *testing.T, srv *doltservertest.IsolatedServer) string {
 	t.Helper()
 
 	workDir := t.TempDir()
@@ -62,7 +63,7 @@ func setupBdWorkDir(t *testing.T, srv *isolatedServer) string {
 // TestMigrateWisps_TableCreation verifies that the wisps table and auxiliary
 // tables are created when they don't exist.
 func TestMigrateWisps_TableCreation(t *testing.T) {
-	srv := startIsolatedDoltServer(t)
+	srv := doltservertest.StartIsolated(t)
 	if _, err := exec.LookPath("bd"); err != nil {
 		t.Skip("bd not found in PATH — skipping integration test")
 	}
@@ -97,9 +98,31 @@ func TestMigrateWisps_TableCreation(t *testing.T) {
 	t.Log("wisps table does not exist — would need to create (skipping actual creation in test)")
 }
 
+// TestVerifySchema_ReportsMissingWisps verifies that a database seeded with
+// only an issues table is reported as missing the wisps table.
+func TestVerifySchema_ReportsMissingWisps(t *testing.T) {
+	srv := doltservertest.StartIsolated(t)
+	if _, err := exec.LookPath("bd"); err != nil {
+		t.Skip("bd not found in PATH — skipping integration test")
+	}
+	if err := exec.Command("bd", "sql", "--help").Run(); err != nil {
+		t.Skipf("bd in PATH has no sql command — skipping integration test: %v", err)
+	}
+
+	workDir := setupBdWorkDir(t, srv)
+
+	missing, err := VerifySchema(workDir, []string{"issues", "wisps"})
+	if err != nil {
+		t.Fatalf("VerifySchema() error = %v", err)
+	}
+	if len(missing) != 1 || missing[0] != "wisps" {
+		t.Errorf("VerifySchema() missing = %v, want [wisps]", missing)
+	}
+}
+
 // TestBdSQLCount verifies the count helper works.
 func TestBdSQLCount(t *testing.T) {
-	srv := startIsolatedDoltServer(t)
+	srv := doltservertest.StartIsolated(t)
 	if _, err := exec.LookPath("bd"); err != nil {
 		t.Skip("bd not found in PATH — skipping integration test")
 	}
@@ -8,11 +8,14 @@ import (
 	"context"
 	"crypto/rand"
 	"crypto/sha256"
+	"database/sql"
 	"encoding/hex"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
 	"time"
 )
 
@@ -21,6 +24,7 @@ const WLCommonsDB = "wl_commons"
 
 // WLCommonsStore abstracts wl-commons database operations.
 type WLCommonsStore interface {
+	Ping(ctx context.Context) error
 	EnsureDB() error
 	DatabaseExists(dbName string) bool
 	InsertWanted(item *WantedItem) error
@@ -30,22 +34,73 @@ type WLCommonsStore interface {
 }
 
 // WLCommons implements WLCommonsStore using the real Dolt server.
-type WLCommons struct{ townRoot string }
+// Writes and queries share a lazily opened connection pool (see
+// wl_commons_pool.go); call Close when the store is no longer needed.
+type WLCommons struct {
+	townRoot string
+	config   *Config
+	readOnly bool
+
+	mu sync.Mutex
+	db *sql.DB
+}
+
+// ErrReadOnly is returned by the write methods of a store created with
+// NewWLCommonsReadOnly.
+var ErrReadOnly = errors.New("wl-commons store is read-only")
 
 // NewWLCommons creates a WLCommonsStore backed by the real Dolt server.
-func NewWLCommons(townRoot string) *WLCommons { return &WLCommons{townRoot: townRoot} }
+func NewWLCommons(townRoot string) *WLCommons {
+	return &WLCommons{townRoot: townRoot, config: DefaultConfig(townRoot)}
+}
+
+// NewWLCommonsReadOnly creates a WLCommonsStore for reporting tools. Reads
+// work normally; EnsureDB, InsertWanted, ClaimWanted, and SubmitCompletion
+// return ErrReadOnly without touching the server.
+func NewWLCommonsReadOnly(townRoot string) *WLCommons {
+	return &WLCommons{townRoot: townRoot, config: DefaultConfig(townRoot), readOnly: true}
+}
 
-func (w *WLCommons) EnsureDB() error           { return EnsureWLCommons(w.townRoot) }
+// EnsureDB creates the wl-commons database if needed and applies any
+// pending schema migrations (see RunMigrations).
+func (w *WLCommons) EnsureDB() error {
+	if w.readOnly {
+		return ErrReadOnly
+	}
+	if err := EnsureWLCommons(w.townRoot); err != nil {
+		return err
+	}
+	if _, err := RunMigrations(context.Background(), w); err != nil {
+		return fmt.Errorf("migrating wl-commons: %w", err)
+	}
+	return nil
+}
 func (w *WLCommons) DatabaseExists(db string) bool { return DatabaseExists(w.townRoot, db) }
-func (w *WLCommons) InsertWanted(item *WantedItem) error { return InsertWanted(w.townRoot, item) }
+func (w *WLCommons) InsertWanted(item *WantedItem) error {
+	if w.readOnly {
+		return ErrReadOnly
+	}
+	script, err := insertWantedScript(item)
+	if err != nil {
+		return err
+	}
+	return w.execScript(script)
+}
 func (w *WLCommons) ClaimWanted(wantedID, rigHandle string) error {
-	return ClaimWanted(w.townRoot, wantedID, rigHandle)
+	if w.readOnly {
+		return ErrReadOnly
+	}
+	return claimWantedResult(w.execScript(claimWantedScript(wantedID, rigHandle)), wantedID)
 }
 func (w *WLCommons) SubmitCompletion(completionID, wantedID, rigHandle, evidence string) error {
-	return SubmitCompletion(w.townRoot, completionID, wantedID, rigHandle, evidence)
+	if w.readOnly {
+		return ErrReadOnly
+	}
+	err := w.execScript(submitCompletionScript(completionID, wantedID, rigHandle, evidence))
+	return submitCompletionResult(err, wantedID, rigHandle)
 }
 func (w *WLCommons) QueryWanted(wantedID string) (*WantedItem, error) {
-	return QueryWanted(w.townRoot, wantedID)
+	return w.queryWanted(wantedID)
 }
 
 // WantedItem represents a row in the wanted table.
@@ -64,11 +119,31 @@ type WantedItem struct {
 	SandboxRequired bool
 }
 
+// nothingToCommitPhrases are the known (lowercased) wordings Dolt has used
+// for a commit with no changes. Dolt has reworded this before, so match any.
+var nothingToCommitPhrases = []string{
+	"nothing to commit",
+	"no changes added to commit",
+	"no changes to commit",
+}
+
 // isNothingToCommit returns true if the error indicates DOLT_COMMIT found no
 // changes to commit. This happens when a conditional UPDATE matched 0 rows,
 // leaving the working set unchanged.
 func isNothingToCommit(err error) bool {
-	return err != nil && strings.Contains(strings.ToLower(err.Error()), "nothing to commit")
+	return err != nil && isNothingToCommitMessage(err.Error())
+}
+
+// isNothingToCommitMessage reports whether msg contains any known
+// nothing-to-commit wording, ignoring case.
+func isNothingToCommitMessage(msg string) bool {
+	lower := strings.ToLower(msg)
+	for _, phrase := range nothingToCommitPhrases {
+		if strings.Contains(lower, phrase) {
+			return true
+		}
+	}
+	return false
 }
 
 // EscapeSQL escapes backslashes and single quotes for SQL string literals.
@@ -224,11 +299,20 @@ func backtickKey() string {
 
 // InsertWanted inserts a new wanted item into the wl-commons database.
 func InsertWanted(townRoot string, item *WantedItem) error {
+	script, err := insertWantedScript(item)
+	if err != nil {
+		return err
+	}
+	return doltSQLScriptWithRetry(townRoot, script)
+}
+
+// insertWantedScript builds the script that inserts and commits a wanted item.
+func insertWantedScript(item *WantedItem) (string, error) {
 	if item.ID == "" {
-		return fmt.Errorf("wanted item ID cannot be empty")
+		return "", fmt.Errorf("wanted item ID cannot be empty")
 	}
 	if item.Title == "" {
-		return fmt.Errorf("wanted item title cannot be empty")
+		return "", fmt.Errorf("wanted item title cannot be empty")
 	}
 
 	now := time.Now().UTC().Format("2006-01-02 15:04:05")
@@ -284,7 +368,7 @@ CALL DOLT_COMMIT('-m', 'wl post: %s');
 		now, now,
 		EscapeSQL(item.Title))
 
-	return doltSQLScriptWithRetry(townRoot, script)
+	return script, nil
 }
 
 // ClaimWanted updates a wanted item's status to claimed.
@@ -296,14 +380,21 @@ CALL DOLT_COMMIT('-m', 'wl post: %s');
 // map to a precondition error. This avoids splitting into separate sessions
 // and eliminates the need for DOLT_RESET on failure.
 func ClaimWanted(townRoot, wantedID, rigHandle string) error {
-	script := fmt.Sprintf(`USE %s;
+	err := doltSQLScriptWithRetry(townRoot, claimWantedScript(wantedID, rigHandle))
+	return claimWantedResult(err, wantedID)
+}
+
+func claimWantedScript(wantedID, rigHandle string) string {
+	return fmt.Sprintf(`USE %s;
 UPDATE wanted SET claimed_by='%s', status='claimed', updated_at=NOW()
   WHERE id='%s' AND status='open';
 CALL DOLT_ADD('-A');
 CALL DOLT_COMMIT('-m', 'wl claim: %s');
 `, WLCommonsDB, EscapeSQL(rigHandle), EscapeSQL(wantedID), EscapeSQL(wantedID))
+}
 
-	err := doltSQLScriptWithRetry(townRoot, script)
+// claimWantedResult maps a claim script error to the caller-facing error.
+func claimWantedResult(err error, wantedID string) error {
 	if err == nil {
 		return nil
 	}
@@ -323,7 +414,12 @@ CALL DOLT_COMMIT('-m', 'wl claim: %s');
 // completions.id is a PRIMARY KEY. NOT EXISTS prevents multiple completions per
 // wanted item, ensuring the lifecycle is strictly post→claim→done.
 func SubmitCompletion(townRoot, completionID, wantedID, rigHandle, evidence string) error {
-	script := fmt.Sprintf(`USE %s;
+	err := doltSQLScriptWithRetry(townRoot, submitCompletionScript(completionID, wantedID, rigHandle, evidence))
+	return submitCompletionResult(err, wantedID, rigHandle)
+}
+
+func submitCompletionScript(completionID, wantedID, rigHandle, evidence string) string {
+	return fmt.Sprintf(`USE %s;
 UPDATE wanted SET status='in_review', evidence_url='%s', updated_at=NOW()
   WHERE id='%s' AND status='claimed' AND claimed_by='%s';
 INSERT IGNORE INTO completions (id, wanted_id, completed_by, evidence, completed_at)
@@ -338,8 +434,10 @@ CALL DOLT_COMMIT('-m', 'wl done: %s');
 		EscapeSQL(completionID), EscapeSQL(wantedID), EscapeSQL(rigHandle), EscapeSQL(evidence),
 		EscapeSQL(wantedID), EscapeSQL(rigHandle), EscapeSQL(wantedID),
 		EscapeSQL(wantedID))
+}
 
-	err := doltSQLScriptWithRetry(townRoot, script)
+// submitCompletionResult maps a completion script error to the caller-facing error.
+func submitCompletionResult(err error, wantedID, rigHandle string) error {
 	if err == nil {
 		return nil
 	}
@@ -377,13 +475,13 @@ func QueryWanted(townRoot, wantedID string) (*WantedItem, error) {
 // doltSQLQuery executes a SQL query and returns the raw CSV output.
 func doltSQLQuery(townRoot, query string) (string, error) {
 	config := DefaultConfig(townRoot)
-	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	ctx, cancel := config.queryContext()
 	defer cancel()
 
 	cmd := buildDoltSQLCmd(ctx, config, "-r", "csv", "-q", query)
 	output, err := cmd.CombinedOutput()
 	if err != nil {
-		return "", fmt.Errorf("dolt sql query failed: %w (%s)", err, strings.TrimSpace(string(output)))
+		return "", config.timeoutError(ctx, fmt.Errorf("dolt sql query failed: %w (%s)", err, strings.TrimSpace(string(output))))
 	}
 	return string(output), nil
 }
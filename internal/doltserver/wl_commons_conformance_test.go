@@ -1,6 +1,7 @@
 package doltserver
 
 import (
+	"context"
 	"strings"
 	"testing"
 )
@@ -9,6 +10,15 @@ import (
 // implementation against the expected behavioral contract. It runs against the
 // fake (always) and can run against the real Dolt server with build tags.
 func wlCommonsConformance(t *testing.T, newStore func(t *testing.T) WLCommonsStore) {
+	t.Run("Ping", func(t *testing.T) {
+		t.Parallel()
+		store := newStore(t)
+
+		if err := store.Ping(context.Background()); err != nil {
+			t.Fatalf("Ping() error: %v", err)
+		}
+	})
+
 	t.Run("InsertAndQuery", func(t *testing.T) {
 		t.Parallel()
 		store := newStore(t)
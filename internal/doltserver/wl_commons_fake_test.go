@@ -1,6 +1,7 @@
 package doltserver
 
 import (
+	"context"
 	"fmt"
 	"sync"
 )
@@ -13,6 +14,7 @@ type fakeWLCommonsStore struct {
 	dbOK  bool
 
 	// Error injection fields
+	PingErr             error
 	EnsureDBErr         error
 	InsertWantedErr     error
 	ClaimWantedErr      error
@@ -27,6 +29,10 @@ func newFakeWLCommonsStore() *fakeWLCommonsStore {
 	}
 }
 
+func (f *fakeWLCommonsStore) Ping(context.Context) error {
+	return f.PingErr
+}
+
 func (f *fakeWLCommonsStore) EnsureDB() error {
 	if f.EnsureDBErr != nil {
 		return f.EnsureDBErr
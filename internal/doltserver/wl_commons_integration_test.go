@@ -3,117 +3,140 @@
 package doltserver
 
 import (
-	"database/sql"
+	"context"
+	"errors"
 	"fmt"
 	"net"
-	"os"
-	"os/exec"
 	"strconv"
 	"testing"
 	"time"
 
-	_ "github.com/go-sql-driver/mysql"
+	"github.com/steveyegge/gastown/internal/doltserver/doltservertest"
 )
 
-// isolatedServer holds the connection details of a test Dolt server.
-type isolatedServer struct {
-	TownRoot string
-	Port     int
-}
+// TestRealWLCommonsStore_Conformance runs the conformance suite against a real Dolt server.
+func TestRealWLCommonsStore_Conformance(t *testing.T) {
+	srv := doltservertest.StartIsolated(t)
 
-// startIsolatedDoltServer starts a Dolt SQL server on a dynamic port with an
-// isolated data directory. It sets GT_DOLT_PORT so that DefaultConfig and all
-// downstream functions (IsRunning, serverExecSQL, buildDoltSQLCmd, etc.)
-// connect to this server instead of the production server on port 3307.
-// The server is killed when the test completes.
-func startIsolatedDoltServer(t *testing.T) *isolatedServer {
-	t.Helper()
+	// Pre-create the database before parallel subtests to avoid
+	// concurrent CREATE DATABASE races.
+	store := NewWLCommons(srv.TownRoot)
+	if err := store.EnsureDB(); err != nil {
+		t.Fatalf("EnsureDB() error: %v", err)
+	}
 
-	if _, err := exec.LookPath("dolt"); err != nil {
-		t.Skip("dolt not found in PATH — skipping integration test")
+	wlCommonsConformance(t, func(t *testing.T) WLCommonsStore {
+		return NewWLCommons(srv.TownRoot)
+	})
+}
+
+// TestRealWLCommonsStore_ReusesConnection verifies that sequential store
+// operations run on the same pooled server connection rather than dialing
+// a new one per call.
+func TestRealWLCommonsStore_ReusesConnection(t *testing.T) {
+	srv := doltservertest.StartIsolated(t)
+	store := NewWLCommons(srv.TownRoot)
+	defer store.Close()
+	if err := store.EnsureDB(); err != nil {
+		t.Fatalf("EnsureDB() error: %v", err)
 	}
 
-	townRoot := t.TempDir()
-	// Read config BEFORE setting GT_DOLT_PORT so DataDir path is correct.
-	dataDir := DefaultConfig(townRoot).DataDir
-	if err := os.MkdirAll(dataDir, 0755); err != nil {
-		t.Fatalf("creating data dir: %v", err)
+	if err := store.InsertWanted(&WantedItem{ID: "w-pool01", Title: "Pooled"}); err != nil {
+		t.Fatalf("InsertWanted() error: %v", err)
+	}
+	for i := 0; i < 5; i++ {
+		if _, err := store.QueryWanted("w-pool01"); err != nil {
+			t.Fatalf("QueryWanted() error: %v", err)
+		}
 	}
 
-	// Find a free port.
-	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	db, err := store.pool()
 	if err != nil {
-		t.Fatalf("finding free port: %v", err)
+		t.Fatalf("pool() error: %v", err)
 	}
-	port := ln.Addr().(*net.TCPAddr).Port
-	ln.Close()
-
-	// Override GT_DOLT_PORT so all DefaultConfig calls use our port.
-	// This is critical: without it, IsRunning/serverExecSQL would fall back
-	// to port 3307 and hit the production server.
-	t.Setenv("GT_DOLT_PORT", strconv.Itoa(port))
-
-	// Configure dolt identity in an isolated root.
-	doltEnv := append(os.Environ(), "DOLT_ROOT_PATH="+townRoot)
-	for _, args := range [][]string{
-		{"dolt", "config", "--global", "--add", "user.name", "integration-test"},
-		{"dolt", "config", "--global", "--add", "user.email", "test@integration.test"},
-	} {
-		cmd := exec.Command(args[0], args[1:]...)
-		cmd.Env = doltEnv
-		if out, err := cmd.CombinedOutput(); err != nil {
-			t.Fatalf("%s failed: %v\n%s", args[1], err, out)
+	connectionID := func() int64 {
+		var id int64
+		if err := db.QueryRow("SELECT CONNECTION_ID()").Scan(&id); err != nil {
+			t.Fatalf("CONNECTION_ID(): %v", err)
 		}
+		return id
+	}
+	if a, b := connectionID(), connectionID(); a != b {
+		t.Errorf("sequential queries used connections %d and %d, want one reused connection", a, b)
 	}
+	if open := db.Stats().OpenConnections; open != 1 {
+		t.Errorf("OpenConnections = %d after sequential calls, want 1", open)
+	}
+}
 
-	// Start dolt sql-server on the dynamic port.
-	serverCmd := exec.Command("dolt", "sql-server",
-		"--port", fmt.Sprintf("%d", port),
-		"--data-dir", dataDir,
-	)
-	serverCmd.Env = doltEnv
-	serverCmd.Stdout = nil
-	serverCmd.Stderr = nil
-	if err := serverCmd.Start(); err != nil {
-		t.Fatalf("starting dolt sql-server: %v", err)
-	}
-	t.Cleanup(func() {
-		_ = serverCmd.Process.Kill()
-		_ = serverCmd.Wait()
-	})
+// TestRealWLCommonsReadOnly verifies a read-only store can read what a
+// writable store wrote, but cannot write itself.
+func TestRealWLCommonsReadOnly(t *testing.T) {
+	srv := doltservertest.StartIsolated(t)
+	writer := NewWLCommons(srv.TownRoot)
+	defer writer.Close()
+	if err := writer.EnsureDB(); err != nil {
+		t.Fatalf("EnsureDB() error: %v", err)
+	}
+	if err := writer.InsertWanted(&WantedItem{ID: "w-ro0001", Title: "Readable"}); err != nil {
+		t.Fatalf("InsertWanted() error: %v", err)
+	}
 
-	// Wait for server readiness via MySQL ping.
-	dsn := fmt.Sprintf("root@tcp(127.0.0.1:%d)/?timeout=1s", port)
-	deadline := time.Now().Add(15 * time.Second)
-	for time.Now().Before(deadline) {
-		db, err := sql.Open("mysql", dsn)
-		if err == nil {
-			if err := db.Ping(); err == nil {
-				db.Close()
-				return &isolatedServer{TownRoot: townRoot, Port: port}
-			}
-			db.Close()
-		}
-		time.Sleep(200 * time.Millisecond)
+	reader := NewWLCommonsReadOnly(srv.TownRoot)
+	defer reader.Close()
+	item, err := reader.QueryWanted("w-ro0001")
+	if err != nil {
+		t.Fatalf("read-only QueryWanted() error: %v", err)
+	}
+	if item.Title != "Readable" {
+		t.Errorf("QueryWanted().Title = %q, want %q", item.Title, "Readable")
+	}
+
+	if err := reader.ClaimWanted("w-ro0001", "some-rig"); !errors.Is(err, ErrReadOnly) {
+		t.Errorf("read-only ClaimWanted() = %v, want ErrReadOnly", err)
+	}
+	if item, _ := writer.QueryWanted("w-ro0001"); item.ClaimedBy != "" {
+		t.Errorf("read-only claim changed the commons: claimed_by = %q", item.ClaimedBy)
 	}
-	t.Fatalf("dolt sql-server did not become ready on port %d within 15s", port)
-	return nil // unreachable
 }
 
-// TestRealWLCommonsStore_Conformance runs the conformance suite against a real Dolt server.
-func TestRealWLCommonsStore_Conformance(t *testing.T) {
-	srv := startIsolatedDoltServer(t)
+// TestWLCommonsPing_RealDolt verifies that Ping succeeds against a running
+// server without creating the wl-commons database, and fails within the
+// caller's deadline when nothing is listening.
+func TestWLCommonsPing_RealDolt(t *testing.T) {
+	srv := doltservertest.StartIsolated(t)
 
-	// Pre-create the database before parallel subtests to avoid
-	// concurrent CREATE DATABASE races.
-	store := NewWLCommons(srv.TownRoot)
-	if err := store.EnsureDB(); err != nil {
-		t.Fatalf("EnsureDB() error: %v", err)
+	store := NewWLCommonsReadOnly(srv.TownRoot)
+	t.Cleanup(func() { _ = store.Close() })
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := store.Ping(ctx); err != nil {
+		t.Fatalf("Ping() against running server = %v", err)
+	}
+	if store.DatabaseExists(WLCommonsDB) {
+		t.Error("Ping() created the wl-commons database")
 	}
 
-	wlCommonsConformance(t, func(t *testing.T) WLCommonsStore {
-		return NewWLCommons(srv.TownRoot)
-	})
+	// Point a second store at a port with no server behind it.
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("finding free port: %v", err)
+	}
+	deadPort := ln.Addr().(*net.TCPAddr).Port
+	ln.Close()
+	t.Setenv("GT_DOLT_PORT", strconv.Itoa(deadPort))
+
+	down := NewWLCommonsReadOnly(srv.TownRoot)
+	t.Cleanup(func() { _ = down.Close() })
+	ctx, cancel = context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	start := time.Now()
+	if err := down.Ping(ctx); err == nil {
+		t.Fatal("Ping() against stopped server succeeded, want error")
+	}
+	if elapsed := time.Since(start); elapsed > 3*time.Second {
+		t.Errorf("Ping() took %v against a stopped server, want it to fail fast", elapsed)
+	}
 }
 
 // TestIsNothingToCommit_RealDolt verifies that isNothingToCommit correctly detects
@@ -121,7 +144,7 @@ func TestRealWLCommonsStore_Conformance(t *testing.T) {
 // logic against the actual Dolt error text so that Dolt upgrades that change the
 // message wording are caught immediately.
 func TestIsNothingToCommit_RealDolt(t *testing.T) {
-	srv := startIsolatedDoltServer(t)
+	srv := doltservertest.StartIsolated(t)
 
 	// Create a database and table so we have a valid context for DOLT_COMMIT.
 	initScript := fmt.Sprintf(`CREATE DATABASE IF NOT EXISTS %s;
@@ -148,3 +171,159 @@ CALL DOLT_COMMIT('-m', 'noop');
 		t.Errorf("isNothingToCommit(%q) = false, want true — Dolt error text may have changed", err)
 	}
 }
+
+// TestRunMigrations_RealDolt applies two migrations, re-runs them, and checks
+// that neither is applied twice — including when two agents run at once.
+func TestRunMigrations_RealDolt(t *testing.T) {
+	srv := doltservertest.StartIsolated(t)
+	store := NewWLCommons(srv.TownRoot)
+	defer store.Close()
+	if err := store.EnsureDB(); err != nil {
+		t.Fatalf("EnsureDB() error: %v", err)
+	}
+
+	// EnsureDB has already applied the registered migrations, so use
+	// versions well clear of them.
+	migrations := []SchemaMigration{
+		stmtMigration{version: 1002, stmts: []string{"INSERT INTO migration_log (note) VALUES ('v2')"}},
+		stmtMigration{version: 1001, stmts: []string{
+			"CREATE TABLE migration_log (id INT AUTO_INCREMENT PRIMARY KEY, note VARCHAR(32))",
+			"INSERT INTO migration_log (note) VALUES ('v1')",
+		}},
+	}
+	ctx := context.Background()
+
+	applied, err := runMigrations(ctx, store, migrations)
+	if err != nil {
+		t.Fatalf("first run error: %v", err)
+	}
+	if fmt.Sprint(applied) != "[1001 1002]" {
+		t.Fatalf("first run applied %v, want [1001 1002]", applied)
+	}
+
+	// Re-run, once sequentially and twice concurrently from separate stores.
+	if applied, err := runMigrations(ctx, store, migrations); err != nil || len(applied) != 0 {
+		t.Fatalf("re-run applied %v, err %v; want nothing", applied, err)
+	}
+	errs := make(chan error, 2)
+	for i := 0; i < 2; i++ {
+		go func() {
+			other := NewWLCommons(srv.TownRoot)
+			defer other.Close()
+			applied, err := runMigrations(ctx, other, migrations)
+			if err == nil && len(applied) != 0 {
+				err = fmt.Errorf("concurrent re-run applied %v", applied)
+			}
+			errs <- err
+		}()
+	}
+	for i := 0; i < 2; i++ {
+		if err := <-errs; err != nil {
+			t.Errorf("concurrent re-run: %v", err)
+		}
+	}
+
+	db, err := store.pool()
+	if err != nil {
+		t.Fatalf("pool() error: %v", err)
+	}
+	var rows, versions int
+	if err := db.QueryRow(fmt.Sprintf("SELECT COUNT(*) FROM `%s`.migration_log", WLCommonsDB)).Scan(&rows); err != nil {
+		t.Fatalf("counting migration_log: %v", err)
+	}
+	if err := db.QueryRow(fmt.Sprintf("SELECT COUNT(*) FROM `%s`.schema_migrations WHERE version > 1000", WLCommonsDB)).Scan(&versions); err != nil {
+		t.Fatalf("counting schema_migrations: %v", err)
+	}
+	if rows != 2 || versions != 2 {
+		t.Errorf("migration_log rows = %d, schema_migrations rows = %d; want 2 and 2", rows, versions)
+	}
+}
+
+// TestEnsureDB_AppliesMigrations_RealDolt checks that EnsureDB applies the
+// registered migrations and leaves a clean working set, both on the first
+// run and on a re-run with nothing pending.
+func TestEnsureDB_AppliesMigrations_RealDolt(t *testing.T) {
+	srv := doltservertest.StartIsolated(t)
+	store := NewWLCommons(srv.TownRoot)
+	defer store.Close()
+
+	for run := 1; run <= 2; run++ {
+		if err := store.EnsureDB(); err != nil {
+			t.Fatalf("run %d: EnsureDB() error: %v", run, err)
+		}
+		db, err := store.pool()
+		if err != nil {
+			t.Fatalf("pool() error: %v", err)
+		}
+		var wisps, dirty int
+		if err := db.QueryRow(fmt.Sprintf("SELECT COUNT(*) FROM `%s`.schema_migrations WHERE version = 1", WLCommonsDB)).Scan(&wisps); err != nil {
+			t.Fatalf("run %d: reading schema_migrations: %v", run, err)
+		}
+		if wisps != 1 {
+			t.Errorf("run %d: wisps migration recorded %d times, want 1", run, wisps)
+		}
+		if _, err := db.Exec(fmt.Sprintf("SELECT id FROM `%s`.wisps LIMIT 1", WLCommonsDB)); err != nil {
+			t.Errorf("run %d: wisps table missing: %v", run, err)
+		}
+		if err := db.QueryRow(fmt.Sprintf("SELECT COUNT(*) FROM `%s`.dolt_status", WLCommonsDB)).Scan(&dirty); err != nil {
+			t.Fatalf("run %d: reading dolt_status: %v", run, err)
+		}
+		if dirty != 0 {
+			t.Errorf("run %d: %d uncommitted table(s) after EnsureDB, want 0", run, dirty)
+		}
+	}
+}
+
+// TestWLCommonsCommitNow_RealDolt verifies that a write left in the working
+// set doesn't move HEAD until CommitNow commits it, and that CommitNow on a
+// clean working set is a no-op rather than an error.
+func TestWLCommonsCommitNow_RealDolt(t *testing.T) {
+	srv := doltservertest.StartIsolated(t)
+	store := NewWLCommons(srv.TownRoot)
+	defer store.Close()
+	if err := store.EnsureDB(); err != nil {
+		t.Fatalf("EnsureDB() error: %v", err)
+	}
+
+	db, err := store.pool()
+	if err != nil {
+		t.Fatalf("pool() error: %v", err)
+	}
+	head := func() string {
+		var hash string
+		if err := db.QueryRow(fmt.Sprintf("SELECT commit_hash FROM `%s`.dolt_log LIMIT 1", WLCommonsDB)).Scan(&hash); err != nil {
+			t.Fatalf("reading HEAD: %v", err)
+		}
+		return hash
+	}
+	ctx := context.Background()
+
+	before := head()
+	if _, err := db.Exec(fmt.Sprintf("CREATE TABLE `%s`.commit_probe (id INT PRIMARY KEY)", WLCommonsDB)); err != nil {
+		t.Fatalf("uncommitted write: %v", err)
+	}
+	if got := head(); got != before {
+		t.Fatalf("HEAD moved from %s to %s without a commit", before, got)
+	}
+
+	if err := store.CommitNow(ctx); err != nil {
+		t.Fatalf("CommitNow() error: %v", err)
+	}
+	committed := head()
+	if committed == before {
+		t.Fatal("CommitNow() did not produce a new commit")
+	}
+
+	if err := store.CommitNow(ctx); err != nil {
+		t.Fatalf("CommitNow() on a clean working set = %v, want nil", err)
+	}
+	if got := head(); got != committed {
+		t.Errorf("CommitNow() on a clean working set moved HEAD from %s to %s", committed, got)
+	}
+
+	reader := NewWLCommonsReadOnly(srv.TownRoot)
+	defer reader.Close()
+	if err := reader.CommitNow(ctx); !errors.Is(err, ErrReadOnly) {
+		t.Errorf("read-only CommitNow() = %v, want ErrReadOnly", err)
+	}
+}
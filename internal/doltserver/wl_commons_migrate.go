@@ -0,0 +1,176 @@
+package doltserver
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// SchemaMigration is one ordered, one-time change to the wl-commons schema.
+// Versions must be unique and positive; they are applied in ascending order
+// and never re-applied once recorded in schema_migrations.
+type SchemaMigration interface {
+	Version() int
+	Up(tx *sql.Tx) error
+}
+
+// wlCommonsMigrations is the ordered list of wl-commons schema migrations
+// applied by RunMigrations. Append new migrations; never renumber or edit
+// ones that have shipped.
+var wlCommonsMigrations = []SchemaMigration{
+	wispsSchemaMigration{},
+}
+
+// wispsSchemaMigration (version 1) creates the wisps table and its auxiliary
+// tables, with the same DDL MigrateAgentBeadsToWisps uses for beads
+// databases. Tables that already exist are left alone.
+type wispsSchemaMigration struct{}
+
+func (wispsSchemaMigration) Version() int { return 1 }
+
+func (wispsSchemaMigration) Up(tx *sql.Tx) error {
+	ddls := []string{wispsTableDDL}
+	for _, t := range wispAuxTables {
+		ddls = append(ddls, t.ddl)
+	}
+	for _, ddl := range ddls {
+		if _, err := tx.Exec(strings.Replace(ddl, "CREATE TABLE ", "CREATE TABLE IF NOT EXISTS ", 1)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// wlMigrationLock names the server-wide lock that serializes migration runs,
+// so agents starting at the same time don't apply a version twice.
+const wlMigrationLock = "wl_commons_migrations"
+
+// wlMigrationLockTimeout is how long (in seconds) a run waits for another
+// agent's run to finish before giving up.
+const wlMigrationLockTimeout = 30
+
+// RunMigrations applies all pending wl-commons migrations in version order
+// and returns the versions it applied. Safe to run concurrently: runs are
+// serialized by a named server lock, and each version is checked again
+// under the lock before it is applied. The database must already exist
+// (see EnsureDB).
+func RunMigrations(ctx context.Context, store *WLCommons) ([]int, error) {
+	return runMigrations(ctx, store, wlCommonsMigrations)
+}
+
+func runMigrations(ctx context.Context, store *WLCommons, migrations []SchemaMigration) ([]int, error) {
+	if store.readOnly {
+		return nil, ErrReadOnly
+	}
+	ordered, err := orderMigrations(migrations)
+	if err != nil {
+		return nil, err
+	}
+
+	db, err := store.pool()
+	if err != nil {
+		return nil, err
+	}
+	// Named locks belong to a session, so pin one connection for the run.
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("connecting for migrations: %w", err)
+	}
+	defer conn.Close()
+
+	if _, err := conn.ExecContext(ctx, fmt.Sprintf("USE `%s`", WLCommonsDB)); err != nil {
+		return nil, fmt.Errorf("selecting %s: %w", WLCommonsDB, err)
+	}
+
+	var locked sql.NullInt64
+	if err := conn.QueryRowContext(ctx, "SELECT GET_LOCK(?, ?)", wlMigrationLock, wlMigrationLockTimeout).Scan(&locked); err != nil {
+		return nil, fmt.Errorf("acquiring migration lock: %w", err)
+	}
+	if locked.Int64 != 1 {
+		return nil, fmt.Errorf("timed out after %ds waiting for another migration run", wlMigrationLockTimeout)
+	}
+	defer func() {
+		_, _ = conn.ExecContext(context.Background(), "SELECT RELEASE_LOCK(?)", wlMigrationLock)
+	}()
+
+	if _, err := conn.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS schema_migrations (
+    version INT PRIMARY KEY,
+    applied_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+)`); err != nil {
+		return nil, fmt.Errorf("creating schema_migrations: %w", err)
+	}
+	// Commit the table on its own, so a run with nothing pending doesn't
+	// leave it in the working set.
+	if _, err := conn.ExecContext(ctx, "CALL DOLT_ADD('schema_migrations')"); err != nil {
+		return nil, fmt.Errorf("staging schema_migrations: %w", err)
+	}
+	if _, err := conn.ExecContext(ctx, "CALL DOLT_COMMIT('-m', 'wl migrate: schema_migrations')"); err != nil && !isNothingToCommit(err) {
+		return nil, fmt.Errorf("committing schema_migrations: %w", err)
+	}
+
+	var applied []int
+	for _, m := range ordered {
+		done, err := applyMigration(ctx, conn, m)
+		if err != nil {
+			return applied, fmt.Errorf("migration %d: %w", m.Version(), err)
+		}
+		if done {
+			applied = append(applied, m.Version())
+		}
+	}
+	return applied, nil
+}
+
+// applyMigration applies m in its own transaction unless schema_migrations
+// already records it. The version row is inserted in the same transaction
+// and Dolt-committed with it, so a migration is recorded iff it took effect.
+func applyMigration(ctx context.Context, conn *sql.Conn, m SchemaMigration) (bool, error) {
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	var n int
+	if err := tx.QueryRowContext(ctx, "SELECT COUNT(*) FROM schema_migrations WHERE version = ?", m.Version()).Scan(&n); err != nil {
+		return false, fmt.Errorf("checking applied versions: %w", err)
+	}
+	if n > 0 {
+		return false, nil
+	}
+
+	if err := m.Up(tx); err != nil {
+		return false, err
+	}
+	if _, err := tx.ExecContext(ctx, "INSERT INTO schema_migrations (version) VALUES (?)", m.Version()); err != nil {
+		return false, fmt.Errorf("recording version: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, "CALL DOLT_ADD('-A')"); err != nil {
+		return false, fmt.Errorf("staging: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, "CALL DOLT_COMMIT('-m', ?)", fmt.Sprintf("wl migrate: %d", m.Version())); err != nil {
+		return false, fmt.Errorf("committing: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// orderMigrations returns migrations sorted by version, rejecting
+// non-positive and duplicate versions.
+func orderMigrations(migrations []SchemaMigration) ([]SchemaMigration, error) {
+	ordered := append([]SchemaMigration(nil), migrations...)
+	sort.SliceStable(ordered, func(i, j int) bool { return ordered[i].Version() < ordered[j].Version() })
+	for i, m := range ordered {
+		if m.Version() <= 0 {
+			return nil, fmt.Errorf("migration version %d must be positive", m.Version())
+		}
+		if i > 0 && ordered[i-1].Version() == m.Version() {
+			return nil, fmt.Errorf("duplicate migration version %d", m.Version())
+		}
+	}
+	return ordered, nil
+}
@@ -0,0 +1,64 @@
+package doltserver
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+)
+
+// stmtMigration is a SchemaMigration that runs fixed statements.
+type stmtMigration struct {
+	version int
+	stmts   []string
+}
+
+func (m stmtMigration) Version() int { return m.version }
+
+func (m stmtMigration) Up(tx *sql.Tx) error {
+	for _, stmt := range m.stmts {
+		if _, err := tx.Exec(stmt); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func TestOrderMigrations(t *testing.T) {
+	ordered, err := orderMigrations([]SchemaMigration{stmtMigration{version: 3}, stmtMigration{version: 1}, stmtMigration{version: 2}})
+	if err != nil {
+		t.Fatalf("orderMigrations: %v", err)
+	}
+	for i, m := range ordered {
+		if m.Version() != i+1 {
+			t.Errorf("ordered[%d].Version() = %d, want %d", i, m.Version(), i+1)
+		}
+	}
+
+	if _, err := orderMigrations([]SchemaMigration{stmtMigration{version: 1}, stmtMigration{version: 1}}); err == nil {
+		t.Error("expected error for duplicate versions")
+	}
+	if _, err := orderMigrations([]SchemaMigration{stmtMigration{version: 0}}); err == nil {
+		t.Error("expected error for non-positive version")
+	}
+}
+
+func TestWLCommonsMigrations_WispsIsVersionOne(t *testing.T) {
+	ordered, err := orderMigrations(wlCommonsMigrations)
+	if err != nil {
+		t.Fatalf("orderMigrations(wlCommonsMigrations): %v", err)
+	}
+	if len(ordered) == 0 {
+		t.Fatal("no wl-commons migrations registered")
+	}
+	if _, ok := ordered[0].(wispsSchemaMigration); !ok || ordered[0].Version() != 1 {
+		t.Errorf("first migration = %T v%d, want wispsSchemaMigration v1", ordered[0], ordered[0].Version())
+	}
+}
+
+func TestRunMigrations_ReadOnly(t *testing.T) {
+	store := NewWLCommonsReadOnly(t.TempDir())
+	if _, err := RunMigrations(context.Background(), store); !errors.Is(err, ErrReadOnly) {
+		t.Errorf("RunMigrations() on read-only store = %v, want ErrReadOnly", err)
+	}
+}
@@ -0,0 +1,143 @@
+package doltserver
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	_ "github.com/go-sql-driver/mysql" // MySQL protocol driver for the Dolt server
+)
+
+// Connection pool limits for WLCommons. Agents issue a handful of short
+// scripts per command, so a small pool is plenty; the lifetime cap keeps
+// connections from outliving a server restart for long.
+const (
+	wlCommonsMaxOpenConns    = 4
+	wlCommonsMaxIdleConns    = 2
+	wlCommonsConnMaxLifetime = 5 * time.Minute
+)
+
+// poolDSN returns a MySQL DSN for the configured server. multiStatements lets
+// a whole wl-commons script (USE, DML, DOLT_COMMIT) run in one round trip on
+// one connection, matching the single-session semantics of `dolt sql --file`.
+func (c *Config) poolDSN() string {
+	return fmt.Sprintf("%s@tcp(%s)/?multiStatements=true&timeout=5s", c.userDSN(), c.HostPort())
+}
+
+// pool returns the store's connection pool, opening it on first use.
+// sql.Open does not dial, so this never blocks on the server.
+func (w *WLCommons) pool() (*sql.DB, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.db != nil {
+		return w.db, nil
+	}
+
+	db, err := sql.Open("mysql", w.config.poolDSN())
+	if err != nil {
+		return nil, fmt.Errorf("opening wl-commons connection pool: %w", err)
+	}
+	db.SetMaxOpenConns(wlCommonsMaxOpenConns)
+	db.SetMaxIdleConns(wlCommonsMaxIdleConns)
+	db.SetConnMaxLifetime(wlCommonsConnMaxLifetime)
+	w.db = db
+	return db, nil
+}
+
+// Close releases the store's pooled connections. The store reopens the pool
+// if it is used again.
+func (w *WLCommons) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.db == nil {
+		return nil
+	}
+	err := w.db.Close()
+	w.db = nil
+	return err
+}
+
+// Ping checks that the configured server answers a trivial query. Unlike
+// EnsureDB it creates nothing and is allowed on read-only stores, so it is
+// cheap enough for liveness probes. ctx bounds how long a dead server can
+// stall the check.
+func (w *WLCommons) Ping(ctx context.Context) error {
+	db, err := w.pool()
+	if err != nil {
+		return err
+	}
+	var one int
+	if err := db.QueryRowContext(ctx, "SELECT 1").Scan(&one); err != nil {
+		return fmt.Errorf("pinging Dolt server at %s: %w", w.config.HostPort(), err)
+	}
+	return nil
+}
+
+// CommitNow Dolt-commits whatever is in the wl-commons working set, so
+// changes made outside the store's own write scripts (which commit as they
+// go) become durable immediately. A clean working set is not an error.
+func (w *WLCommons) CommitNow(ctx context.Context) error {
+	if w.readOnly {
+		return ErrReadOnly
+	}
+	db, err := w.pool()
+	if err != nil {
+		return err
+	}
+	// USE is per-session, so pin one connection for the commit.
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("connecting to commit %s: %w", WLCommonsDB, err)
+	}
+	defer conn.Close()
+
+	if _, err := conn.ExecContext(ctx, fmt.Sprintf("USE `%s`", WLCommonsDB)); err != nil {
+		return fmt.Errorf("selecting %s: %w", WLCommonsDB, err)
+	}
+	if _, err := conn.ExecContext(ctx, "CALL DOLT_ADD('-A')"); err != nil {
+		return fmt.Errorf("staging %s: %w", WLCommonsDB, err)
+	}
+	if _, err := conn.ExecContext(ctx, "CALL DOLT_COMMIT('-m', 'wl commit')"); err != nil && !isNothingToCommit(err) {
+		return fmt.Errorf("committing %s: %w", WLCommonsDB, err)
+	}
+	return nil
+}
+
+// execScript runs a multi-statement script on one pooled connection, with the
+// same retry policy as doltSQLScriptWithRetry.
+func (w *WLCommons) execScript(script string) error {
+	db, err := w.pool()
+	if err != nil {
+		return err
+	}
+	return retryDoltScript(func() error {
+		ctx, cancel := w.config.queryContext()
+		defer cancel()
+		_, err := db.ExecContext(ctx, script)
+		return w.config.timeoutError(ctx, err)
+	})
+}
+
+// queryWanted fetches a wanted item by ID over the pool.
+func (w *WLCommons) queryWanted(wantedID string) (*WantedItem, error) {
+	db, err := w.pool()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := w.config.queryContext()
+	defer cancel()
+
+	query := fmt.Sprintf("SELECT id, title, status, COALESCE(claimed_by, '') FROM `%s`.wanted WHERE id = ?", WLCommonsDB)
+	item := &WantedItem{}
+	err = db.QueryRowContext(ctx, query, wantedID).Scan(&item.ID, &item.Title, &item.Status, &item.ClaimedBy)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("wanted item %q not found", wantedID)
+	}
+	if err != nil {
+		return nil, w.config.timeoutError(ctx, fmt.Errorf("querying wanted item %q: %w", wantedID, err))
+	}
+	return item, nil
+}
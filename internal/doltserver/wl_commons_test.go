@@ -1,6 +1,7 @@
 package doltserver
 
 import (
+	"errors"
 	"strings"
 	"testing"
 )
@@ -134,6 +135,52 @@ func TestEscapeSQL_Backslashes(t *testing.T) {
 	}
 }
 
+func TestIsNothingToCommit(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"nil", nil, false},
+		{"current wording", errors.New("Error 1105 (HY000): nothing to commit"), true},
+		{"uppercase", errors.New("NOTHING TO COMMIT"), true},
+		{"git-style wording", errors.New("no changes added to commit (use \"dolt add\")"), true},
+		{"short wording", errors.New("error: no changes to commit"), true},
+		{"unrelated", errors.New("table not found: wanted"), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := isNothingToCommit(tt.err); got != tt.want {
+				t.Errorf("isNothingToCommit(%v) = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWLCommonsReadOnly_WritesRejected(t *testing.T) {
+	t.Parallel()
+	// No server is needed: writes must be refused before any connection.
+	store := NewWLCommonsReadOnly(t.TempDir())
+	defer store.Close()
+
+	writes := map[string]error{
+		"EnsureDB":         store.EnsureDB(),
+		"InsertWanted":     store.InsertWanted(&WantedItem{ID: "w-1", Title: "x"}),
+		"ClaimWanted":      store.ClaimWanted("w-1", "rig"),
+		"SubmitCompletion": store.SubmitCompletion("c-1", "w-1", "rig", "evidence"),
+	}
+	for name, err := range writes {
+		if !errors.Is(err, ErrReadOnly) {
+			t.Errorf("%s() = %v, want ErrReadOnly", name, err)
+		}
+	}
+	if store.db != nil {
+		t.Error("read-only writes opened a connection pool")
+	}
+}
+
 func TestGenerateWantedID_Format(t *testing.T) {
 	t.Parallel()
 	id := GenerateWantedID("Test Title")
@@ -164,3 +211,50 @@ func TestGenerateWantedID_Uniqueness(t *testing.T) {
 		seen[id] = true
 	}
 }
+
+func TestWLCommons_PoolIsReused(t *testing.T) {
+	t.Parallel()
+	store := NewWLCommons(t.TempDir())
+
+	first, err := store.pool()
+	if err != nil {
+		t.Fatalf("pool() error: %v", err)
+	}
+	second, err := store.pool()
+	if err != nil {
+		t.Fatalf("pool() error: %v", err)
+	}
+	if first != second {
+		t.Error("pool() opened a second *sql.DB; want the same pool reused")
+	}
+	if got := first.Stats().MaxOpenConnections; got != wlCommonsMaxOpenConns {
+		t.Errorf("MaxOpenConnections = %d, want %d", got, wlCommonsMaxOpenConns)
+	}
+
+	if err := store.Close(); err != nil {
+		t.Fatalf("Close() error: %v", err)
+	}
+	if err := store.Close(); err != nil {
+		t.Errorf("second Close() error: %v", err)
+	}
+	reopened, err := store.pool()
+	if err != nil {
+		t.Fatalf("pool() after Close error: %v", err)
+	}
+	if reopened == first {
+		t.Error("pool() after Close returned the closed pool")
+	}
+	_ = store.Close()
+}
+
+func TestConfigPoolDSN(t *testing.T) {
+	t.Parallel()
+	c := &Config{Host: "db.example", Port: 3307, User: "root", Password: "pw"}
+	dsn := c.poolDSN()
+	if !strings.HasPrefix(dsn, "root:pw@tcp(db.example:3307)/") {
+		t.Errorf("poolDSN() = %q, want user, password and host:port", dsn)
+	}
+	if !strings.Contains(dsn, "multiStatements=true") {
+		t.Errorf("poolDSN() = %q, want multiStatements enabled for scripts", dsn)
+	}
+}
@@ -0,0 +1,65 @@
+package hooks
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// maxSettingsBackups is how many settings.json backups are kept per file.
+// Older backups are deleted when a new one is taken.
+const maxSettingsBackups = 5
+
+// backupTimeFormat sorts lexically in chronological order.
+const backupTimeFormat = "20060102-150405.000000000"
+
+// BackupSettings copies the settings file at path to
+// <path>.bak.<timestamp> with 0600 permissions, so a hand-tuned file can be
+// recovered after it is overwritten. Only the most recent backups are kept.
+// Returns the backup path, or "" if there was no file to back up.
+func BackupSettings(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", nil
+		}
+		return "", fmt.Errorf("reading settings for backup: %w", err)
+	}
+
+	backupPath := path + ".bak." + time.Now().UTC().Format(backupTimeFormat)
+	if err := os.WriteFile(backupPath, data, 0600); err != nil {
+		return "", fmt.Errorf("writing settings backup: %w", err)
+	}
+
+	pruneSettingsBackups(path, maxSettingsBackups)
+	return backupPath, nil
+}
+
+// pruneSettingsBackups deletes all but the newest keep backups of path.
+// Best-effort: failures to list or remove are ignored.
+func pruneSettingsBackups(path string, keep int) {
+	dir := filepath.Dir(path)
+	prefix := filepath.Base(path) + ".bak."
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return
+	}
+	var backups []string
+	for _, e := range entries {
+		if !e.IsDir() && strings.HasPrefix(e.Name(), prefix) {
+			backups = append(backups, e.Name())
+		}
+	}
+	if len(backups) <= keep {
+		return
+	}
+
+	sort.Strings(backups)
+	for _, name := range backups[:len(backups)-keep] {
+		_ = os.Remove(filepath.Join(dir, name))
+	}
+}
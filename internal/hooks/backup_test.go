@@ -0,0 +1,90 @@
+package hooks
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestBackupSettings_NoFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "settings.json")
+
+	backup, err := BackupSettings(path)
+	if err != nil {
+		t.Fatalf("BackupSettings() error: %v", err)
+	}
+	if backup != "" {
+		t.Errorf("BackupSettings() = %q, want empty for missing file", backup)
+	}
+}
+
+func TestBackupSettings_MatchesOriginal(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "settings.json")
+	original := []byte("{\n  \"hooks\": {},\n  \"custom\": \"hand-tuned\"\n}\n")
+	if err := os.WriteFile(path, original, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	backup, err := BackupSettings(path)
+	if err != nil {
+		t.Fatalf("BackupSettings() error: %v", err)
+	}
+	if !strings.HasPrefix(backup, path+".bak.") {
+		t.Errorf("backup path = %q, want prefix %q", backup, path+".bak.")
+	}
+
+	// Overwrite the original, as a sync would
+	if err := os.WriteFile(path, []byte(`{"hooks":{}}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := os.ReadFile(backup)
+	if err != nil {
+		t.Fatalf("reading backup: %v", err)
+	}
+	if !bytes.Equal(got, original) {
+		t.Errorf("backup content = %q, want %q", got, original)
+	}
+
+	info, err := os.Stat(backup)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if perm := info.Mode().Perm(); perm != 0600 {
+		t.Errorf("backup perms = %o, want 0600", perm)
+	}
+}
+
+func TestBackupSettings_KeepsMostRecent(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "settings.json")
+	if err := os.WriteFile(path, []byte(`{}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	var backups []string
+	for i := 0; i < maxSettingsBackups+3; i++ {
+		backup, err := BackupSettings(path)
+		if err != nil {
+			t.Fatalf("BackupSettings() #%d error: %v", i, err)
+		}
+		backups = append(backups, backup)
+	}
+
+	matches, err := filepath.Glob(path + ".bak.*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(matches) != maxSettingsBackups {
+		t.Fatalf("got %d backups, want %d", len(matches), maxSettingsBackups)
+	}
+
+	// The newest backups survive
+	for _, b := range backups[len(backups)-maxSettingsBackups:] {
+		if _, err := os.Stat(b); err != nil {
+			t.Errorf("recent backup %s was pruned", filepath.Base(b))
+		}
+	}
+}
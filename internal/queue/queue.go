@@ -0,0 +1,311 @@
+// Package queue enqueues beads for deferred dispatch by the capacity scheduler.
+//
+// Enqueueing creates a sling context bead holding the scheduling parameters;
+// the work bead itself is never modified. The check-and-write sequence lives
+// here so the sling and convoy commands and other callers share it. Lookups
+// that belong to the command layer (bead info, rig registry, formulas,
+// convoys) are passed to New or set as callbacks on the Enqueuer.
+package queue
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/gofrs/flock"
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/events"
+	"github.com/steveyegge/gastown/internal/scheduler/capacity"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+// Options holds options for enqueueing a bead.
+type Options struct {
+	Formula     string        // Formula to apply at dispatch time (e.g., "mol-polecat-work")
+	Args        string        // Natural language args for executor
+	Vars        []string      // Formula variables (key=value)
+	Merge       string        // Merge strategy: direct/mr/local
+	BaseBranch  string        // Override base branch for polecat worktree
+	NoConvoy    bool          // Skip auto-convoy creation
+	Owned       bool          // Mark auto-convoy as caller-managed lifecycle
+	DryRun      bool          // Show what would be done without acting
+	Force       bool          // Force schedule even if bead is hooked/in_progress
+	NoMerge     bool          // Skip merge queue on completion
+	Account     string        // Claude Code account handle
+	Agent       string        // Agent override (e.g., "gemini", "codex")
+	HookRawBead bool          // Hook raw bead without default formula
+	Ralph       bool          // Ralph Wiggum loop mode
+	TTL         time.Duration // Drop the context if not dispatched within this long (0 = never)
+}
+
+// fields builds the sling context fields recorded for beadID on rig.
+func (o Options) fields(beadID, rig string, now time.Time) *capacity.SlingContextFields {
+	f := &capacity.SlingContextFields{
+		Version:     1,
+		WorkBeadID:  beadID,
+		TargetRig:   rig,
+		EnqueuedAt:  now.UTC().Format(time.RFC3339),
+		Formula:     o.Formula,
+		Args:        o.Args,
+		Merge:       o.Merge,
+		BaseBranch:  o.BaseBranch,
+		NoMerge:     o.NoMerge,
+		Account:     o.Account,
+		Agent:       o.Agent,
+		HookRawBead: o.HookRawBead,
+		Owned:       o.Owned,
+	}
+	if len(o.Vars) > 0 {
+		f.Vars = strings.Join(o.Vars, "\n")
+	}
+	if o.TTL > 0 {
+		f.TTL = o.TTL.String()
+	}
+	if o.Ralph {
+		f.Mode = "ralph"
+	}
+	return f
+}
+
+// BeadInfo is the subset of a work bead Enqueue inspects.
+type BeadInfo struct {
+	Title    string
+	Status   string
+	Assignee string
+}
+
+// Store persists sling context beads. Satisfied by *beads.Beads.
+type Store interface {
+	FindOpenSlingContext(workBeadID string) (*beads.Issue, *capacity.SlingContextFields, error)
+	CreateSlingContext(workBeadTitle, workBeadID string, fields *capacity.SlingContextFields) (*beads.Issue, error)
+	UpdateSlingContextFields(contextID string, fields *capacity.SlingContextFields) error
+}
+
+// Enqueuer schedules beads for deferred dispatch.
+// BeadInfo and IsRig are required; any other nil callback skips its step.
+type Enqueuer struct {
+	// TownRoot is the town to enqueue into. Empty = discover from cwd.
+	TownRoot string
+
+	// Store persists sling contexts. Nil = the town's beads database.
+	Store Store
+
+	// BeadInfo looks up the work bead. An error means it does not exist.
+	BeadInfo func(beadID string) (*BeadInfo, error)
+
+	// IsRig reports whether name is a known rig.
+	IsRig func(name string) bool
+
+	// CrossRigGuard rejects beads that belong to another rig. Skipped with Force.
+	CrossRigGuard func(beadID, rig, townRoot string) error
+
+	// VerifyFormula checks that a formula exists.
+	VerifyFormula func(name string) error
+
+	// CookFormula cooks a formula in the bead's hook directory.
+	CookFormula func(name, workDir, townRoot string) error
+
+	// TrackingConvoy returns the convoy already tracking beadID, or "".
+	TrackingConvoy func(beadID string) string
+
+	// CreateConvoy creates an auto-convoy tracking beadID and returns its ID.
+	CreateConvoy func(beadID, title string, owned bool, merge string) (string, error)
+
+	// Actor names who enqueued, for the event feed.
+	Actor func() string
+
+	// Now returns the enqueue time. Nil = time.Now.
+	Now func() time.Time
+
+	// Out receives progress output. Nil = os.Stdout.
+	Out io.Writer
+}
+
+// New creates an Enqueuer for townRoot (empty = discover from cwd) with its
+// required lookups. The optional steps are enabled by setting the remaining
+// callbacks on the result.
+func New(townRoot string, beadInfo func(beadID string) (*BeadInfo, error), isRig func(name string) bool) *Enqueuer {
+	return &Enqueuer{
+		TownRoot: townRoot,
+		BeadInfo: beadInfo,
+		IsRig:    isRig,
+	}
+}
+
+// Enqueue schedules beadID for deferred dispatch to rig.
+// Enqueueing a bead that already has an open sling context is a no-op.
+func (e *Enqueuer) Enqueue(beadID, rig string, opts Options) error {
+	if e.BeadInfo == nil || e.IsRig == nil {
+		return errors.New("queue: enqueuer is not configured")
+	}
+	out := e.Out
+	if out == nil {
+		out = os.Stdout
+	}
+
+	townRoot := e.TownRoot
+	if townRoot == "" {
+		var err error
+		if townRoot, err = workspace.FindFromCwdOrError(); err != nil {
+			return err
+		}
+	}
+
+	info, err := e.BeadInfo(beadID)
+	if err != nil {
+		return fmt.Errorf("bead '%s' not found: %w", beadID, err)
+	}
+
+	if !e.IsRig(rig) {
+		return fmt.Errorf("'%s' is not a known rig", rig)
+	}
+
+	if !opts.Force && e.CrossRigGuard != nil {
+		if err := e.CrossRigGuard(beadID, rig, townRoot); err != nil {
+			return err
+		}
+	}
+
+	// Idempotency: check for existing open sling context for this work bead.
+	// Fail fast on errors to avoid creating duplicate contexts on transient DB failures.
+	store := e.Store
+	if store == nil {
+		store = beads.NewWithBeadsDir(townRoot, filepath.Join(townRoot, ".beads"))
+	}
+	if scheduled, err := alreadyScheduled(store, beadID, out); err != nil || scheduled {
+		return err
+	}
+
+	if (info.Status == "pinned" || info.Status == "hooked" || info.Status == "in_progress") && !opts.Force {
+		return fmt.Errorf("bead %s is already %s to %s\nUse --force to override", beadID, info.Status, info.Assignee)
+	}
+
+	if opts.Formula != "" && e.VerifyFormula != nil {
+		if err := e.VerifyFormula(opts.Formula); err != nil {
+			return fmt.Errorf("formula %q not found: %w", opts.Formula, err)
+		}
+	}
+
+	if opts.DryRun {
+		fmt.Fprintf(out, "Would schedule %s → %s\n", beadID, rig)
+		fmt.Fprintf(out, "  Would create sling context bead\n")
+		if !opts.NoConvoy {
+			fmt.Fprintf(out, "  Would create auto-convoy\n")
+		}
+		return nil
+	}
+
+	// Cook formula after dry-run check to avoid side effects
+	if opts.Formula != "" && e.CookFormula != nil {
+		workDir := beads.ResolveHookDir(townRoot, beadID, "")
+		if err := e.CookFormula(opts.Formula, workDir, townRoot); err != nil {
+			return fmt.Errorf("formula %q failed to cook: %w", opts.Formula, err)
+		}
+	}
+
+	now := time.Now
+	if e.Now != nil {
+		now = e.Now
+	}
+	fields := opts.fields(beadID, rig, now())
+
+	// Re-check and write under the dispatch lock: another operator may have
+	// scheduled the bead while we verified and cooked the formula, and without
+	// the lock two enqueues could both pass the check before either writes.
+	lock, err := lockDispatch(townRoot, out)
+	if err != nil {
+		return err
+	}
+	ctxBead, err := createSlingContext(store, info.Title, beadID, fields, out)
+	_ = lock.Unlock()
+	if err != nil || ctxBead == nil {
+		return err
+	}
+
+	if !opts.NoConvoy && e.CreateConvoy != nil {
+		existingConvoy := ""
+		if e.TrackingConvoy != nil {
+			existingConvoy = e.TrackingConvoy(beadID)
+		}
+		if existingConvoy == "" {
+			convoyID, err := e.CreateConvoy(beadID, info.Title, opts.Owned, opts.Merge)
+			if err != nil {
+				fmt.Fprintf(out, "%s Could not create auto-convoy: %v\n", style.Dim.Render("Warning:"), err)
+			} else {
+				fmt.Fprintf(out, "%s Created convoy %s\n", style.Bold.Render("→"), convoyID)
+				// Update the context bead fields with convoy ID
+				fields.Convoy = convoyID
+				if updateErr := store.UpdateSlingContextFields(ctxBead.ID, fields); updateErr != nil {
+					fmt.Fprintf(out, "%s Could not update context with convoy: %v\n", style.Dim.Render("Warning:"), updateErr)
+				}
+			}
+		} else {
+			fmt.Fprintf(out, "%s Already tracked by convoy %s\n", style.Dim.Render("○"), existingConvoy)
+		}
+	}
+
+	actor := ""
+	if e.Actor != nil {
+		actor = e.Actor()
+	}
+	_ = events.LogFeed(events.TypeSchedulerEnqueue, actor, events.SchedulerEnqueuePayload(beadID, rig))
+
+	fmt.Fprintf(out, "%s Scheduled %s → %s (context: %s)\n", style.Bold.Render("✓"), beadID, rig, ctxBead.ID)
+	return nil
+}
+
+// lockDispatch takes the scheduler's dispatch lock for townRoot, waiting for
+// a running dispatch or enqueue to release it.
+func lockDispatch(townRoot string, out io.Writer) (*flock.Flock, error) {
+	path := capacity.DispatchLockFile(townRoot)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, fmt.Errorf("creating runtime directory: %w", err)
+	}
+	lock := flock.New(path)
+	locked, err := lock.TryLock()
+	if err != nil {
+		return nil, fmt.Errorf("acquiring dispatch lock: %w", err)
+	}
+	if !locked {
+		fmt.Fprintf(out, "%s Waiting for the scheduler dispatch lock...\n", style.Dim.Render("○"))
+		if err := lock.Lock(); err != nil {
+			return nil, fmt.Errorf("acquiring dispatch lock: %w", err)
+		}
+	}
+	return lock, nil
+}
+
+// createSlingContext creates the sling context bead unless beadID already
+// has one, in which case it returns nil. Callers hold the dispatch lock.
+func createSlingContext(store Store, title, beadID string, fields *capacity.SlingContextFields, out io.Writer) (*beads.Issue, error) {
+	if scheduled, err := alreadyScheduled(store, beadID, out); err != nil || scheduled {
+		return nil, err
+	}
+	// Single atomic operation. No two-step write.
+	ctxBead, err := store.CreateSlingContext(title, beadID, fields)
+	if err != nil {
+		return nil, fmt.Errorf("creating sling context: %w", err)
+	}
+	return ctxBead, nil
+}
+
+// alreadyScheduled reports whether beadID has an open sling context, printing
+// the no-op notice if so. Errors fail fast so a transient DB failure cannot
+// lead to a duplicate context.
+func alreadyScheduled(store Store, beadID string, out io.Writer) (bool, error) {
+	existingCtx, _, err := store.FindOpenSlingContext(beadID)
+	if err != nil {
+		return false, fmt.Errorf("checking for existing sling context: %w", err)
+	}
+	if existingCtx == nil {
+		return false, nil
+	}
+	fmt.Fprintf(out, "%s Bead %s is already scheduled (context: %s), no-op\n",
+		style.Dim.Render("○"), beadID, existingCtx.ID)
+	return true, nil
+}
@@ -0,0 +1,259 @@
+package queue
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gofrs/flock"
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/scheduler/capacity"
+)
+
+type fakeStore struct {
+	// onCreate, if set, runs when CreateSlingContext is called.
+	onCreate func()
+	existing *beads.Issue
+	// existingAfter, when > 0, makes existing visible only from that
+	// FindOpenSlingContext call on, simulating a concurrent enqueue.
+	existingAfter int
+	finds         int
+	created       []*capacity.SlingContextFields
+	updated       []*capacity.SlingContextFields
+}
+
+func (s *fakeStore) FindOpenSlingContext(string) (*beads.Issue, *capacity.SlingContextFields, error) {
+	s.finds++
+	if s.finds < s.existingAfter {
+		return nil, nil, nil
+	}
+	return s.existing, nil, nil
+}
+
+func (s *fakeStore) CreateSlingContext(_, _ string, fields *capacity.SlingContextFields) (*beads.Issue, error) {
+	if s.onCreate != nil {
+		s.onCreate()
+	}
+	cp := *fields
+	s.created = append(s.created, &cp)
+	return &beads.Issue{ID: "hq-ctx1"}, nil
+}
+
+func (s *fakeStore) UpdateSlingContextFields(_ string, fields *capacity.SlingContextFields) error {
+	cp := *fields
+	s.updated = append(s.updated, &cp)
+	return nil
+}
+
+// calls records which callbacks an Enqueue run invoked.
+type calls struct {
+	guard, verify, cook, convoy int
+}
+
+func newTestEnqueuer(t *testing.T, status string) (*Enqueuer, *fakeStore, *calls) {
+	t.Helper()
+	t.Chdir(t.TempDir()) // keep the event feed out of any real town
+	store := &fakeStore{}
+	c := &calls{}
+	e := &Enqueuer{
+		TownRoot: t.TempDir(),
+		Store:    store,
+		BeadInfo: func(string) (*BeadInfo, error) {
+			return &BeadInfo{Title: "Fix it", Status: status, Assignee: "gt/polecats/nux"}, nil
+		},
+		IsRig: func(name string) bool { return name == "gastown" },
+		CrossRigGuard: func(string, string, string) error {
+			c.guard++
+			return errors.New("cross-rig")
+		},
+		VerifyFormula: func(string) error { c.verify++; return nil },
+		CookFormula:   func(string, string, string) error { c.cook++; return nil },
+		CreateConvoy: func(string, string, bool, string) (string, error) {
+			c.convoy++
+			return "hq-cv-1", nil
+		},
+		Now: func() time.Time { return time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC) },
+		Out: io.Discard,
+	}
+	return e, store, c
+}
+
+func TestEnqueue_NoConvoy(t *testing.T) {
+	e, store, c := newTestEnqueuer(t, "open")
+	e.CrossRigGuard = nil
+
+	if err := e.Enqueue("gt-abc", "gastown", Options{NoConvoy: true}); err != nil {
+		t.Fatalf("Enqueue: %v", err)
+	}
+	if c.convoy != 0 {
+		t.Errorf("CreateConvoy called %d times with NoConvoy", c.convoy)
+	}
+	if len(store.created) != 1 || len(store.updated) != 0 {
+		t.Fatalf("created=%d updated=%d, want 1 and 0", len(store.created), len(store.updated))
+	}
+
+	if err := e.Enqueue("gt-abc", "gastown", Options{}); err != nil {
+		t.Fatalf("Enqueue: %v", err)
+	}
+	if c.convoy != 1 {
+		t.Errorf("CreateConvoy called %d times without NoConvoy, want 1", c.convoy)
+	}
+	if len(store.updated) != 1 || store.updated[0].Convoy != "hq-cv-1" {
+		t.Errorf("context not updated with convoy: %+v", store.updated)
+	}
+}
+
+func TestEnqueue_Force(t *testing.T) {
+	e, store, c := newTestEnqueuer(t, "hooked")
+
+	err := e.Enqueue("gt-abc", "gastown", Options{NoConvoy: true})
+	if err == nil || err.Error() != "cross-rig" {
+		t.Fatalf("without Force: err = %v, want cross-rig guard error", err)
+	}
+
+	e.CrossRigGuard = nil
+	err = e.Enqueue("gt-abc", "gastown", Options{NoConvoy: true})
+	if err == nil || !strings.Contains(err.Error(), "already hooked") {
+		t.Fatalf("without Force: err = %v, want already-hooked error", err)
+	}
+	if len(store.created) != 0 {
+		t.Fatalf("context created without Force")
+	}
+
+	e.CrossRigGuard = func(string, string, string) error { c.guard++; return errors.New("cross-rig") }
+	if err := e.Enqueue("gt-abc", "gastown", Options{NoConvoy: true, Force: true}); err != nil {
+		t.Fatalf("with Force: %v", err)
+	}
+	if c.guard != 1 {
+		t.Errorf("CrossRigGuard called %d times, want 1 (skipped with Force)", c.guard)
+	}
+	if len(store.created) != 1 {
+		t.Errorf("created %d contexts with Force, want 1", len(store.created))
+	}
+}
+
+func TestEnqueue_Formula(t *testing.T) {
+	e, store, c := newTestEnqueuer(t, "open")
+	e.CrossRigGuard = nil
+
+	if err := e.Enqueue("gt-abc", "gastown", Options{NoConvoy: true, Formula: "mol-polecat-work", DryRun: true}); err != nil {
+		t.Fatalf("dry run: %v", err)
+	}
+	if c.verify != 1 || c.cook != 0 || len(store.created) != 0 {
+		t.Fatalf("dry run: verify=%d cook=%d created=%d, want 1 0 0", c.verify, c.cook, len(store.created))
+	}
+
+	if err := e.Enqueue("gt-abc", "gastown", Options{NoConvoy: true, Formula: "mol-polecat-work"}); err != nil {
+		t.Fatalf("Enqueue: %v", err)
+	}
+	if c.verify != 2 || c.cook != 1 {
+		t.Errorf("verify=%d cook=%d, want 2 1", c.verify, c.cook)
+	}
+	if got := store.created[0].Formula; got != "mol-polecat-work" {
+		t.Errorf("Formula = %q, want mol-polecat-work", got)
+	}
+
+	e.VerifyFormula = func(string) error { return errors.New("missing") }
+	err := e.Enqueue("gt-abc", "gastown", Options{NoConvoy: true, Formula: "nope"})
+	if err == nil || !strings.Contains(err.Error(), `formula "nope" not found`) {
+		t.Errorf("err = %v, want formula not found", err)
+	}
+}
+
+func TestEnqueue_AlreadyScheduled(t *testing.T) {
+	e, store, _ := newTestEnqueuer(t, "open")
+	e.CrossRigGuard = nil
+	store.existing = &beads.Issue{ID: "hq-ctx0"}
+
+	if err := e.Enqueue("gt-abc", "gastown", Options{}); err != nil {
+		t.Fatalf("Enqueue: %v", err)
+	}
+	if len(store.created) != 0 {
+		t.Errorf("created %d contexts for an already scheduled bead", len(store.created))
+	}
+}
+
+func TestEnqueue_ScheduledConcurrently(t *testing.T) {
+	e, store, c := newTestEnqueuer(t, "open")
+	e.CrossRigGuard = nil
+	store.existing = &beads.Issue{ID: "hq-ctx0"}
+	store.existingAfter = 2 // first check sees nothing; the pre-write re-check sees the context
+
+	if err := e.Enqueue("gt-abc", "gastown", Options{Formula: "mol-polecat-work"}); err != nil {
+		t.Fatalf("Enqueue: %v, want no-op success", err)
+	}
+	if store.finds != 2 {
+		t.Errorf("FindOpenSlingContext called %d times, want 2", store.finds)
+	}
+	if len(store.created) != 0 || c.convoy != 0 {
+		t.Errorf("created=%d convoys=%d, want no writes for an already scheduled bead", len(store.created), c.convoy)
+	}
+}
+
+func TestEnqueue_HoldsDispatchLockWhileCreating(t *testing.T) {
+	e, store, _ := newTestEnqueuer(t, "open")
+	e.CrossRigGuard = nil
+	lockPath := capacity.DispatchLockFile(e.TownRoot)
+	heldDuringCreate := false
+	store.onCreate = func() {
+		locked, err := flock.New(lockPath).TryLock()
+		heldDuringCreate = err == nil && !locked
+	}
+
+	if err := e.Enqueue("gt-abc", "gastown", Options{NoConvoy: true}); err != nil {
+		t.Fatalf("Enqueue: %v", err)
+	}
+	if !heldDuringCreate {
+		t.Error("dispatch lock was not held while creating the sling context")
+	}
+
+	other := flock.New(lockPath)
+	if locked, err := other.TryLock(); err != nil || !locked {
+		t.Errorf("dispatch lock still held after Enqueue (locked=%v, err=%v)", locked, err)
+	}
+	_ = other.Unlock()
+}
+
+func TestEnqueue_Unconfigured(t *testing.T) {
+	if err := (&Enqueuer{}).Enqueue("gt-abc", "gastown", Options{}); err == nil {
+		t.Error("expected error from unconfigured Enqueuer")
+	}
+}
+
+func TestNew_BeadNotFound(t *testing.T) {
+	t.Chdir(t.TempDir())
+	lookupErr := errors.New("bd show failed")
+	e := New(t.TempDir(),
+		func(string) (*BeadInfo, error) { return nil, lookupErr },
+		func(string) bool { return true },
+	)
+	err := e.Enqueue("gt-abc", "gastown", Options{})
+	if !errors.Is(err, lookupErr) {
+		t.Errorf("Enqueue() = %v, want it to wrap the lookup error", err)
+	}
+}
+
+func TestOptionsFields(t *testing.T) {
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.FixedZone("X", 3600))
+	f := Options{
+		Vars:  []string{"a=1", "b=2"},
+		TTL:   90 * time.Minute,
+		Ralph: true,
+		Owned: true,
+	}.fields("gt-abc", "gastown", now)
+
+	if f.Version != 1 || f.WorkBeadID != "gt-abc" || f.TargetRig != "gastown" {
+		t.Errorf("identity fields = %+v", f)
+	}
+	if f.EnqueuedAt != "2026-01-02T02:04:05Z" {
+		t.Errorf("EnqueuedAt = %q", f.EnqueuedAt)
+	}
+	if f.Vars != "a=1\nb=2" || f.TTL != "1h30m0s" || f.Mode != "ralph" || !f.Owned {
+		t.Errorf("fields = %+v", f)
+	}
+	if g := (Options{}).fields("gt-abc", "gastown", now); g.TTL != "" || g.Mode != "" || g.Vars != "" {
+		t.Errorf("zero options fields = %+v", g)
+	}
+}
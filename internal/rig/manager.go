@@ -1135,16 +1135,16 @@ func bdDatabaseExists(beadsDir string) bool {
 		return true // Can't parse — assume it exists (backward compat)
 	}
 
-	// For server mode, verify the database exists in .dolt-data/.
-	// metadata.json may be tracked in git from another workspace where
-	// the Dolt server had this database, but this is a fresh server.
+	// For server mode, verify the database exists in the town's Dolt data
+	// directory. metadata.json may be tracked in git from another workspace
+	// where the Dolt server had this database, but this is a fresh server.
 	if meta.DoltMode == "server" && meta.DoltDatabase != "" {
-		// Walk up from beadsDir to find the town root (.dolt-data lives there).
+		// Walk up from beadsDir to find the town root (the data dir's owner).
 		townRoot := beads.FindTownRoot(filepath.Dir(beadsDir))
 		if townRoot == "" {
 			return true // Can't find town root — assume it exists
 		}
-		dbDir := filepath.Join(townRoot, ".dolt-data", meta.DoltDatabase)
+		dbDir := doltserver.RigDatabaseDir(townRoot, meta.DoltDatabase)
 		if _, err := os.Stat(dbDir); os.IsNotExist(err) {
 			return false // Database doesn't exist on this server
 		}
@@ -1278,3 +1278,36 @@ func TestEnsureMetadata_SetsRequiredFields(t *testing.T) {
 		}
 	}
 }
+
+func TestBdDatabaseExists_CustomDataDir(t *testing.T) {
+	townRoot := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(townRoot, "mayor"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(townRoot, "mayor", "town.json"), []byte("{}"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	dataDir := filepath.Join(t.TempDir(), "shared-dolt")
+	if err := doltserver.SetDataDir(townRoot, dataDir); err != nil {
+		t.Fatalf("SetDataDir: %v", err)
+	}
+
+	beadsDir := filepath.Join(townRoot, "myrig", ".beads")
+	if err := os.MkdirAll(beadsDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	meta := `{"dolt_mode":"server","dolt_database":"myrig"}`
+	if err := os.WriteFile(filepath.Join(beadsDir, "metadata.json"), []byte(meta), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if bdDatabaseExists(beadsDir) {
+		t.Fatal("bdDatabaseExists = true before the database was created")
+	}
+	if err := os.MkdirAll(filepath.Join(dataDir, "myrig"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if !bdDatabaseExists(beadsDir) {
+		t.Error("bdDatabaseExists = false for a database in the custom data dir")
+	}
+}
@@ -1,6 +1,9 @@
 package capacity
 
-import "strings"
+import (
+	"strings"
+	"time"
+)
 
 // PendingBead represents a bead that is scheduled and ready for dispatch evaluation.
 type PendingBead struct {
@@ -23,6 +26,7 @@ type SlingContextFields struct {
 	Args             string `json:"args,omitempty"`
 	Vars             string `json:"vars,omitempty"`
 	EnqueuedAt       string `json:"enqueued_at"`
+	TTL              string `json:"ttl,omitempty"` // Go duration; empty = never expires
 	Merge            string `json:"merge,omitempty"`
 	Convoy           string `json:"convoy,omitempty"`
 	BaseBranch       string `json:"base_branch,omitempty"`
@@ -36,6 +40,24 @@ type SlingContextFields struct {
 	LastFailure      string `json:"last_failure,omitempty"`
 }
 
+// Expired reports whether the context's TTL has elapsed since EnqueuedAt.
+// Contexts without a TTL or a parseable timestamp (including legacy contexts
+// written before TTLs existed) never expire.
+func (f *SlingContextFields) Expired(now time.Time) bool {
+	if f.TTL == "" || f.EnqueuedAt == "" {
+		return false
+	}
+	ttl, err := time.ParseDuration(f.TTL)
+	if err != nil || ttl <= 0 {
+		return false
+	}
+	enqueued, err := time.Parse(time.RFC3339, f.EnqueuedAt)
+	if err != nil {
+		return false
+	}
+	return now.Sub(enqueued) >= ttl
+}
+
 // LabelSlingContext is the label used to identify sling context beads.
 const LabelSlingContext = "gt:sling-context"
 
@@ -2,6 +2,7 @@ package capacity
 
 import (
 	"testing"
+	"time"
 )
 
 func TestPlanDispatch(t *testing.T) {
@@ -262,3 +263,29 @@ func TestSplitVars(t *testing.T) {
 		})
 	}
 }
+
+func TestSlingContextFields_Expired(t *testing.T) {
+	now := time.Date(2026, 1, 10, 12, 0, 0, 0, time.UTC)
+	enqueued := func(ago time.Duration) string {
+		return now.Add(-ago).Format(time.RFC3339)
+	}
+
+	tests := []struct {
+		name   string
+		fields SlingContextFields
+		want   bool
+	}{
+		{"fresh", SlingContextFields{EnqueuedAt: enqueued(time.Hour), TTL: "24h"}, false},
+		{"expired", SlingContextFields{EnqueuedAt: enqueued(72 * time.Hour), TTL: "24h"}, true},
+		{"legacy no ttl", SlingContextFields{EnqueuedAt: enqueued(720 * time.Hour)}, false},
+		{"legacy no timestamp", SlingContextFields{TTL: "1h"}, false},
+		{"unparseable ttl", SlingContextFields{EnqueuedAt: enqueued(72 * time.Hour), TTL: "soon"}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.fields.Expired(now); got != tt.want {
+				t.Errorf("Expired() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -28,6 +28,12 @@ func legacyStateFile(townRoot string) string {
 	return filepath.Join(townRoot, ".runtime", "queue-state.json")
 }
 
+// DispatchLockFile returns the path of the flock that serializes dispatch
+// runs and enqueues, so a bead can't end up with two open sling contexts.
+func DispatchLockFile(townRoot string) string {
+	return filepath.Join(townRoot, ".runtime", "scheduler-dispatch.lock")
+}
+
 // LoadState loads the scheduler runtime state, returning a zero-value state if the file
 // doesn't exist. This is intentional: absence means "not paused, never dispatched."
 // Falls back to reading the legacy queue-state.json if the new file doesn't exist.
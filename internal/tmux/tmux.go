@@ -42,6 +42,8 @@ var (
 	ErrNoServer            = errors.New("no tmux server running")
 	ErrSessionExists       = errors.New("session already exists")
 	ErrSessionNotFound     = errors.New("session not found")
+	ErrPaneNotFound        = errors.New("pane not found")
+	ErrNotGastownSession   = errors.New("not a Gas Town session")
 	ErrInvalidSessionName  = errors.New("invalid session name")
 	ErrIdleTimeout         = errors.New("agent not idle before timeout")
 )
@@ -101,6 +103,9 @@ func (t *Tmux) wrapError(err error, stderr string, args []string) error {
 		strings.Contains(stderr, "can't find session") {
 		return ErrSessionNotFound
 	}
+	if strings.Contains(stderr, "can't find pane") {
+		return ErrPaneNotFound
+	}
 
 	if stderr != "" {
 		return fmt.Errorf("tmux %s: %s", args[0], stderr)
@@ -231,6 +236,29 @@ func (t *Tmux) KillSession(name string) (retErr error) {
 	return retErr
 }
 
+// KillSessionGuarded terminates a tmux session like KillSession, but for
+// callers that must know what happened:
+//   - it matches name exactly, so "gt-deacon" never kills "gt-deacon-boot";
+//   - it returns an error wrapping ErrSessionNotFound if the session is
+//     already gone (including when no tmux server is running);
+//   - it refuses, with ErrNotGastownSession, to kill a session that
+//     isGTSession (e.g. session.IsKnownSession) doesn't recognize, unless
+//     allowNonGastown is set.
+//
+// The predicate is a parameter to avoid an import cycle from tmux → session.
+func (t *Tmux) KillSessionGuarded(name string, isGTSession func(string) bool, allowNonGastown bool) (retErr error) {
+	if !allowNonGastown && (isGTSession == nil || !isGTSession(name)) {
+		return fmt.Errorf("refusing to kill %q: %w", name, ErrNotGastownSession)
+	}
+
+	defer func() { telemetry.RecordSessionStop(context.Background(), name, retErr) }()
+	_, err := t.run("kill-session", "-t", "="+name)
+	if errors.Is(err, ErrSessionNotFound) || errors.Is(err, ErrNoServer) {
+		return fmt.Errorf("killing %s: %w", name, ErrSessionNotFound)
+	}
+	return err
+}
+
 // processKillGracePeriod is how long to wait after SIGTERM before sending SIGKILL.
 // 2 seconds gives processes time to clean up gracefully. The previous 100ms was too short
 // and caused Claude processes to become orphans when they couldn't shut down in time.
@@ -645,6 +673,25 @@ func (t *Tmux) ListSessions() ([]string, error) {
 	return strings.Split(out, "\n"), nil
 }
 
+// ListGastownSessions returns the names of all Gas Town sessions, sorted.
+// The isGTSession predicate identifies Gas Town sessions (e.g.
+// session.IsKnownSession); it is a parameter to avoid an import cycle from
+// tmux → session. With no tmux server running, it returns an empty slice.
+func (t *Tmux) ListGastownSessions(isGTSession func(string) bool) ([]string, error) {
+	all, err := t.ListSessions()
+	if err != nil {
+		return nil, err
+	}
+	sessions := []string{}
+	for _, name := range all {
+		if isGTSession(name) {
+			sessions = append(sessions, name)
+		}
+	}
+	sort.Strings(sessions)
+	return sessions, nil
+}
+
 // SessionSet provides O(1) session existence checks by caching session names.
 // Use this when you need to check multiple sessions to avoid N+1 subprocess calls.
 type SessionSet struct {
@@ -1415,10 +1462,17 @@ func (t *Tmux) FindSessionByWorkDir(targetDir string, processNames []string) ([]
 	return matches, nil
 }
 
-// CapturePane captures the visible content of a pane.
-func (t *Tmux) CapturePane(session string, lines int) (string, error) {
-	content, err := t.run("capture-pane", "-p", "-t", session, "-S", fmt.Sprintf("-%d", lines))
-	telemetry.RecordPaneRead(context.Background(), session, lines, len(content), err)
+// CapturePane captures the last lines of a pane's content, for example to
+// show what a dead agent printed in an error message. pane is any tmux target
+// (a session name or a pane ID such as "%5"). If the pane or its session no
+// longer exists, the error wraps ErrPaneNotFound or ErrSessionNotFound and
+// names the pane.
+func (t *Tmux) CapturePane(pane string, lines int) (string, error) {
+	content, err := t.run("capture-pane", "-p", "-t", pane, "-S", fmt.Sprintf("-%d", lines))
+	telemetry.RecordPaneRead(context.Background(), pane, lines, len(content), err)
+	if errors.Is(err, ErrPaneNotFound) || errors.Is(err, ErrSessionNotFound) {
+		return "", fmt.Errorf("capturing pane %s: %w", pane, err)
+	}
 	return content, err
 }
 
@@ -1458,12 +1512,19 @@ func (t *Tmux) SetEnvironment(session, key, value string) error {
 	return err
 }
 
+// errEnvUnset is returned (wrapped) by GetEnvironment when the session has
+// marked the variable unset (tmux prints "-KEY").
+var errEnvUnset = errors.New("variable is unset")
+
 // GetEnvironment gets an environment variable from the session.
 func (t *Tmux) GetEnvironment(session, key string) (string, error) {
 	out, err := t.run("show-environment", "-t", session, key)
 	if err != nil {
 		return "", err
 	}
+	if out == "-"+key {
+		return "", fmt.Errorf("%s: %w", key, errEnvUnset)
+	}
 	// Output format: KEY=value
 	parts := strings.SplitN(out, "=", 2)
 	if len(parts) != 2 {
@@ -1472,6 +1533,18 @@ func (t *Tmux) GetEnvironment(session, key string) (string, error) {
 	return parts[1], nil
 }
 
+// SessionEnv returns the value of name in a running session's environment
+// (tmux show-environment). A variable the session doesn't set, or has marked
+// unset, yields "" rather than an error, so callers can read agent identity
+// (GT_RIG, GT_CREW, ...) without depending on their own process env.
+func (t *Tmux) SessionEnv(session, name string) (string, error) {
+	value, err := t.GetEnvironment(session, name)
+	if err != nil && (errors.Is(err, errEnvUnset) || strings.Contains(err.Error(), "unknown variable")) {
+		return "", nil
+	}
+	return value, err
+}
+
 // GetAllEnvironment returns all environment variables for a session.
 func (t *Tmux) GetAllEnvironment(session string) (map[string]string, error) {
 	out, err := t.run("show-environment", "-t", session)
@@ -1667,6 +1740,36 @@ func (t *Tmux) WaitForCommand(session string, excludeCommands []string, timeout
 	return fmt.Errorf("timeout waiting for command (still running excluded command)")
 }
 
+// sessionChecker is the part of Tmux that WaitForSession polls.
+type sessionChecker interface {
+	HasSession(name string) (bool, error)
+}
+
+// WaitForSession polls HasSession until the session exists or timeout
+// elapses. Use it instead of a single HasSession check right after a
+// respawn, when the session can briefly be missing. On timeout the error
+// wraps ErrSessionNotFound.
+func (t *Tmux) WaitForSession(name string, timeout time.Duration) error {
+	return waitForSession(t, name, timeout, constants.PollInterval)
+}
+
+func waitForSession(c sessionChecker, name string, timeout, poll time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	for {
+		exists, err := c.HasSession(name)
+		if err != nil {
+			return fmt.Errorf("checking session %s: %w", name, err)
+		}
+		if exists {
+			return nil
+		}
+		if !time.Now().Before(deadline) {
+			return fmt.Errorf("%s did not appear within %s: %w", name, timeout, ErrSessionNotFound)
+		}
+		time.Sleep(poll)
+	}
+}
+
 // WaitForShellReady polls until the pane is running a shell command.
 // Useful for waiting until a process has exited and returned to shell.
 func (t *Tmux) WaitForShellReady(session string, timeout time.Duration) error {
@@ -2031,13 +2134,41 @@ func (t *Tmux) RespawnPane(pane, command string) error {
 // in the specified working directory. Use this when the pane's current working
 // directory may have been deleted.
 func (t *Tmux) RespawnPaneWithWorkDir(pane, workDir, command string) error {
+	_, err := t.run(respawnPaneArgs(pane, workDir, command, nil)...)
+	return err
+}
+
+// RespawnPaneWithEnv is like RespawnPane, but also sets env in the new
+// process via respawn-pane -e, so a recycled agent keeps the GT_* variables
+// that identify it even if the restart command doesn't export them.
+func (t *Tmux) RespawnPaneWithEnv(pane, command string, env map[string]string) error {
+	_, err := t.run(respawnPaneArgs(pane, "", command, env)...)
+	return err
+}
+
+// RespawnPaneWithWorkDirAndEnv combines RespawnPaneWithWorkDir and
+// RespawnPaneWithEnv: the new command starts in workDir with env set.
+func (t *Tmux) RespawnPaneWithWorkDirAndEnv(pane, workDir, command string, env map[string]string) error {
+	_, err := t.run(respawnPaneArgs(pane, workDir, command, env)...)
+	return err
+}
+
+// respawnPaneArgs builds the respawn-pane arguments. Environment variables
+// are passed in sorted order so the command is deterministic.
+func respawnPaneArgs(pane, workDir, command string, env map[string]string) []string {
 	args := []string{"respawn-pane", "-k", "-t", pane}
 	if workDir != "" {
 		args = append(args, "-c", workDir)
 	}
-	args = append(args, command)
-	_, err := t.run(args...)
-	return err
+	names := make([]string, 0, len(env))
+	for name := range env {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		args = append(args, "-e", name+"="+env[name])
+	}
+	return append(args, command)
 }
 
 // ClearHistory clears the scrollback history buffer for a pane.
@@ -2061,6 +2192,17 @@ func (t *Tmux) SetRemainOnExit(pane string, on bool) error {
 	return err
 }
 
+// IsPaneDead reports whether the process in a pane has exited. Only
+// meaningful for panes with remain-on-exit set; otherwise a dead pane is
+// destroyed and this returns an error.
+func (t *Tmux) IsPaneDead(pane string) (bool, error) {
+	out, err := t.run("display-message", "-t", pane, "-p", "#{pane_dead}")
+	if err != nil {
+		return false, err
+	}
+	return strings.TrimSpace(out) == "1", nil
+}
+
 // SwitchClient switches the current tmux client to a different session.
 // Used after remote recycle to move the user's view to the recycled session.
 func (t *Tmux) SwitchClient(targetSession string) error {
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
+	"path/filepath"
 	"runtime"
 	"strings"
 	"testing"
@@ -206,6 +207,7 @@ func TestWrapError(t *testing.T) {
 		{"duplicate session: test", ErrSessionExists},
 		{"session not found: test", ErrSessionNotFound},
 		{"can't find session: test", ErrSessionNotFound},
+		{"can't find pane: %99", ErrPaneNotFound},
 	}
 
 	for _, tt := range tests {
@@ -2267,3 +2269,221 @@ func TestCheckSessionHealth_ActivityCheck(t *testing.T) {
 	// (if the agent were actually running). This tests the activity threshold logic
 	// without needing a real Claude process.
 }
+
+// installFakeTmux puts a tmux script on PATH that prints stdout and stderr
+// and exits with code. It records its arguments in the returned file.
+func installFakeTmux(t *testing.T, stdout, stderr string, code int) string {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("fake tmux script requires a POSIX shell")
+	}
+	dir := t.TempDir()
+	argsFile := filepath.Join(dir, "args")
+	for name, content := range map[string]string{"stdout": stdout, "stderr": stderr} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	script := fmt.Sprintf("#!/bin/sh\necho \"$@\" > '%[1]s/args'\ncat '%[1]s/stdout'\ncat '%[1]s/stderr' >&2\nexit %[2]d\n", dir, code)
+	if err := os.WriteFile(filepath.Join(dir, "tmux"), []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+	return argsFile
+}
+
+func TestCapturePane_FakeTmux(t *testing.T) {
+	argsFile := installFakeTmux(t, "line one\nline two\n", "", 0)
+
+	out, err := NewTmux().CapturePane("%5", 20)
+	if err != nil {
+		t.Fatalf("CapturePane: %v", err)
+	}
+	if out != "line one\nline two" {
+		t.Errorf("CapturePane = %q, want the canned pane content", out)
+	}
+	args, err := os.ReadFile(argsFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.TrimSpace(string(args)); got != "-u capture-pane -p -t %5 -S -20" {
+		t.Errorf("tmux args = %q", got)
+	}
+}
+
+func TestCapturePane_PaneGone(t *testing.T) {
+	installFakeTmux(t, "", "can't find pane: %5", 1)
+
+	_, err := NewTmux().CapturePane("%5", 20)
+	if !errors.Is(err, ErrPaneNotFound) {
+		t.Fatalf("CapturePane error = %v, want ErrPaneNotFound", err)
+	}
+	if !strings.Contains(err.Error(), "%5") {
+		t.Errorf("error %q should name the pane", err)
+	}
+}
+
+func TestListGastownSessions_FakeTmux(t *testing.T) {
+	installFakeTmux(t, "hq-mayor\nscratch\ngt-witness\ngt-crew-max\nmain\n", "", 0)
+	isGT := func(name string) bool {
+		return strings.HasPrefix(name, "gt-") || strings.HasPrefix(name, "hq-")
+	}
+
+	got, err := NewTmux().ListGastownSessions(isGT)
+	if err != nil {
+		t.Fatalf("ListGastownSessions: %v", err)
+	}
+	want := []string{"gt-crew-max", "gt-witness", "hq-mayor"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("ListGastownSessions = %v, want %v", got, want)
+	}
+}
+
+func TestListGastownSessions_NoServer(t *testing.T) {
+	installFakeTmux(t, "", "no server running on /tmp/tmux-1000/default", 1)
+
+	got, err := NewTmux().ListGastownSessions(func(string) bool { return true })
+	if err != nil {
+		t.Fatalf("ListGastownSessions: %v", err)
+	}
+	if got == nil || len(got) != 0 {
+		t.Errorf("ListGastownSessions = %#v, want empty non-nil slice", got)
+	}
+}
+
+func isTestGTSession(name string) bool {
+	return strings.HasPrefix(name, "gt-") || strings.HasPrefix(name, "hq-")
+}
+
+func TestKillSessionGuarded_Success(t *testing.T) {
+	argsFile := installFakeTmux(t, "", "", 0)
+
+	if err := NewTmux().KillSessionGuarded("gt-witness", isTestGTSession, false); err != nil {
+		t.Fatalf("KillSessionGuarded: %v", err)
+	}
+	args, err := os.ReadFile(argsFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.TrimSpace(string(args)); got != "-u kill-session -t =gt-witness" {
+		t.Errorf("tmux args = %q, want an exact-match kill-session", got)
+	}
+}
+
+func TestKillSessionGuarded_MissingSession(t *testing.T) {
+	installFakeTmux(t, "", "can't find session: gt-witness", 1)
+
+	err := NewTmux().KillSessionGuarded("gt-witness", isTestGTSession, false)
+	if !errors.Is(err, ErrSessionNotFound) {
+		t.Fatalf("KillSessionGuarded error = %v, want ErrSessionNotFound", err)
+	}
+}
+
+func TestKillSessionGuarded_PrefixGuard(t *testing.T) {
+	argsFile := installFakeTmux(t, "", "", 0)
+
+	err := NewTmux().KillSessionGuarded("main", isTestGTSession, false)
+	if !errors.Is(err, ErrNotGastownSession) {
+		t.Fatalf("KillSessionGuarded error = %v, want ErrNotGastownSession", err)
+	}
+	if _, err := os.Stat(argsFile); !os.IsNotExist(err) {
+		t.Error("tmux was invoked for a non-Gas Town session")
+	}
+
+	if err := NewTmux().KillSessionGuarded("main", isTestGTSession, true); err != nil {
+		t.Fatalf("KillSessionGuarded with allowNonGastown: %v", err)
+	}
+}
+
+// fakeSessionChecker reports a session as existing from the appearOn-th poll.
+type fakeSessionChecker struct {
+	appearOn int
+	polls    int
+}
+
+func (f *fakeSessionChecker) HasSession(string) (bool, error) {
+	f.polls++
+	return f.appearOn > 0 && f.polls >= f.appearOn, nil
+}
+
+func TestWaitForSession_AppearsOnThirdPoll(t *testing.T) {
+	fake := &fakeSessionChecker{appearOn: 3}
+	if err := waitForSession(fake, "gt-witness", time.Second, time.Millisecond); err != nil {
+		t.Fatalf("waitForSession: %v", err)
+	}
+	if fake.polls != 3 {
+		t.Errorf("polls = %d, want 3", fake.polls)
+	}
+}
+
+func TestWaitForSession_Timeout(t *testing.T) {
+	fake := &fakeSessionChecker{}
+	err := waitForSession(fake, "gt-witness", 10*time.Millisecond, time.Millisecond)
+	if !errors.Is(err, ErrSessionNotFound) {
+		t.Fatalf("waitForSession error = %v, want ErrSessionNotFound", err)
+	}
+	if fake.polls < 2 {
+		t.Errorf("polls = %d, want polling until the deadline", fake.polls)
+	}
+}
+
+func TestRespawnPaneArgs_IncludesEnv(t *testing.T) {
+	env := map[string]string{"GT_RIG": "gastown", "GT_CREW": "max", "GT_ROLE": "gastown/crew/max"}
+	got := strings.Join(respawnPaneArgs("%3", "", "exec claude", env), " ")
+	want := "respawn-pane -k -t %3 -e GT_CREW=max -e GT_RIG=gastown -e GT_ROLE=gastown/crew/max exec claude"
+	if got != want {
+		t.Errorf("respawnPaneArgs() = %q, want %q", got, want)
+	}
+
+	got = strings.Join(respawnPaneArgs("%3", "/town", "exec claude", nil), " ")
+	if want := "respawn-pane -k -t %3 -c /town exec claude"; got != want {
+		t.Errorf("respawnPaneArgs() without env = %q, want %q", got, want)
+	}
+}
+
+func TestSessionEnv_FakeTmux(t *testing.T) {
+	argsFile := installFakeTmux(t, "GT_RIG=foo\n", "", 0)
+
+	got, err := NewTmux().SessionEnv("gt-crew-max", "GT_RIG")
+	if err != nil {
+		t.Fatalf("SessionEnv: %v", err)
+	}
+	if got != "foo" {
+		t.Errorf("SessionEnv = %q, want foo", got)
+	}
+	args, err := os.ReadFile(argsFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.TrimSpace(string(args)); got != "-u show-environment -t gt-crew-max GT_RIG" {
+		t.Errorf("tmux args = %q", got)
+	}
+}
+
+func TestSessionEnv_Unset(t *testing.T) {
+	installFakeTmux(t, "", "unknown variable: GT_CREW", 1)
+	if got, err := NewTmux().SessionEnv("gt-crew-max", "GT_CREW"); err != nil || got != "" {
+		t.Errorf("SessionEnv unknown variable = %q, %v; want empty, nil", got, err)
+	}
+
+	installFakeTmux(t, "-GT_CREW\n", "", 0)
+	if got, err := NewTmux().SessionEnv("gt-crew-max", "GT_CREW"); err != nil || got != "" {
+		t.Errorf("SessionEnv removed variable = %q, %v; want empty, nil", got, err)
+	}
+
+	installFakeTmux(t, "", "can't find session: gt-crew-max", 1)
+	if _, err := NewTmux().SessionEnv("gt-crew-max", "GT_CREW"); !errors.Is(err, ErrSessionNotFound) {
+		t.Errorf("SessionEnv missing session error = %v, want ErrSessionNotFound", err)
+	}
+}
+
+// TestRespawnPane_PaneGone pins the sentinel gt crew at relies on to detect a
+// stale session and recreate it.
+func TestRespawnPane_PaneGone(t *testing.T) {
+	installFakeTmux(t, "", "can't find pane: %5", 1)
+
+	err := NewTmux().RespawnPane("%5", "exec claude")
+	if !errors.Is(err, ErrPaneNotFound) {
+		t.Fatalf("RespawnPane error = %v, want ErrPaneNotFound", err)
+	}
+}
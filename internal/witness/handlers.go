@@ -72,6 +72,9 @@ type HandlerResult struct {
 // When work is done, the polecat transitions to idle state (no nuke).
 // The MR lifecycle continues independently in the Refinery.
 // If conflicts arise, Refinery creates a conflict-resolution task for an available polecat.
+//
+// Follow-up beads the polecat slung for its successor are slung to the rig,
+// whatever the exit type.
 func HandlePolecatDone(workDir, rigName string, msg *mail.Message, router *mail.Router) *HandlerResult {
 	result := &HandlerResult{
 		MessageID:    msg.ID,
@@ -90,6 +93,8 @@ func HandlePolecatDone(workDir, rigName string, msg *mail.Message, router *mail.
 		return result
 	}
 
+	defer dispatchFollowUps(workDir, rigName, payload, result)
+
 	if payload.Exit == "PHASE_COMPLETE" {
 		result.Handled = true
 		result.Action = fmt.Sprintf("phase-complete for %s (gate=%s) - session recycled, awaiting gate", payload.PolecatName, payload.Gate)
@@ -171,6 +176,39 @@ func handlePolecatDoneNoMR(workDir, rigName string, payload *PolecatDonePayload,
 	return result
 }
 
+// slingFollowUp slings a follow-up bead to the rig, which spawns a fresh
+// polecat for it. Overridable in tests.
+var slingFollowUp = func(workDir, rigName, beadID string) error {
+	return util.ExecRun(workDir, "gt", "sling", beadID, rigName)
+}
+
+// dispatchFollowUps slings each of the payload's follow-up beads to the rig
+// and notes the outcome on result. Failures are non-fatal for the rest of the
+// POLECAT_DONE handling but are reported so the beads aren't silently dropped.
+func dispatchFollowUps(workDir, rigName string, payload *PolecatDonePayload, result *HandlerResult) {
+	if len(payload.FollowUps) == 0 {
+		return
+	}
+	var slung, failed []string
+	for _, beadID := range payload.FollowUps {
+		if err := slingFollowUp(workDir, rigName, beadID); err != nil {
+			failed = append(failed, fmt.Sprintf("%s: %v", beadID, err))
+			continue
+		}
+		slung = append(slung, beadID)
+	}
+	if len(slung) > 0 {
+		result.Action += fmt.Sprintf("; follow-ups slung to %s: %s", rigName, strings.Join(slung, ", "))
+	}
+	if len(failed) > 0 {
+		err := fmt.Errorf("slinging follow-ups: %s", strings.Join(failed, "; "))
+		if result.Error != nil {
+			err = fmt.Errorf("%w (also: %v)", err, result.Error)
+		}
+		result.Error = err
+	}
+}
+
 func isStalePolecatDone(workDir, rigName, polecatName string, msg *mail.Message) (bool, string) {
 	if msg == nil {
 		return false, ""
@@ -9,6 +9,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/steveyegge/gastown/internal/mail"
 	"github.com/steveyegge/gastown/internal/tmux"
 )
 
@@ -1137,6 +1138,40 @@ func TestHandlePolecatDone_CompletedWithMRID(t *testing.T) {
 	}
 }
 
+func TestHandlePolecatDone_SlingsFollowUps(t *testing.T) {
+	var slung []string
+	orig := slingFollowUp
+	slingFollowUp = func(workDir, rigName, beadID string) error {
+		if beadID == "gt-bad" {
+			return fmt.Errorf("no such bead")
+		}
+		slung = append(slung, rigName+":"+beadID)
+		return nil
+	}
+	t.Cleanup(func() { slingFollowUp = orig })
+
+	msg := &mail.Message{
+		ID:      "msg-1",
+		Subject: "POLECAT_DONE nux",
+		Body:    "Exit: DEFERRED\nFollowUps: gt-def456, gt-bad, gt-ghi789",
+	}
+	result := HandlePolecatDone(t.TempDir(), "gastown", msg, nil)
+
+	if !result.Handled {
+		t.Fatalf("Handled = false, want true (action=%q, err=%v)", result.Action, result.Error)
+	}
+	want := []string{"gastown:gt-def456", "gastown:gt-ghi789"}
+	if strings.Join(slung, ",") != strings.Join(want, ",") {
+		t.Errorf("slung = %v, want %v", slung, want)
+	}
+	if !strings.Contains(result.Action, "follow-ups slung to gastown: gt-def456, gt-ghi789") {
+		t.Errorf("Action = %q, want it to list the slung follow-ups", result.Action)
+	}
+	if result.Error == nil || !strings.Contains(result.Error.Error(), "gt-bad") {
+		t.Errorf("Error = %v, want it to report the failed follow-up", result.Error)
+	}
+}
+
 func TestFindMRBeadForBranch_NoBdAvailable(t *testing.T) {
 	// When bd is not available, should return empty string
 	result := findMRBeadForBranch("/nonexistent", "polecat/nux-abc123")
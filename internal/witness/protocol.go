@@ -57,8 +57,9 @@ type PolecatDonePayload struct {
 	IssueID     string
 	MRID        string
 	Branch      string
-	Gate        string // Gate ID when Exit is PHASE_COMPLETE
-	MRFailed    bool   // True when MR bead creation was attempted but failed
+	Gate        string   // Gate ID when Exit is PHASE_COMPLETE
+	MRFailed    bool     // True when MR bead creation was attempted but failed
+	FollowUps   []string // Beads the polecat slung for its successor
 }
 
 // HelpPayload contains parsed data from a HELP message.
@@ -140,6 +141,7 @@ func ClassifyMessage(subject string) ProtocolType {
 //	MR: <mr-id>
 //	Gate: <gate-id>
 //	Branch: <branch>
+//	FollowUps: <bead-id>, <bead-id>, ...
 func ParsePolecatDone(subject, body string) (*PolecatDonePayload, error) {
 	matches := PatternPolecatDone.FindStringSubmatch(subject)
 	if len(matches) < 2 {
@@ -165,6 +167,12 @@ func ParsePolecatDone(subject, body string) (*PolecatDonePayload, error) {
 			payload.Branch = strings.TrimSpace(strings.TrimPrefix(line, "Branch:"))
 		} else if strings.HasPrefix(line, "MRFailed:") {
 			payload.MRFailed = strings.TrimSpace(strings.TrimPrefix(line, "MRFailed:")) == "true"
+		} else if strings.HasPrefix(line, "FollowUps:") {
+			for _, id := range strings.Split(strings.TrimPrefix(line, "FollowUps:"), ",") {
+				if id = strings.TrimSpace(id); id != "" {
+					payload.FollowUps = append(payload.FollowUps, id)
+				}
+			}
 		}
 	}
 
@@ -370,9 +378,9 @@ func SwarmWispLabels(swarmID string, total, completed int, startTime time.Time)
 
 // HelpAssessment represents the Witness's assessment of a help request.
 type HelpAssessment struct {
-	CanHelp     bool
-	HelpAction  string // What the Witness can do to help
-	NeedsEscalation bool
+	CanHelp          bool
+	HelpAction       string // What the Witness can do to help
+	NeedsEscalation  bool
 	EscalationReason string
 }
 
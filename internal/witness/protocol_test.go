@@ -1,6 +1,7 @@
 package witness
 
 import (
+	"strings"
 	"testing"
 )
 
@@ -93,6 +94,23 @@ func TestParsePolecatDone_InvalidSubject(t *testing.T) {
 	}
 }
 
+func TestParsePolecatDone_FollowUps(t *testing.T) {
+	subject := "POLECAT_DONE nux"
+	body := `Exit: COMPLETED
+Issue: gt-abc123
+FollowUps: gt-def456, gt-ghi789`
+
+	payload, err := ParsePolecatDone(subject, body)
+	if err != nil {
+		t.Fatalf("ParsePolecatDone() error = %v", err)
+	}
+
+	want := []string{"gt-def456", "gt-ghi789"}
+	if strings.Join(payload.FollowUps, ",") != strings.Join(want, ",") {
+		t.Errorf("FollowUps = %v, want %v", payload.FollowUps, want)
+	}
+}
+
 func TestParsePolecatDone_MRFailed(t *testing.T) {
 	subject := "POLECAT_DONE nux"
 	body := `Exit: COMPLETED